@@ -21,10 +21,30 @@ DROP TABLE IF EXISTS offline_transactions CASCADE;
 DROP TABLE IF EXISTS fare_matrix CASCADE;
 DROP TABLE IF EXISTS journeys CASCADE;
 DROP TABLE IF EXISTS transactions CASCADE;
+DROP TABLE IF EXISTS channel_partner CASCADE;
+DROP TABLE IF EXISTS gate_command CASCADE;
+DROP TABLE IF EXISTS gate_status_history CASCADE;
+DROP TABLE IF EXISTS gate_credential CASCADE;
 DROP TABLE IF EXISTS gates CASCADE;
+DROP TABLE IF EXISTS card_hotlist CASCADE;
 DROP TABLE IF EXISTS cards CASCADE;
+DROP TABLE IF EXISTS rider CASCADE;
+DROP TABLE IF EXISTS card_product CASCADE;
+DROP TABLE IF EXISTS terminal_closure CASCADE;
+DROP TABLE IF EXISTS terminal_operating_hour CASCADE;
 DROP TABLE IF EXISTS terminal CASCADE;
+DROP TABLE IF EXISTS audit_log CASCADE;
+DROP TABLE IF EXISTS login_throttle CASCADE;
+DROP TABLE IF EXISTS admin_2fa_enrollment CASCADE;
+DROP TABLE IF EXISTS admin_recovery_code CASCADE;
+DROP TABLE IF EXISTS admin_password_reset CASCADE;
+DROP TABLE IF EXISTS admin_password_history CASCADE;
+DROP TABLE IF EXISTS revoked_token CASCADE;
+DROP TABLE IF EXISTS admin_refresh_token CASCADE;
+DROP TABLE IF EXISTS admin_session CASCADE;
 DROP TABLE IF EXISTS admin CASCADE;
+DROP TABLE IF EXISTS role_permission CASCADE;
+DROP TABLE IF EXISTS role CASCADE;
 
 -- Drop custom types
 DROP TYPE IF EXISTS transaction_type_enum CASCADE;
@@ -35,21 +55,57 @@ DROP TYPE IF EXISTS offline_sync_status_enum CASCADE;
 -- ===============================================
 -- CREATE CUSTOM TYPES
 -- ===============================================
-CREATE TYPE transaction_type_enum AS ENUM ('checkin', 'checkout');
+CREATE TYPE transaction_type_enum AS ENUM ('checkin', 'checkout', 'topup', 'transfer_out', 'transfer_in');
 CREATE TYPE sync_status_enum AS ENUM ('synced', 'pending', 'error');
 CREATE TYPE journey_status_enum AS ENUM ('active', 'completed', 'incomplete', 'cancelled', 'penalty');
 CREATE TYPE offline_sync_status_enum AS ENUM ('pending', 'synced', 'error', 'conflict');
 
+-- ===============================================
+-- TABLE: role
+-- ===============================================
+CREATE TABLE role (
+    id_role BIGSERIAL PRIMARY KEY,
+    name VARCHAR(50) NOT NULL UNIQUE,
+    description VARCHAR(255) NULL,
+    require_2fa BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Add comment
+COMMENT ON TABLE role IS 'Peran admin (super admin, keuangan, supervisor terminal, dll)';
+COMMENT ON COLUMN role.name IS 'Nama unik peran';
+COMMENT ON COLUMN role.require_2fa IS 'Admin dengan peran ini wajib memakai autentikasi dua faktor';
+
+-- ===============================================
+-- TABLE: role_permission
+-- ===============================================
+CREATE TABLE role_permission (
+    id_role BIGINT NOT NULL REFERENCES role(id_role) ON DELETE CASCADE,
+    permission VARCHAR(50) NOT NULL,
+    PRIMARY KEY (id_role, permission)
+);
+
+-- Add comment
+COMMENT ON TABLE role_permission IS 'Hak akses yang dimiliki setiap peran';
+COMMENT ON COLUMN role_permission.permission IS 'Kode hak akses (terminal:write, fare:read, * = semua)';
+
 -- ===============================================
 -- TABLE: admin
 -- ===============================================
 CREATE TABLE admin (
     id_admin BIGSERIAL PRIMARY KEY,
     name VARCHAR(100) NOT NULL,
-    username VARCHAR(100) NOT NULL UNIQUE,
+    username VARCHAR(100) NOT NULL,
     password VARCHAR(100) NOT NULL,
+    id_role BIGINT NOT NULL REFERENCES role(id_role),
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    totp_secret VARCHAR(64) NULL,
+    totp_enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    totp_last_step BIGINT NOT NULL DEFAULT 0,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL
 );
 
 -- Add comment
@@ -58,6 +114,182 @@ COMMENT ON COLUMN admin.id_admin IS 'ID unik admin';
 COMMENT ON COLUMN admin.name IS 'Nama lengkap admin';
 COMMENT ON COLUMN admin.username IS 'Username untuk login';
 COMMENT ON COLUMN admin.password IS 'Password yang sudah di-hash';
+COMMENT ON COLUMN admin.id_role IS 'Peran admin';
+COMMENT ON COLUMN admin.is_active IS 'Akun aktif (FALSE = dinonaktifkan, tidak bisa login)';
+COMMENT ON COLUMN admin.totp_secret IS 'Secret TOTP (base32), terisi sejak setup 2FA';
+COMMENT ON COLUMN admin.totp_enabled IS 'Autentikasi dua faktor aktif';
+COMMENT ON COLUMN admin.totp_last_step IS 'Time step TOTP terakhir yang dipakai (mencegah kode dipakai ulang)';
+COMMENT ON COLUMN admin.deleted_at IS 'Waktu soft delete (NULL = aktif)';
+
+-- ===============================================
+-- TABLE: admin_recovery_code
+-- ===============================================
+CREATE TABLE admin_recovery_code (
+    id BIGSERIAL PRIMARY KEY,
+    id_admin BIGINT NOT NULL REFERENCES admin(id_admin) ON DELETE CASCADE,
+    code_hash VARCHAR(64) NOT NULL,
+    used_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Add comment
+COMMENT ON TABLE admin_recovery_code IS 'Kode pemulihan 2FA sekali pakai';
+COMMENT ON COLUMN admin_recovery_code.code_hash IS 'SHA-256 dari kode pemulihan';
+COMMENT ON COLUMN admin_recovery_code.used_at IS 'Waktu kode dipakai (NULL = belum dipakai)';
+
+-- ===============================================
+-- TABLE: admin_2fa_enrollment
+-- ===============================================
+CREATE TABLE admin_2fa_enrollment (
+    id BIGSERIAL PRIMARY KEY,
+    id_admin BIGINT NOT NULL REFERENCES admin(id_admin) ON DELETE CASCADE,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    created_by BIGINT NOT NULL REFERENCES admin(id_admin) ON DELETE CASCADE,
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Add comment
+COMMENT ON TABLE admin_2fa_enrollment IS 'Token pendaftaran 2FA sekali pakai yang dibuat oleh admin lain';
+COMMENT ON COLUMN admin_2fa_enrollment.token_hash IS 'SHA-256 dari token pendaftaran';
+COMMENT ON COLUMN admin_2fa_enrollment.created_by IS 'Admin yang membuat token pendaftaran';
+COMMENT ON COLUMN admin_2fa_enrollment.used_at IS 'Waktu token dipakai atau dibatalkan (NULL = masih berlaku)';
+
+-- ===============================================
+-- TABLE: admin_session
+-- ===============================================
+CREATE TABLE admin_session (
+    id_session VARCHAR(36) PRIMARY KEY,
+    id_admin BIGINT NOT NULL REFERENCES admin(id_admin) ON DELETE CASCADE,
+    ip_address VARCHAR(45) NULL,
+    user_agent VARCHAR(255) NULL,
+    expires_at TIMESTAMP NOT NULL,
+    revoked_at TIMESTAMP NULL,
+    revoked_reason VARCHAR(50) NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Add comment
+COMMENT ON TABLE admin_session IS 'Sesi login admin (satu keluarga refresh token per login)';
+COMMENT ON COLUMN admin_session.id_session IS 'UUID sesi';
+COMMENT ON COLUMN admin_session.expires_at IS 'Waktu sesi berakhir, diperpanjang setiap refresh';
+COMMENT ON COLUMN admin_session.revoked_at IS 'Waktu sesi dicabut (NULL = aktif)';
+COMMENT ON COLUMN admin_session.revoked_reason IS 'Alasan pencabutan sesi (logout, refresh_token_reuse, dll)';
+
+-- ===============================================
+-- TABLE: admin_refresh_token
+-- ===============================================
+CREATE TABLE admin_refresh_token (
+    id_refresh_token BIGSERIAL PRIMARY KEY,
+    id_session VARCHAR(36) NOT NULL REFERENCES admin_session(id_session) ON DELETE CASCADE,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Add comment
+COMMENT ON TABLE admin_refresh_token IS 'Refresh token admin, dirotasi setiap kali dipakai';
+COMMENT ON COLUMN admin_refresh_token.token_hash IS 'SHA-256 dari refresh token';
+COMMENT ON COLUMN admin_refresh_token.used_at IS 'Waktu token ditukar (NULL = belum dipakai)';
+
+-- ===============================================
+-- TABLE: revoked_token
+-- ===============================================
+CREATE TABLE revoked_token (
+    jti VARCHAR(36) PRIMARY KEY,
+    id_admin BIGINT NOT NULL REFERENCES admin(id_admin) ON DELETE CASCADE,
+    expires_at TIMESTAMP NOT NULL,
+    revoked_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Add comment
+COMMENT ON TABLE revoked_token IS 'Daftar access token (jti) yang dicabut sebelum kedaluwarsa';
+COMMENT ON COLUMN revoked_token.jti IS 'ID unik access token (klaim jti)';
+COMMENT ON COLUMN revoked_token.expires_at IS 'Waktu kedaluwarsa token, baris boleh dihapus setelahnya';
+
+-- ===============================================
+-- TABLE: admin_password_history
+-- ===============================================
+CREATE TABLE admin_password_history (
+    id BIGSERIAL PRIMARY KEY,
+    id_admin BIGINT NOT NULL REFERENCES admin(id_admin) ON DELETE CASCADE,
+    password_hash VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Add comment
+COMMENT ON TABLE admin_password_history IS 'Riwayat hash password admin untuk mencegah pemakaian ulang';
+
+-- ===============================================
+-- TABLE: admin_password_reset
+-- ===============================================
+CREATE TABLE admin_password_reset (
+    id BIGSERIAL PRIMARY KEY,
+    id_admin BIGINT NOT NULL REFERENCES admin(id_admin) ON DELETE CASCADE,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    created_by BIGINT NOT NULL REFERENCES admin(id_admin) ON DELETE CASCADE,
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Add comment
+COMMENT ON TABLE admin_password_reset IS 'Token reset password sekali pakai yang dibuat oleh admin lain';
+COMMENT ON COLUMN admin_password_reset.token_hash IS 'SHA-256 dari token reset';
+COMMENT ON COLUMN admin_password_reset.created_by IS 'Admin yang membuat token reset';
+COMMENT ON COLUMN admin_password_reset.used_at IS 'Waktu token dipakai atau dibatalkan (NULL = masih berlaku)';
+
+-- ===============================================
+-- TABLE: login_throttle
+-- ===============================================
+CREATE TABLE login_throttle (
+    throttle_key VARCHAR(150) PRIMARY KEY,
+    failed_count INTEGER NOT NULL DEFAULT 0,
+    last_failed_at TIMESTAMP NOT NULL,
+    locked_until TIMESTAMP NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Add comment
+COMMENT ON TABLE login_throttle IS 'Percobaan login gagal per username dan per IP untuk proteksi brute-force';
+COMMENT ON COLUMN login_throttle.throttle_key IS 'Kunci throttle (username:<nama> atau ip:<alamat>)';
+COMMENT ON COLUMN login_throttle.locked_until IS 'Terkunci sampai waktu ini (NULL = tidak terkunci)';
+
+-- Add check constraint
+ALTER TABLE login_throttle ADD CONSTRAINT chk_login_throttle_failed_count CHECK (failed_count >= 0);
+
+-- ===============================================
+-- TABLE: audit_log
+-- ===============================================
+CREATE TABLE audit_log (
+    id_audit_log BIGSERIAL PRIMARY KEY,
+    id_admin BIGINT NULL,
+    username VARCHAR(100) NULL,
+    action VARCHAR(100) NOT NULL,
+    entity_type VARCHAR(50) NULL,
+    entity_id VARCHAR(64) NULL,
+    before_data JSONB NULL,
+    after_data JSONB NULL,
+    success BOOLEAN NOT NULL,
+    status_code INTEGER NOT NULL,
+    method VARCHAR(10) NOT NULL,
+    path VARCHAR(255) NOT NULL,
+    ip_address VARCHAR(45) NULL,
+    user_agent VARCHAR(255) NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Add comment
+COMMENT ON TABLE audit_log IS 'Jejak audit aksi admin (append-only, tidak boleh diubah atau dihapus)';
+COMMENT ON COLUMN audit_log.id_admin IS 'Admin pelaku (tanpa foreign key agar log tetap ada setelah admin dihapus)';
+COMMENT ON COLUMN audit_log.username IS 'Username pelaku, termasuk username yang dicoba saat login gagal';
+COMMENT ON COLUMN audit_log.action IS 'Nama aksi (auth.login, admin.update, dll)';
+COMMENT ON COLUMN audit_log.before_data IS 'Field yang berubah, nilai sebelum aksi';
+COMMENT ON COLUMN audit_log.after_data IS 'Field yang berubah, nilai sesudah aksi';
+COMMENT ON COLUMN audit_log.success IS 'Aksi berhasil (status HTTP < 400)';
 
 -- ===============================================
 -- TABLE: terminal
@@ -66,8 +298,13 @@ CREATE TABLE terminal (
     id_terminal BIGSERIAL PRIMARY KEY,
     name VARCHAR(100) NOT NULL,
     location VARCHAR(100) NOT NULL,
+    address VARCHAR(255) NULL,
+    region_code VARCHAR(20) NULL,
+    latitude NUMERIC(9,6) NULL,
+    longitude NUMERIC(9,6) NULL,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL
 );
 
 -- Add comment
@@ -75,6 +312,109 @@ COMMENT ON TABLE terminal IS 'Master data terminal transportasi publik';
 COMMENT ON COLUMN terminal.id_terminal IS 'ID unik terminal';
 COMMENT ON COLUMN terminal.name IS 'Nama terminal';
 COMMENT ON COLUMN terminal.location IS 'Lokasi terminal';
+COMMENT ON COLUMN terminal.address IS 'Alamat lengkap terminal';
+COMMENT ON COLUMN terminal.region_code IS 'Kode wilayah (kode BPS/Kemendagri)';
+COMMENT ON COLUMN terminal.latitude IS 'Lintang (WGS84, -90 s.d. 90)';
+COMMENT ON COLUMN terminal.longitude IS 'Bujur (WGS84, -180 s.d. 180)';
+COMMENT ON COLUMN terminal.deleted_at IS 'Waktu soft delete (NULL = aktif)';
+
+-- Add check constraints
+ALTER TABLE terminal ADD CONSTRAINT chk_terminal_latitude CHECK (latitude BETWEEN -90 AND 90);
+ALTER TABLE terminal ADD CONSTRAINT chk_terminal_longitude CHECK (longitude BETWEEN -180 AND 180);
+ALTER TABLE terminal ADD CONSTRAINT chk_terminal_coordinates CHECK ((latitude IS NULL) = (longitude IS NULL));
+
+-- ===============================================
+-- TABLE: terminal_operating_hour
+-- ===============================================
+CREATE TABLE terminal_operating_hour (
+    id_operating_hour BIGSERIAL PRIMARY KEY,
+    id_terminal BIGINT NOT NULL REFERENCES terminal(id_terminal) ON DELETE CASCADE,
+    day_of_week SMALLINT NOT NULL,
+    open_minute SMALLINT NOT NULL,
+    close_minute SMALLINT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Add comment
+COMMENT ON TABLE terminal_operating_hour IS 'Jam operasional mingguan terminal (tanpa baris = buka 24 jam)';
+COMMENT ON COLUMN terminal_operating_hour.day_of_week IS 'Hari (0 = Minggu s.d. 6 = Sabtu)';
+COMMENT ON COLUMN terminal_operating_hour.open_minute IS 'Jam buka, menit sejak tengah malam waktu lokal';
+COMMENT ON COLUMN terminal_operating_hour.close_minute IS 'Jam tutup, menit sejak tengah malam (1440 = akhir hari)';
+
+-- Add check constraints
+ALTER TABLE terminal_operating_hour ADD CONSTRAINT chk_operating_hour_day CHECK (day_of_week BETWEEN 0 AND 6);
+ALTER TABLE terminal_operating_hour ADD CONSTRAINT chk_operating_hour_range CHECK (open_minute >= 0 AND close_minute <= 1440 AND open_minute < close_minute);
+
+-- ===============================================
+-- TABLE: terminal_closure
+-- ===============================================
+CREATE TABLE terminal_closure (
+    id_closure BIGSERIAL PRIMARY KEY,
+    id_terminal BIGINT NOT NULL REFERENCES terminal(id_terminal) ON DELETE CASCADE,
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    reason VARCHAR(255) NOT NULL,
+    created_by BIGINT NOT NULL REFERENCES admin(id_admin),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Add comment
+COMMENT ON TABLE terminal_closure IS 'Penutupan terminal (libur/perbaikan) per rentang tanggal';
+COMMENT ON COLUMN terminal_closure.start_date IS 'Tanggal mulai tutup (inklusif, waktu lokal)';
+COMMENT ON COLUMN terminal_closure.end_date IS 'Tanggal selesai tutup (inklusif, waktu lokal)';
+COMMENT ON COLUMN terminal_closure.reason IS 'Alasan penutupan';
+
+-- Add check constraint
+ALTER TABLE terminal_closure ADD CONSTRAINT chk_closure_range CHECK (end_date >= start_date);
+
+-- ===============================================
+-- TABLE: rider
+-- ===============================================
+CREATE TABLE rider (
+    id_rider BIGSERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    id_number VARCHAR(30) NOT NULL UNIQUE,
+    phone VARCHAR(20) NULL,
+    email VARCHAR(100) NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Add comment
+COMMENT ON TABLE rider IS 'Identitas penumpang pemilik kartu terdaftar';
+COMMENT ON COLUMN rider.id_number IS 'Nomor identitas (NIK/paspor)';
+
+-- ===============================================
+-- TABLE: card_product
+-- ===============================================
+CREATE TABLE card_product (
+    id_product SERIAL PRIMARY KEY,
+    code VARCHAR(30) NOT NULL UNIQUE,
+    name VARCHAR(100) NOT NULL,
+    concession BOOLEAN NOT NULL DEFAULT FALSE,
+    discount_type VARCHAR(20) NOT NULL,
+    discount_value DECIMAL(8,2) NOT NULL DEFAULT 0,
+    valid_from DATE NOT NULL DEFAULT CURRENT_DATE,
+    valid_until DATE NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Add comment
+COMMENT ON TABLE card_product IS 'Jenis kartu (pelajar, lansia, disabilitas, staf) beserta aturan diskon tarif';
+COMMENT ON COLUMN card_product.concession IS 'Termasuk tarif konsesi untuk pelaporan';
+COMMENT ON COLUMN card_product.discount_type IS 'Jenis diskon (percentage, fixed, free)';
+COMMENT ON COLUMN card_product.discount_value IS 'Persentase diskon atau potongan dalam rupiah';
+COMMENT ON COLUMN card_product.valid_from IS 'Tanggal mulai berlaku';
+COMMENT ON COLUMN card_product.valid_until IS 'Tanggal berakhir (NULL = tanpa batas)';
+
+-- Add check constraints
+ALTER TABLE card_product ADD CONSTRAINT chk_card_product_discount CHECK (
+    (discount_type = 'percentage' AND discount_value > 0 AND discount_value <= 100)
+    OR (discount_type = 'fixed' AND discount_value > 0)
+    OR (discount_type = 'free' AND discount_value = 0)
+);
+ALTER TABLE card_product ADD CONSTRAINT chk_card_product_dates CHECK (valid_until IS NULL OR valid_until >= valid_from);
 
 -- ===============================================
 -- TABLE: cards
@@ -83,6 +423,12 @@ CREATE TABLE cards (
     card_number BIGSERIAL PRIMARY KEY,
     balance DECIMAL(12,2) DEFAULT 0,
     status VARCHAR(20) DEFAULT 'active',
+    id_product INTEGER NULL REFERENCES card_product(id_product),
+    block_reason VARCHAR(20) NULL,
+    block_note VARCHAR(255) NULL,
+    blocked_at TIMESTAMP NULL,
+    id_rider BIGINT NULL REFERENCES rider(id_rider),
+    replaced_by BIGINT NULL REFERENCES cards(card_number),
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 );
@@ -92,10 +438,42 @@ COMMENT ON TABLE cards IS 'Master data kartu e-ticketing';
 COMMENT ON COLUMN cards.card_number IS 'Nomor kartu unik';
 COMMENT ON COLUMN cards.balance IS 'Saldo kartu dalam rupiah';
 COMMENT ON COLUMN cards.status IS 'Status kartu (active, blocked, expired)';
+COMMENT ON COLUMN cards.id_product IS 'Jenis kartu (NULL = tarif reguler)';
+COMMENT ON COLUMN cards.block_reason IS 'Alasan pemblokiran (lost, stolen, fraud, damaged, other)';
+COMMENT ON COLUMN cards.block_note IS 'Catatan pemblokiran';
+COMMENT ON COLUMN cards.blocked_at IS 'Waktu kartu diblokir';
+COMMENT ON COLUMN cards.id_rider IS 'Penumpang pemilik kartu (NULL = tidak terdaftar)';
+COMMENT ON COLUMN cards.replaced_by IS 'Kartu pengganti yang menerima saldo kartu ini';
 
 -- Add check constraints
 ALTER TABLE cards ADD CONSTRAINT chk_cards_balance CHECK (balance >= 0);
 ALTER TABLE cards ADD CONSTRAINT chk_cards_status CHECK (status IN ('active', 'blocked', 'expired'));
+ALTER TABLE cards ADD CONSTRAINT chk_cards_replaced CHECK (replaced_by IS NULL OR (replaced_by <> card_number AND balance = 0));
+ALTER TABLE cards ADD CONSTRAINT chk_cards_block_reason CHECK (
+    (status = 'blocked') = (block_reason IS NOT NULL)
+    AND (block_reason IS NULL OR block_reason IN ('lost', 'stolen', 'fraud', 'damaged', 'other'))
+);
+
+-- ===============================================
+-- TABLE: card_hotlist
+-- ===============================================
+CREATE TABLE card_hotlist (
+    id_version BIGSERIAL PRIMARY KEY,
+    card_number BIGINT NOT NULL REFERENCES cards(card_number),
+    action VARCHAR(10) NOT NULL,
+    reason VARCHAR(20) NULL,
+    created_by BIGINT NOT NULL REFERENCES admin(id_admin),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Add comment
+COMMENT ON TABLE card_hotlist IS 'Log perubahan daftar hitam kartu yang diunduh gate';
+COMMENT ON COLUMN card_hotlist.id_version IS 'Versi hotlist, gate mengunduh perubahan setelah versi yang dimiliki';
+COMMENT ON COLUMN card_hotlist.action IS 'Perubahan (add, remove)';
+COMMENT ON COLUMN card_hotlist.reason IS 'Alasan pemblokiran (lost, stolen, fraud, damaged, other)';
+
+-- Add check constraint
+ALTER TABLE card_hotlist ADD CONSTRAINT chk_card_hotlist_action CHECK (action IN ('add', 'remove'));
 
 -- ===============================================
 -- TABLE: gates
@@ -105,6 +483,12 @@ CREATE TABLE gates (
     id_terminal BIGINT NOT NULL REFERENCES terminal(id_terminal) ON DELETE CASCADE,
     gate_number VARCHAR(50) NOT NULL,
     status VARCHAR(20) DEFAULT 'offline',
+    status_changed_at TIMESTAMP NULL,
+    last_heartbeat_at TIMESTAMP NULL,
+    firmware_version VARCHAR(50) NULL,
+    clock_offset_ms BIGINT NULL,
+    pending_offline_count INTEGER NOT NULL DEFAULT 0,
+    decommissioned_at TIMESTAMP NULL,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 );
@@ -114,10 +498,99 @@ COMMENT ON TABLE gates IS 'Gate/gerbang validasi di setiap terminal';
 COMMENT ON COLUMN gates.id_gates IS 'ID unik gate';
 COMMENT ON COLUMN gates.id_terminal IS 'ID terminal tempat gate berada';
 COMMENT ON COLUMN gates.gate_number IS 'Nomor gate (A1, A2, dll)';
-COMMENT ON COLUMN gates.status IS 'Status gate (online, offline, error, maintenance)';
+COMMENT ON COLUMN gates.status IS 'Status gate (online, offline, error, maintenance, decommissioned)';
+COMMENT ON COLUMN gates.status_changed_at IS 'Waktu status gate terakhir berubah';
+COMMENT ON COLUMN gates.last_heartbeat_at IS 'Waktu heartbeat terakhir dari gate';
+COMMENT ON COLUMN gates.firmware_version IS 'Versi firmware yang dilaporkan gate';
+COMMENT ON COLUMN gates.clock_offset_ms IS 'Selisih jam gate terhadap server dalam milidetik (positif = gate lebih cepat)';
+COMMENT ON COLUMN gates.pending_offline_count IS 'Jumlah transaksi offline yang belum tersinkron di gate';
+COMMENT ON COLUMN gates.decommissioned_at IS 'Waktu gate dinonaktifkan permanen (NULL = masih beroperasi)';
 
 -- Add check constraint
-ALTER TABLE gates ADD CONSTRAINT chk_gates_status CHECK (status IN ('online', 'offline', 'error', 'maintenance'));
+ALTER TABLE gates ADD CONSTRAINT chk_gates_status CHECK (status IN ('online', 'offline', 'error', 'maintenance', 'decommissioned'));
+ALTER TABLE gates ADD CONSTRAINT chk_gates_pending_offline_count CHECK (pending_offline_count >= 0);
+
+-- ===============================================
+-- TABLE: gate_status_history
+-- ===============================================
+CREATE TABLE gate_status_history (
+    id_status_history BIGSERIAL PRIMARY KEY,
+    id_gates INTEGER NOT NULL REFERENCES gates(id_gates) ON DELETE CASCADE,
+    from_status VARCHAR(20) NOT NULL,
+    to_status VARCHAR(20) NOT NULL,
+    reason VARCHAR(20) NOT NULL,
+    changed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Add comment
+COMMENT ON TABLE gate_status_history IS 'Riwayat perubahan status gate';
+COMMENT ON COLUMN gate_status_history.from_status IS 'Status gate sebelum perubahan';
+COMMENT ON COLUMN gate_status_history.to_status IS 'Status gate setelah perubahan';
+COMMENT ON COLUMN gate_status_history.reason IS 'Penyebab perubahan (heartbeat, silence, decommission)';
+
+-- Add check constraint
+ALTER TABLE gate_status_history ADD CONSTRAINT chk_gate_status_history_reason CHECK (reason IN ('heartbeat', 'silence', 'decommission'));
+
+-- ===============================================
+-- TABLE: gate_command
+-- ===============================================
+CREATE TABLE gate_command (
+    id_command BIGSERIAL PRIMARY KEY,
+    id_gates INTEGER NOT NULL REFERENCES gates(id_gates) ON DELETE CASCADE,
+    command VARCHAR(30) NOT NULL,
+    payload JSONB NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    result VARCHAR(255) NULL,
+    issued_by BIGINT NOT NULL REFERENCES admin(id_admin),
+    expires_at TIMESTAMP NOT NULL,
+    delivered_at TIMESTAMP NULL,
+    completed_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Add comment
+COMMENT ON TABLE gate_command IS 'Antrian perintah jarak jauh untuk gate';
+COMMENT ON COLUMN gate_command.command IS 'Jenis perintah (open, emergency_mode, reload_config, flush_offline_queue)';
+COMMENT ON COLUMN gate_command.payload IS 'Parameter perintah, misalnya {"enabled": true} untuk emergency_mode';
+COMMENT ON COLUMN gate_command.status IS 'Status perintah (pending, delivered, acknowledged, failed)';
+COMMENT ON COLUMN gate_command.result IS 'Hasil yang dilaporkan gate, atau expired/cancelled';
+COMMENT ON COLUMN gate_command.issued_by IS 'Admin yang mengirim perintah';
+COMMENT ON COLUMN gate_command.expires_at IS 'Batas waktu perintah dikirim ke gate';
+
+-- Add check constraints
+ALTER TABLE gate_command ADD CONSTRAINT chk_gate_command_command CHECK (command IN ('open', 'emergency_mode', 'reload_config', 'flush_offline_queue'));
+ALTER TABLE gate_command ADD CONSTRAINT chk_gate_command_status CHECK (status IN ('pending', 'delivered', 'acknowledged', 'failed'));
+
+-- ===============================================
+-- TABLE: gate_credential
+-- ===============================================
+CREATE TABLE gate_credential (
+    id_credential VARCHAR(36) PRIMARY KEY,
+    id_gates INTEGER NOT NULL REFERENCES gates(id_gates) ON DELETE CASCADE,
+    auth_type VARCHAR(20) NOT NULL,
+    key_hash VARCHAR(64) NULL,
+    secret VARCHAR(128) NULL,
+    created_by BIGINT NOT NULL REFERENCES admin(id_admin),
+    expires_at TIMESTAMP NULL,
+    revoked_at TIMESTAMP NULL,
+    last_used_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Add comment
+COMMENT ON TABLE gate_credential IS 'Kredensial autentikasi perangkat gate (API key atau HMAC)';
+COMMENT ON COLUMN gate_credential.id_credential IS 'ID kredensial, dipakai sebagai key id oleh gate';
+COMMENT ON COLUMN gate_credential.auth_type IS 'Jenis kredensial (api_key, hmac)';
+COMMENT ON COLUMN gate_credential.key_hash IS 'SHA-256 dari API key (khusus api_key)';
+COMMENT ON COLUMN gate_credential.secret IS 'Secret untuk tanda tangan HMAC (khusus hmac)';
+COMMENT ON COLUMN gate_credential.expires_at IS 'Berlaku sampai waktu ini setelah rotasi (NULL = tanpa batas)';
+COMMENT ON COLUMN gate_credential.revoked_at IS 'Waktu kredensial dicabut (NULL = belum dicabut)';
+
+-- Add check constraints
+ALTER TABLE gate_credential ADD CONSTRAINT chk_gate_credential_auth_type CHECK (auth_type IN ('api_key', 'hmac'));
+ALTER TABLE gate_credential ADD CONSTRAINT chk_gate_credential_material CHECK (
+    (auth_type = 'api_key' AND key_hash IS NOT NULL) OR (auth_type = 'hmac' AND secret IS NOT NULL)
+);
 
 -- ===============================================
 -- TABLE: fare_matrix
@@ -158,6 +631,8 @@ CREATE TABLE journeys (
     checkout_gate INTEGER NULL REFERENCES gates(id_gates),
     checkin_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
     checkout_time TIMESTAMP NULL,
+    id_product INTEGER NULL REFERENCES card_product(id_product),
+    regular_fare DECIMAL(8,2) NULL,
     fare_charged DECIMAL(8,2) NULL,
     max_fare_held DECIMAL(8,2) NOT NULL,
     journey_status journey_status_enum NOT NULL DEFAULT 'active',
@@ -175,15 +650,36 @@ COMMENT ON COLUMN journeys.origin_terminal IS 'Terminal asal';
 COMMENT ON COLUMN journeys.destination_terminal IS 'Terminal tujuan';
 COMMENT ON COLUMN journeys.checkin_time IS 'Waktu check-in';
 COMMENT ON COLUMN journeys.checkout_time IS 'Waktu check-out';
+COMMENT ON COLUMN journeys.id_product IS 'Jenis kartu yang diskonnya berlaku untuk perjalanan';
+COMMENT ON COLUMN journeys.regular_fare IS 'Tarif reguler sebelum diskon';
 COMMENT ON COLUMN journeys.fare_charged IS 'Tarif yang dikenakan';
 COMMENT ON COLUMN journeys.max_fare_held IS 'Tarif maksimum yang di-hold saat checkin';
 COMMENT ON COLUMN journeys.travel_duration IS 'Durasi perjalanan dalam menit';
 
 -- Add check constraints
 ALTER TABLE journeys ADD CONSTRAINT chk_journey_fare_positive CHECK (fare_charged IS NULL OR fare_charged >= 0);
-ALTER TABLE journeys ADD CONSTRAINT chk_journey_max_fare_positive CHECK (max_fare_held > 0);
+ALTER TABLE journeys ADD CONSTRAINT chk_journey_max_fare_positive CHECK (max_fare_held >= 0);
 ALTER TABLE journeys ADD CONSTRAINT chk_journey_checkout_after_checkin CHECK (checkout_time IS NULL OR checkout_time >= checkin_time);
 
+-- ===============================================
+-- TABLE: channel_partner
+-- ===============================================
+CREATE TABLE channel_partner (
+    id_partner VARCHAR(36) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    key_hash VARCHAR(64) NOT NULL,
+    created_by BIGINT NOT NULL REFERENCES admin(id_admin),
+    disabled_at TIMESTAMP NULL,
+    last_used_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Add comment
+COMMENT ON TABLE channel_partner IS 'Mitra kanal penjualan (bank, retail) yang dapat melakukan topup kartu';
+COMMENT ON COLUMN channel_partner.id_partner IS 'ID mitra, dipakai sebagai awalan API key';
+COMMENT ON COLUMN channel_partner.key_hash IS 'SHA-256 dari API key';
+COMMENT ON COLUMN channel_partner.disabled_at IS 'Waktu mitra dinonaktifkan (NULL = aktif)';
+
 -- ===============================================
 -- TABLE: transactions
 -- ===============================================
@@ -198,6 +694,7 @@ CREATE TABLE transactions (
     id_gates INTEGER NULL REFERENCES gates(id_gates),
     id_terminal BIGINT NULL REFERENCES terminal(id_terminal),
     reference_number VARCHAR(50) NULL,
+    id_partner VARCHAR(36) NULL REFERENCES channel_partner(id_partner),
     timestamp TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
     sync_status sync_status_enum NOT NULL DEFAULT 'synced',
     offline_created BOOLEAN NOT NULL DEFAULT FALSE,
@@ -215,6 +712,7 @@ COMMENT ON COLUMN transactions.balance_before IS 'Saldo sebelum transaksi';
 COMMENT ON COLUMN transactions.balance_after IS 'Saldo setelah transaksi';
 COMMENT ON COLUMN transactions.id_gates IS 'Gate tempat transaksi';
 COMMENT ON COLUMN transactions.reference_number IS 'Nomor referensi untuk topup/refund';
+COMMENT ON COLUMN transactions.id_partner IS 'Mitra yang melakukan topup (NULL = admin)';
 COMMENT ON COLUMN transactions.hash_signature IS 'Hash untuk validasi integritas data';
 
 -- Add check constraints
@@ -254,22 +752,53 @@ ALTER TABLE offline_transactions ADD CONSTRAINT chk_offline_sync_attempts CHECK
 -- ===============================================
 
 -- Admin indexes
-CREATE INDEX idx_admin_username ON admin(username);
+CREATE UNIQUE INDEX idx_admin_username ON admin(username) WHERE deleted_at IS NULL;
+CREATE INDEX idx_admin_deleted_at ON admin(deleted_at);
 CREATE INDEX idx_admin_created_at ON admin(created_at);
+CREATE INDEX idx_admin_role ON admin(id_role);
+
+-- Admin session indexes
+CREATE INDEX idx_admin_session_admin ON admin_session(id_admin);
+CREATE INDEX idx_admin_refresh_token_session ON admin_refresh_token(id_session);
+CREATE INDEX idx_revoked_token_expires_at ON revoked_token(expires_at);
+CREATE INDEX idx_admin_password_history_admin ON admin_password_history(id_admin, created_at);
+CREATE INDEX idx_admin_password_reset_admin ON admin_password_reset(id_admin);
+CREATE INDEX idx_admin_recovery_code_admin ON admin_recovery_code(id_admin);
+CREATE INDEX idx_admin_2fa_enrollment_admin ON admin_2fa_enrollment(id_admin);
+
+-- Audit log indexes
+CREATE INDEX idx_audit_log_created_at ON audit_log(created_at);
+CREATE INDEX idx_audit_log_admin ON audit_log(id_admin, created_at);
+CREATE INDEX idx_audit_log_action ON audit_log(action, created_at);
+CREATE INDEX idx_audit_log_entity ON audit_log(entity_type, entity_id);
 
 -- Terminal indexes
 CREATE INDEX idx_terminal_name ON terminal(name);
 CREATE INDEX idx_terminal_location ON terminal(location);
+CREATE INDEX idx_terminal_deleted_at ON terminal(deleted_at);
+CREATE INDEX idx_terminal_coordinates ON terminal(latitude, longitude) WHERE latitude IS NOT NULL;
+CREATE INDEX idx_operating_hour_terminal ON terminal_operating_hour(id_terminal, day_of_week);
+CREATE INDEX idx_closure_terminal_range ON terminal_closure(id_terminal, start_date, end_date);
 
 -- Cards indexes
 CREATE INDEX idx_cards_status ON cards(status);
 CREATE INDEX idx_cards_balance ON cards(balance);
 CREATE INDEX idx_cards_created_at ON cards(created_at);
+CREATE INDEX idx_cards_rider ON cards(id_rider);
+
+-- Card hotlist indexes
+CREATE INDEX idx_card_hotlist_card ON card_hotlist(card_number, id_version);
 
 -- Gates indexes
 CREATE INDEX idx_gates_terminal ON gates(id_terminal);
 CREATE INDEX idx_gates_status ON gates(status);
 CREATE INDEX idx_gates_gate_number ON gates(gate_number);
+CREATE UNIQUE INDEX uq_gates_terminal_number ON gates(id_terminal, gate_number) WHERE decommissioned_at IS NULL;
+CREATE INDEX idx_gates_last_heartbeat ON gates(last_heartbeat_at) WHERE decommissioned_at IS NULL;
+CREATE INDEX idx_gate_credential_gate ON gate_credential(id_gates);
+CREATE INDEX idx_gate_status_history_gate ON gate_status_history(id_gates, changed_at);
+CREATE INDEX idx_gate_command_gate ON gate_command(id_gates, id_command);
+CREATE INDEX idx_gate_command_open ON gate_command(id_gates, expires_at) WHERE status IN ('pending', 'delivered');
 
 -- Fare matrix indexes
 CREATE UNIQUE INDEX idx_fare_route_date ON fare_matrix(from_terminal, to_terminal, effective_date);
@@ -283,6 +812,7 @@ CREATE INDEX idx_journeys_route ON journeys(origin_terminal, destination_termina
 CREATE INDEX idx_journeys_checkin_time ON journeys(checkin_time);
 CREATE INDEX idx_journeys_card_status ON journeys(card_number, journey_status);
 CREATE INDEX idx_journeys_created_at ON journeys(created_at);
+CREATE INDEX idx_journeys_product_checkout ON journeys(id_product, checkout_time) WHERE id_product IS NOT NULL;
 
 -- Transactions indexes
 CREATE INDEX idx_transactions_card ON transactions(card_number);
@@ -292,6 +822,7 @@ CREATE INDEX idx_transactions_time ON transactions(timestamp);
 CREATE INDEX idx_transactions_sync ON transactions(sync_status);
 CREATE INDEX idx_transactions_card_time ON transactions(card_number, timestamp);
 CREATE INDEX idx_transactions_type ON transactions(transaction_type);
+CREATE UNIQUE INDEX idx_transactions_topup_reference ON transactions(COALESCE(id_partner, ''), reference_number) WHERE transaction_type = 'topup';
 
 -- Offline transactions indexes
 CREATE INDEX idx_offline_trans_gate ON offline_transactions(id_gates);
@@ -314,7 +845,9 @@ END;
 $$ language 'plpgsql';
 
 -- Create triggers for all tables with updated_at
+CREATE TRIGGER update_role_updated_at BEFORE UPDATE ON role FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
 CREATE TRIGGER update_admin_updated_at BEFORE UPDATE ON admin FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+CREATE TRIGGER update_admin_session_updated_at BEFORE UPDATE ON admin_session FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
 CREATE TRIGGER update_terminal_updated_at BEFORE UPDATE ON terminal FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
 CREATE TRIGGER update_cards_updated_at BEFORE UPDATE ON cards FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
 CREATE TRIGGER update_gates_updated_at BEFORE UPDATE ON gates FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -325,19 +858,21 @@ CREATE TRIGGER update_journeys_updated_at BEFORE UPDATE ON journeys FOR EACH ROW
 -- FUNCTIONS AND PROCEDURES
 -- ===============================================
 
--- Function to get current active fare
+-- Function to get current active fare (soft deleted terminals have no fare)
 CREATE OR REPLACE FUNCTION get_active_fare(p_from_terminal BIGINT, p_to_terminal BIGINT)
 RETURNS DECIMAL(8,2) AS $$
 DECLARE
     fare_amount DECIMAL(8,2);
 BEGIN
-    SELECT regular_fare INTO fare_amount
-    FROM fare_matrix
-    WHERE from_terminal = p_from_terminal
-      AND to_terminal = p_to_terminal
-      AND effective_date <= CURRENT_DATE
-      AND (end_date IS NULL OR end_date >= CURRENT_DATE)
-    ORDER BY effective_date DESC
+    SELECT fm.regular_fare INTO fare_amount
+    FROM fare_matrix fm
+    JOIN terminal tf ON tf.id_terminal = fm.from_terminal AND tf.deleted_at IS NULL
+    JOIN terminal tt ON tt.id_terminal = fm.to_terminal AND tt.deleted_at IS NULL
+    WHERE fm.from_terminal = p_from_terminal
+      AND fm.to_terminal = p_to_terminal
+      AND fm.effective_date <= CURRENT_DATE
+      AND (fm.end_date IS NULL OR fm.end_date >= CURRENT_DATE)
+    ORDER BY fm.effective_date DESC
     LIMIT 1;
     
     RETURN COALESCE(fare_amount, 0);
@@ -377,21 +912,63 @@ CREATE TRIGGER trigger_update_journey_duration
     BEFORE UPDATE ON journeys
     FOR EACH ROW EXECUTE FUNCTION update_journey_duration();
 
+-- Function to keep the audit log append-only
+CREATE OR REPLACE FUNCTION prevent_audit_log_change()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+-- Create trigger rejecting updates and deletes of the audit log
+CREATE TRIGGER trigger_prevent_audit_log_change
+    BEFORE UPDATE OR DELETE ON audit_log
+    FOR EACH ROW EXECUTE FUNCTION prevent_audit_log_change();
+
 -- ===============================================
 -- INSERT SAMPLE DATA
 -- ===============================================
 
+-- Insert roles
+INSERT INTO role (name, description, require_2fa) VALUES
+('super_admin', 'Akses penuh ke seluruh sistem', TRUE),
+('finance', 'Staf keuangan: tarif dan kartu', TRUE),
+('terminal_supervisor', 'Supervisor terminal dan gate', FALSE);
+
+INSERT INTO role_permission (id_role, permission) VALUES
+(1, '*'),
+(2, 'fare:read'),
+(2, 'fare:write'),
+(2, 'terminal:read'),
+(2, 'card:read'),
+(2, 'card:write'),
+(2, 'rider:read'),
+(2, 'rider:write'),
+(3, 'terminal:read'),
+(3, 'terminal:write'),
+(3, 'fare:read'),
+(3, 'gate:read'),
+(3, 'gate:write'),
+(3, 'monitor:read');
+
+-- Insert card products
+INSERT INTO card_product (code, name, concession, discount_type, discount_value) VALUES
+('student', 'Pelajar', TRUE, 'percentage', 50),
+('senior', 'Lansia', TRUE, 'percentage', 50),
+('disability', 'Disabilitas', TRUE, 'free', 0),
+('staff', 'Staf', FALSE, 'free', 0);
+
 -- Insert default admin
-INSERT INTO admin (name, username, password) VALUES 
-('Administrator', 'admin', '$2a$10$92IXUNpkjO0rOQ5byMi.Ye4oKoEa3Ro9llC/.og/at2.uheWG/igi'); -- password: password
+INSERT INTO admin (name, username, password, id_role) VALUES 
+('Administrator', 'admin', '$2a$10$92IXUNpkjO0rOQ5byMi.Ye4oKoEa3Ro9llC/.og/at2.uheWG/igi', 1); -- password: password
 
 -- Insert terminals
-INSERT INTO terminal (name, location) VALUES 
-('Terminal A', 'Jakarta Pusat'),
-('Terminal B', 'Jakarta Selatan'),
-('Terminal C', 'Jakarta Barat'),
-('Terminal D', 'Jakarta Utara'),
-('Terminal E', 'Jakarta Timur');
+INSERT INTO terminal (name, location, region_code, latitude, longitude) VALUES 
+('Terminal A', 'Jakarta Pusat', '31.71', -6.186486, 106.834091),
+('Terminal B', 'Jakarta Selatan', '31.74', -6.261493, 106.810600),
+('Terminal C', 'Jakarta Barat', '31.73', -6.168329, 106.758849),
+('Terminal D', 'Jakarta Utara', '31.72', -6.138414, 106.863956),
+('Terminal E', 'Jakarta Timur', '31.75', -6.225014, 106.900447);
 
 -- Insert gates for each terminal
 INSERT INTO gates (id_terminal, gate_number, status) VALUES 
@@ -498,6 +1075,10 @@ ORDER BY j.checkin_time;
 -- WHERE sync_status = 'synced' 
 --   AND synced_at < CURRENT_DATE - INTERVAL '30 days';
 
+-- Query to clean up expired revocation list entries
+-- DELETE FROM revoked_token
+-- WHERE expires_at < CURRENT_TIMESTAMP;
+
 -- Query to find incomplete journeys (older than 24 hours)
 -- SELECT * FROM journeys 
 -- WHERE journey_status = 'active' 
@@ -4,8 +4,13 @@ import (
 	"test-kerja-mkp/internal/delivery/http"
 	"test-kerja-mkp/internal/delivery/http/middleware"
 	"test-kerja-mkp/internal/delivery/http/route"
+	"test-kerja-mkp/internal/entity"
+	"test-kerja-mkp/internal/helper"
 	"test-kerja-mkp/internal/repository"
 	"test-kerja-mkp/internal/usecase"
+	"time"
+	// embedded zone database for app.timezone on hosts without tzdata
+	_ "time/tzdata"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
@@ -23,34 +28,182 @@ type BootstrapConfig struct {
 }
 
 func Bootstrap(config *BootstrapConfig) {
-	jwtSecret := config.Config.GetString("app.jwtSecretKey")
-	// setup repositories
+	helper.InitValidator()
 
-	authAdminRepository := repository.NewAuthAdminRepository(config.Log)
-	personalAccessTokenRepository := repository.NewPersonalAccessTokenRepository(config.Log)
-	authStudentRepository := repository.NewAuthStudentRepository(config.Log)
-	bannerRepository := repository.NewBannerRepository(config.Log, config.DB)
-	attachmentRepository := repository.NewAttachmentRepository(config.Log)
+	keyRing, err := newKeyRing(config.Config)
+	if err != nil {
+		config.Log.Fatalf("Failed to load jwt signing keys: %v", err)
+	}
+	accessTokenTTL := time.Second * time.Duration(config.Config.GetInt("auth.accessTokenTTL"))
+	refreshTokenTTL := time.Second * time.Duration(config.Config.GetInt("auth.refreshTokenTTL"))
+	revocationCacheTTL := time.Second * time.Duration(config.Config.GetInt("auth.revocationCacheTTL"))
+	sessionMaxLifetime := time.Second * time.Duration(config.Config.GetInt("auth.sessionMaxLifetime"))
+	passwordResetTTL := time.Second * time.Duration(config.Config.GetInt("auth.passwordResetTTL"))
+	twoFactorEnrollmentTTL := time.Second * time.Duration(config.Config.GetInt("auth.twoFactorEnrollmentTTL"))
+	loginThrottlePolicy := usecase.LoginThrottlePolicy{
+		UsernameThreshold: config.Config.GetInt("auth.lockout.usernameThreshold"),
+		IPThreshold:       config.Config.GetInt("auth.lockout.ipThreshold"),
+		LockDuration:      time.Second * time.Duration(config.Config.GetInt("auth.lockout.duration")),
+		Window:            time.Second * time.Duration(config.Config.GetInt("auth.lockout.window")),
+		BaseDelay:         time.Millisecond * time.Duration(config.Config.GetInt("auth.lockout.baseDelayMs")),
+		MaxDelay:          time.Millisecond * time.Duration(config.Config.GetInt("auth.lockout.maxDelayMs")),
+	}
+	gateSignatureTolerance := time.Second * time.Duration(config.Config.GetInt("gate.signatureTolerance"))
+	gateRotationGrace := time.Second * time.Duration(config.Config.GetInt("gate.rotationGrace"))
+	gateHeartbeatTimeout := time.Second * time.Duration(config.Config.GetInt("gate.heartbeatTimeout"))
+	gateMonitorInterval := time.Second * time.Duration(config.Config.GetInt("gate.monitorInterval"))
+	gateCommandPollInterval := time.Millisecond * time.Duration(config.Config.GetInt("gate.commandPollIntervalMs"))
+	eventBroker := helper.NewEventBroker(config.Config.GetInt("event.bufferSize"))
+	location, err := time.LoadLocation(config.Config.GetString("app.timezone"))
+	if err != nil {
+		config.Log.Fatalf("Failed to load timezone: %v", err)
+	}
+	topUpPolicy := usecase.TopUpPolicy{
+		MinAmount:  configMoney(config, "topup.minAmount"),
+		MaxAmount:  configMoney(config, "topup.maxAmount"),
+		MaxBalance: configMoney(config, "card.maxBalance"),
+	}
+	passwordPolicy := usecase.PasswordPolicy{
+		MinLength:        config.Config.GetInt("auth.password.minLength"),
+		RequireUppercase: config.Config.GetBool("auth.password.requireUppercase"),
+		RequireLowercase: config.Config.GetBool("auth.password.requireLowercase"),
+		RequireDigit:     config.Config.GetBool("auth.password.requireDigit"),
+		RequireSymbol:    config.Config.GetBool("auth.password.requireSymbol"),
+		HistorySize:      config.Config.GetInt("auth.password.historySize"),
+	}
+
+	// setup repositories
+	authRepository := repository.NewAuthRepository(config.Log)
+	adminSessionRepository := repository.NewAdminSessionRepository(config.Log)
+	adminRefreshTokenRepository := repository.NewAdminRefreshTokenRepository(config.Log)
+	revokedTokenRepository := repository.NewRevokedTokenRepository(config.Log)
+	roleRepository := repository.NewRoleRepository(config.Log)
+	adminPasswordHistoryRepository := repository.NewAdminPasswordHistoryRepository(config.Log)
+	adminPasswordResetRepository := repository.NewAdminPasswordResetRepository(config.Log)
+	loginThrottleRepository := repository.NewLoginThrottleRepository(config.Log)
+	adminRecoveryCodeRepository := repository.NewAdminRecoveryCodeRepository(config.Log)
+	adminTwoFactorEnrollmentRepository := repository.NewAdminTwoFactorEnrollmentRepository(config.Log)
+	auditLogRepository := repository.NewAuditLogRepository(config.Log)
+	gateRepository := repository.NewGateRepository(config.Log)
+	gateCredentialRepository := repository.NewGateCredentialRepository(config.Log)
+	gateStatusHistoryRepository := repository.NewGateStatusHistoryRepository(config.Log)
+	gateCommandRepository := repository.NewGateCommandRepository(config.Log)
+	terminalRepository := repository.NewTerminalRepository(config.Log, config.DB)
+	terminalOperatingHourRepository := repository.NewTerminalOperatingHourRepository(config.Log)
+	terminalClosureRepository := repository.NewTerminalClosureRepository(config.Log)
+	cardRepository := repository.NewCardRepository(config.Log)
+	transactionRepository := repository.NewTransactionRepository(config.Log)
+	channelPartnerRepository := repository.NewChannelPartnerRepository(config.Log)
+	cardHotlistRepository := repository.NewCardHotlistRepository(config.Log)
+	journeyRepository := repository.NewJourneyRepository(config.Log)
+	fareMatrixRepository := repository.NewFareMatrixRepository(config.Log)
+	riderRepository := repository.NewRiderRepository(config.Log)
+	cardProductRepository := repository.NewCardProductRepository(config.Log)
 
 	// setup use cases
-	authAdminUseCase := usecase.NewAuthAdminUseCase(config.DB, config.Log, config.Validate, authAdminRepository, personalAccessTokenRepository, []byte(jwtSecret))
-	authStudentUseCase := usecase.NewAuthStudentUseCase(config.DB, config.Log, config.Validate, authStudentRepository, personalAccessTokenRepository, []byte(jwtSecret))
-	attachmentUseCase := usecase.NewAttachmentUseCase(config.DB, attachmentRepository, config.Log)
-	bannerUseCase := usecase.NewBannerUseCase(config.Log, bannerRepository, config.DB, config.Validate, attachmentUseCase)
+	loginThrottleUseCase := usecase.NewLoginThrottleUseCase(config.DB, config.Log, loginThrottleRepository, authRepository, loginThrottlePolicy)
+	authUseCase := usecase.NewAuthUseCase(config.DB, config.Log, config.Validate, authRepository, adminSessionRepository, adminRefreshTokenRepository, revokedTokenRepository, loginThrottleUseCase, keyRing, accessTokenTTL, refreshTokenTTL, revocationCacheTTL, sessionMaxLifetime)
+	passwordUseCase := usecase.NewPasswordUseCase(config.DB, config.Log, config.Validate, authRepository, adminPasswordHistoryRepository, adminPasswordResetRepository, authUseCase, passwordPolicy, passwordResetTTL)
+	adminUseCase := usecase.NewAdminUseCase(config.DB, config.Log, config.Validate, authRepository, roleRepository, authUseCase, passwordUseCase, loginThrottleUseCase)
+	twoFactorUseCase := usecase.NewTwoFactorUseCase(config.DB, config.Log, config.Validate, authRepository, adminRecoveryCodeRepository, adminTwoFactorEnrollmentRepository, authUseCase, config.Config.GetString("app.name"), twoFactorEnrollmentTTL)
+	auditUseCase := usecase.NewAuditUseCase(config.DB, config.Log, config.Validate, auditLogRepository)
+	gateUseCase := usecase.NewGateUseCase(config.DB, config.Log, config.Validate, gateRepository, gateCredentialRepository, gateStatusHistoryRepository, terminalRepository, eventBroker)
+	gateMonitorUseCase := usecase.NewGateMonitorUseCase(config.DB, config.Log, config.Validate, gateRepository, gateStatusHistoryRepository, terminalRepository, eventBroker, gateHeartbeatTimeout, gateMonitorInterval)
+	gateCommandUseCase := usecase.NewGateCommandUseCase(config.DB, config.Log, config.Validate, gateRepository, gateCommandRepository, gateCommandPollInterval)
+	gateCredentialUseCase := usecase.NewGateCredentialUseCase(config.DB, config.Log, config.Validate, gateRepository, gateCredentialRepository, gateSignatureTolerance, gateRotationGrace)
+	terminalUseCase := usecase.NewTerminalUseCase(config.Log, terminalRepository, config.DB, config.Validate)
+	terminalScheduleUseCase := usecase.NewTerminalScheduleUseCase(config.DB, config.Log, config.Validate, terminalRepository, terminalOperatingHourRepository, terminalClosureRepository, location)
+	cardUseCase := usecase.NewCardUseCase(config.DB, config.Log, config.Validate, cardRepository, cardHotlistRepository)
+	topUpUseCase := usecase.NewTopUpUseCase(config.DB, config.Log, config.Validate, cardRepository, transactionRepository, topUpPolicy)
+	channelPartnerUseCase := usecase.NewChannelPartnerUseCase(config.DB, config.Log, config.Validate, channelPartnerRepository)
+	cardReplacementUseCase := usecase.NewCardReplacementUseCase(config.DB, config.Log, config.Validate, cardRepository, journeyRepository, transactionRepository, cardUseCase)
+	riderUseCase := usecase.NewRiderUseCase(config.DB, config.Log, config.Validate, riderRepository, cardRepository)
+	cardProductUseCase := usecase.NewCardProductUseCase(config.DB, config.Log, config.Validate, cardProductRepository, cardRepository, location)
+	journeyUseCase := usecase.NewJourneyUseCase(config.DB, config.Log, config.Validate, cardRepository, journeyRepository, transactionRepository, fareMatrixRepository, cardProductRepository, terminalRepository, terminalScheduleUseCase, eventBroker)
 
 	// setup controller
-	authAdminController := controller.NewAuthAdminController(authAdminUseCase, config.Log)
-	authStudentController := controller.NewAuthStudentController(authStudentUseCase, config.Log)
-	bannerController := controller.NewBannerController(bannerUseCase, config.Log)
+	authController := http.NewAuthController(authUseCase, config.Log)
+	adminController := http.NewAdminController(adminUseCase, config.Log)
+	passwordController := http.NewPasswordController(passwordUseCase, config.Log)
+	twoFactorController := http.NewTwoFactorController(twoFactorUseCase, config.Log)
+	terminalController := http.NewTerminalController(terminalUseCase, config.Log)
+	terminalScheduleController := http.NewTerminalScheduleController(terminalScheduleUseCase, config.Log)
+	cardController := http.NewCardController(cardUseCase, config.Log)
+	topUpController := http.NewTopUpController(topUpUseCase, config.Log)
+	channelPartnerController := http.NewChannelPartnerController(channelPartnerUseCase, config.Log)
+	journeyController := http.NewJourneyController(journeyUseCase, config.Log)
+	cardReplacementController := http.NewCardReplacementController(cardReplacementUseCase, config.Log)
+	riderController := http.NewRiderController(riderUseCase, config.Log)
+	cardProductController := http.NewCardProductController(cardProductUseCase, config.Log)
+	gateController := http.NewGateController(gateUseCase, config.Log)
+	gateMonitorController := http.NewGateMonitorController(gateMonitorUseCase, config.Log)
+	gateCommandController := http.NewGateCommandController(gateCommandUseCase, config.Log)
+	gateCredentialController := http.NewGateCredentialController(gateCredentialUseCase, config.Log)
+	auditController := http.NewAuditController(auditUseCase, config.Log)
+	eventController := http.NewEventController(eventBroker, authUseCase, config.Log)
 
-	authAdminMiddleware := middleware.NewAuthAdmin(authAdminUseCase)
+	authMiddleware := middleware.NewAuthAdmin(authUseCase)
+	gateAuthMiddleware := middleware.NewAuthGate(gateCredentialUseCase)
+	partnerAuthMiddleware := middleware.NewAuthPartner(channelPartnerUseCase)
+	auditMiddleware := middleware.NewAudit(auditUseCase)
 
 	routeConfig := route.RouteConfig{
-		App:                   config.App,
-		AuthAdminController:   authAdminController,
-		AuthStudentController: authStudentController,
-		BannerController:      bannerController,
-		AuthMiddleware:        authAdminMiddleware,
+		App:                        config.App,
+		AuthController:             authController,
+		AdminController:            adminController,
+		PasswordController:         passwordController,
+		TwoFactorController:        twoFactorController,
+		TerminalController:         terminalController,
+		TerminalScheduleController: terminalScheduleController,
+		CardController:             cardController,
+		JourneyController:          journeyController,
+		CardReplacementController:  cardReplacementController,
+		RiderController:            riderController,
+		CardProductController:      cardProductController,
+		TopUpController:            topUpController,
+		ChannelPartnerController:   channelPartnerController,
+		GateController:             gateController,
+		GateMonitorController:      gateMonitorController,
+		GateCommandController:      gateCommandController,
+		GateCredentialController:   gateCredentialController,
+		AuditController:            auditController,
+		EventController:            eventController,
+		AuthMiddleware:             authMiddleware,
+		GateAuthMiddleware:         gateAuthMiddleware,
+		PartnerAuthMiddleware:      partnerAuthMiddleware,
+		AuditMiddleware:            auditMiddleware,
 	}
 	routeConfig.Setup()
+
+	gateMonitorUseCase.Start()
+}
+
+// configMoney reads a decimal amount in rupiah from the config.
+func configMoney(config *BootstrapConfig, key string) entity.Money {
+	amount, err := entity.ParseMoney(config.Config.GetString(key))
+	if err != nil {
+		config.Log.Fatalf("Invalid amount for %s: %v", key, err)
+	}
+	return amount
+}
+
+// newKeyRing loads the jwt signing keys from "auth.jwt". When no keys are
+// configured, the legacy HS256 secret "app.jwtSecretKey" is used as the only
+// key.
+func newKeyRing(config *viper.Viper) (*helper.KeyRing, error) {
+	var keys []helper.SigningKeyConfig
+	if err := config.UnmarshalKey("auth.jwt.keys", &keys); err != nil {
+		return nil, err
+	}
+
+	activeKid := config.GetString("auth.jwt.activeKid")
+	if len(keys) == 0 {
+		keys = append(keys, helper.SigningKeyConfig{
+			ID:        activeKid,
+			Algorithm: "HS256",
+			Secret:    config.GetString("app.jwtSecretKey"),
+		})
+	}
+
+	return helper.NewKeyRing(activeKid, keys)
 }
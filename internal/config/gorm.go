@@ -24,6 +24,8 @@ func NewDatabase(viper *viper.Viper, log *logrus.Logger) *gorm.DB {
 		host, username, password, database, port)
 
 	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
+		// Unique violations surface as gorm.ErrDuplicatedKey.
+		TranslateError: true,
 		Logger: logger.New(&logrusWriter{Logger: log}, logger.Config{
 			SlowThreshold:             time.Second * 5,
 			Colorful:                  false,
@@ -14,6 +14,37 @@ func NewViper() *viper.Viper {
 	config.SetConfigType("json")
 	config.AddConfigPath("./../")
 	config.AddConfigPath("./")
+
+	config.SetDefault("auth.accessTokenTTL", 600)
+	config.SetDefault("auth.refreshTokenTTL", 604800)
+	config.SetDefault("auth.sessionMaxLifetime", 2592000)
+	config.SetDefault("auth.revocationCacheTTL", 30)
+	config.SetDefault("auth.passwordResetTTL", 3600)
+	config.SetDefault("auth.twoFactorEnrollmentTTL", 86400)
+	config.SetDefault("auth.jwt.activeKid", "default")
+	config.SetDefault("auth.lockout.usernameThreshold", 5)
+	config.SetDefault("auth.lockout.ipThreshold", 20)
+	config.SetDefault("auth.lockout.duration", 900)
+	config.SetDefault("auth.lockout.window", 900)
+	config.SetDefault("auth.lockout.baseDelayMs", 1000)
+	config.SetDefault("auth.lockout.maxDelayMs", 30000)
+	config.SetDefault("auth.password.minLength", 10)
+	config.SetDefault("auth.password.requireUppercase", true)
+	config.SetDefault("auth.password.requireLowercase", true)
+	config.SetDefault("auth.password.requireDigit", true)
+	config.SetDefault("auth.password.requireSymbol", true)
+	config.SetDefault("auth.password.historySize", 5)
+	config.SetDefault("gate.signatureTolerance", 300)
+	config.SetDefault("gate.rotationGrace", 86400)
+	config.SetDefault("gate.heartbeatTimeout", 90)
+	config.SetDefault("gate.monitorInterval", 30)
+	config.SetDefault("gate.commandPollIntervalMs", 1000)
+	config.SetDefault("app.timezone", "Asia/Jakarta")
+	config.SetDefault("event.bufferSize", 256)
+	config.SetDefault("topup.minAmount", "10000")
+	config.SetDefault("topup.maxAmount", "1000000")
+	config.SetDefault("card.maxBalance", "2000000")
+
 	err := config.ReadInConfig()
 
 	if err != nil {
@@ -21,4 +52,4 @@ func NewViper() *viper.Viper {
 	}
 
 	return config
-}
\ No newline at end of file
+}
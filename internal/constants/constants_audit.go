@@ -0,0 +1,74 @@
+package constants
+
+const (
+	AuditEntityAdmin          = "admin"
+	AuditEntityCard           = "card"
+	AuditEntityCardProduct    = "card_product"
+	AuditEntityChannelPartner = "channel_partner"
+	AuditEntityGate           = "gate"
+	AuditEntityGateCommand    = "gate_command"
+	AuditEntityGateCredential = "gate_credential"
+	AuditEntityRider          = "rider"
+	AuditEntityTerminal       = "terminal"
+
+	AuditActionLogin          = "auth.login"
+	AuditActionLoginTwoFactor = "auth.login_2fa"
+	AuditActionRefresh        = "auth.refresh"
+	AuditActionLogout         = "auth.logout"
+	AuditActionLogoutAll      = "auth.logout_all"
+
+	AuditActionTwoFactorEnrollIssue   = "2fa.enroll_issue"
+	AuditActionTwoFactorSetup         = "2fa.setup"
+	AuditActionTwoFactorEnable        = "2fa.enable"
+	AuditActionTwoFactorDisable       = "2fa.disable"
+	AuditActionTwoFactorRecoveryCodes = "2fa.recovery_codes"
+
+	AuditActionPasswordChange = "password.change"
+	AuditActionPasswordIssue  = "password.reset_issue"
+	AuditActionPasswordReset  = "password.reset"
+
+	AuditActionAdminCreate       = "admin.create"
+	AuditActionAdminUpdate       = "admin.update"
+	AuditActionAdminUpdateStatus = "admin.update_status"
+	AuditActionAdminDelete       = "admin.delete"
+	AuditActionAdminUnlock       = "admin.unlock"
+
+	AuditActionTerminalCreate  = "terminal.create"
+	AuditActionTerminalUpdate  = "terminal.update"
+	AuditActionTerminalDelete  = "terminal.delete"
+	AuditActionTerminalRestore = "terminal.restore"
+	AuditActionTerminalImport  = "terminal.import"
+
+	AuditActionTerminalHoursUpdate   = "terminal.hours_update"
+	AuditActionTerminalClosureCreate = "terminal.closure_create"
+	AuditActionTerminalClosureDelete = "terminal.closure_delete"
+
+	AuditActionGateCreate       = "gate.create"
+	AuditActionGateRename       = "gate.rename"
+	AuditActionGateMove         = "gate.move"
+	AuditActionGateDecommission = "gate.decommission"
+
+	AuditActionGateCredentialIssue  = "gate_credential.issue"
+	AuditActionGateCredentialRotate = "gate_credential.rotate"
+	AuditActionGateCredentialRevoke = "gate_credential.revoke"
+
+	AuditActionGateCommandIssue  = "gate_command.issue"
+	AuditActionGateCommandCancel = "gate_command.cancel"
+
+	AuditActionCardIssue         = "card.issue"
+	AuditActionCardUpdateStatus  = "card.update_status"
+	AuditActionCardTopUp         = "card.topup"
+	AuditActionCardBlock         = "card.block"
+	AuditActionCardUnblock       = "card.unblock"
+	AuditActionCardRegister      = "card.register"
+	AuditActionCardReplace       = "card.replace"
+	AuditActionCardAssignProduct = "card.assign_product"
+
+	AuditActionCardProductCreate = "card_product.create"
+	AuditActionCardProductUpdate = "card_product.update"
+
+	AuditActionRiderCreate = "rider.create"
+
+	AuditActionChannelPartnerCreate  = "channel_partner.create"
+	AuditActionChannelPartnerDisable = "channel_partner.disable"
+)
@@ -0,0 +1,15 @@
+package constants
+
+// Event types streamed to the control room.
+const (
+	EventGateStatus      = "gate.status"
+	EventJourneyCheckIn  = "journey.checkin"
+	EventJourneyCheckOut = "journey.checkout"
+	EventSyncError       = "sync.error"
+)
+
+// Reasons of a sync.error event.
+const (
+	SyncErrorReasonGateError      = "gate_error"
+	SyncErrorReasonPendingGrowing = "pending_growing"
+)
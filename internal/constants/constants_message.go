@@ -4,23 +4,126 @@ const (
 
 	// Login messages
 	InvalidCredentialsMessage = "Invalid email or password"
-	InvalidToken			  = "Invalid token"
-	
-	SuccessLoginMessage       = "Login successful"
-	FailedLoginMessage        = "Login failed"
-
-	SuccessGetDataMessage     = "Get data successfully"
-	SuccessFindDataMessage     = "Find data successfully"
-	SuccessCreateMessage      = "Create data successfully"
-	SuccessUpdateMessage      = "Update data successfully"
-	SuccessDeleteMessage      = "Delete data successfully"
-	
-	FailedGetDataMessage      = "Failed to get data"
-	FailedFindDataMessage     = "Failed to find data"
-	FailedCreateMessage       = "Failed to create data"
-	FailedUpdateMessage       = "Failed to update data"
-	FailedDeleteMessage       = "Failed to delete data"
-
-	InvalidRequestMessage     = "Invalid request data"
-	InvalidParamsMessage      = "Invalid parameters"
-)
\ No newline at end of file
+	InvalidToken              = "Invalid token"
+	ForbiddenMessage          = "You do not have permission to access this resource"
+
+	SuccessLoginMessage = "Login successful"
+	FailedLoginMessage  = "Login failed"
+
+	TooManyLoginAttemptsMessage = "Too many failed login attempts, please try again later"
+	SuccessUnlockMessage        = "Account unlocked successfully"
+
+	SuccessRefreshTokenMessage = "Refresh token successful"
+	FailedRefreshTokenMessage  = "Refresh token failed"
+
+	SuccessLogoutMessage = "Logout successful"
+	FailedLogoutMessage  = "Logout failed"
+
+	SuccessChangePasswordMessage  = "Password changed successfully, please login again"
+	FailedChangePasswordMessage   = "Failed to change password"
+	InvalidCurrentPasswordMessage = "Current password is incorrect"
+	InvalidResetTokenMessage      = "Invalid or expired reset token"
+	PasswordReusedMessage         = "Password has been used recently, choose a different one"
+
+	SuccessTwoFactorSetupMessage   = "Two-factor authentication setup started"
+	SuccessTwoFactorEnableMessage  = "Two-factor authentication enabled successfully"
+	SuccessTwoFactorDisableMessage = "Two-factor authentication disabled successfully"
+	FailedTwoFactorMessage         = "Two-factor authentication failed"
+	InvalidTwoFactorCodeMessage    = "Invalid two-factor authentication code"
+	InvalidChallengeTokenMessage   = "Invalid or expired challenge token"
+	InvalidEnrollmentTokenMessage  = "Invalid or expired enrollment token"
+	TwoFactorAlreadyEnabledMessage = "Two-factor authentication is already enabled"
+	TwoFactorNotEnabledMessage     = "Two-factor authentication is not enabled"
+	TwoFactorNotSetUpMessage       = "Two-factor authentication has not been set up"
+	TwoFactorRequiredByRoleMessage = "Two-factor authentication is required by your role"
+
+	SuccessGetDataMessage  = "Get data successfully"
+	SuccessFindDataMessage = "Find data successfully"
+	SuccessCreateMessage   = "Create data successfully"
+	SuccessUpdateMessage   = "Update data successfully"
+	SuccessDeleteMessage   = "Delete data successfully"
+
+	FailedGetDataMessage  = "Failed to get data"
+	FailedFindDataMessage = "Failed to find data"
+	FailedCreateMessage   = "Failed to create data"
+	FailedUpdateMessage   = "Failed to update data"
+	FailedDeleteMessage   = "Failed to delete data"
+
+	InvalidGateCredentialMessage       = "Invalid gate credential"
+	GateNotFoundMessage                = "Gate not found"
+	SuccessRevokeGateCredentialMessage = "Gate credential revoked successfully"
+	GateNumberAlreadyExistsMessage     = "Gate number already exists in this terminal"
+	GateDecommissionedMessage          = "Gate is decommissioned"
+	SuccessDecommissionGateMessage     = "Gate decommissioned successfully"
+	SuccessHeartbeatMessage            = "Heartbeat received"
+	GateCommandNotFoundMessage         = "Gate command not found"
+	GateCommandClosedMessage           = "Gate command is already completed or expired"
+	SuccessCancelGateCommandMessage    = "Gate command cancelled successfully"
+	SuccessAckGateCommandMessage       = "Gate command acknowledged"
+
+	TerminalNotFoundMessage   = "Terminal not found"
+	TerminalInUseMessage      = "Terminal still has gates in service, effective fares or active journeys"
+	TerminalNotDeletedMessage = "Terminal is not deleted"
+	SuccessRestoreMessage     = "Restore data successfully"
+	FailedRestoreMessage      = "Failed to restore data"
+	InvalidImportFileMessage  = "Invalid import file"
+	InvalidImportRowsMessage  = "Import file contains invalid rows"
+	SuccessImportMessage      = "Import data successfully"
+	FailedImportMessage       = "Failed to import data"
+
+	InvalidOperatingHoursMessage   = "Operating hours must be HH:MM intervals that do not overlap"
+	InvalidClosureRangeMessage     = "Closure end date must not be before its start date"
+	TerminalClosureNotFoundMessage = "Terminal closure not found"
+	TerminalClosedMessage          = "Terminal is closed"
+
+	CardNotFoundMessage            = "Card not found"
+	CardNotActiveMessage           = "Card is not active"
+	InvalidAmountMessage           = "Amount must be a decimal with at most two decimals"
+	CardBlockedMessage             = "Card is blocked, unblock it first"
+	CardAlreadyBlockedMessage      = "Card is already blocked"
+	CardNotBlockedMessage          = "Card is not blocked"
+	CardHotlistedMessage           = "Card is hotlisted"
+	SuccessBlockCardMessage        = "Card blocked successfully"
+	SuccessUnblockCardMessage      = "Card unblocked successfully"
+	CardAlreadyRegisteredMessage   = "Card is registered to another rider"
+	CardNotRegisteredMessage       = "Card is not registered to a rider"
+	CardAlreadyReplacedMessage     = "Card has already been replaced"
+	CardHasActiveJourneyMessage    = "Card has an active journey"
+	InvalidReplacementCardMessage  = "Replacement card must be an active card that was not replaced"
+	SuccessRegisterCardMessage     = "Card registered successfully"
+	SuccessReplaceCardMessage      = "Card replaced successfully"
+	RiderNotFoundMessage           = "Rider not found"
+	RiderAlreadyExistsMessage      = "Identity number is already registered"
+	TopUpAmountOutOfRangeMessage   = "Top-up amount is outside the allowed range"
+	MaxBalanceExceededMessage      = "Top-up would exceed the maximum card balance"
+	ReferenceNumberConflictMessage = "Reference number was already used for a different top-up"
+	SuccessTopUpMessage            = "Top-up successful"
+	FailedTopUpMessage             = "Top-up failed"
+	ChannelPartnerNotFoundMessage  = "Channel partner not found"
+	InvalidPartnerKeyMessage       = "Invalid partner API key"
+	SuccessDisablePartnerMessage   = "Channel partner disabled successfully"
+
+	JourneyAlreadyActiveMessage = "Card already has an active journey"
+	NoFareFromTerminalMessage   = "No fare is effective from this terminal"
+	InsufficientBalanceMessage  = "Card balance is below the maximum fare"
+	SuccessCheckInMessage       = "Check-in successful"
+	FailedCheckInMessage        = "Check-in failed"
+	NoActiveJourneyMessage      = "Card has no active journey"
+	SuccessCheckOutMessage      = "Check-out successful"
+	FailedCheckOutMessage       = "Check-out failed"
+
+	CardProductNotFoundMessage    = "Card product not found"
+	CardProductCodeExistsMessage  = "Card product code already exists"
+	CardProductNotValidMessage    = "Card product is not valid today"
+	InvalidDiscountMessage        = "Discount value does not match the discount type"
+	InvalidProductValidityMessage = "Product validity end must not be before its start"
+	InvalidReportRangeMessage     = "Report end date must not be before its start date"
+
+	UsernameAlreadyExistsMessage = "Username already exists"
+	RoleNotFoundMessage          = "Role not found"
+	CannotDisableSelfMessage     = "You cannot disable your own account"
+	CannotDeleteSelfMessage      = "You cannot delete your own account"
+
+	InvalidRequestMessage = "Invalid request data"
+	InvalidParamsMessage  = "Invalid parameters"
+)
@@ -0,0 +1,31 @@
+package constants
+
+const (
+	// PermissionAll grants every permission, used by the super admin role.
+	PermissionAll = "*"
+
+	PermissionAdminRead  = "admin:read"
+	PermissionAdminWrite = "admin:write"
+
+	PermissionTerminalRead  = "terminal:read"
+	PermissionTerminalWrite = "terminal:write"
+
+	PermissionGateRead  = "gate:read"
+	PermissionGateWrite = "gate:write"
+
+	PermissionAuditRead = "audit:read"
+
+	PermissionCardRead  = "card:read"
+	PermissionCardWrite = "card:write"
+
+	PermissionRiderRead  = "rider:read"
+	PermissionRiderWrite = "rider:write"
+
+	PermissionPartnerRead  = "partner:read"
+	PermissionPartnerWrite = "partner:write"
+
+	PermissionMonitorRead = "monitor:read"
+
+	PermissionFareRead  = "fare:read"
+	PermissionFareWrite = "fare:write"
+)
@@ -0,0 +1,158 @@
+package http
+
+import (
+	"strconv"
+	"test-kerja-mkp/internal/constants"
+	"test-kerja-mkp/internal/helper"
+	"test-kerja-mkp/internal/model"
+	"test-kerja-mkp/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type AdminController struct {
+	Log     *logrus.Logger
+	UseCase *usecase.AdminUseCase
+}
+
+func NewAdminController(usecase *usecase.AdminUseCase, log *logrus.Logger) *AdminController {
+	return &AdminController{
+		Log:     log,
+		UseCase: usecase,
+	}
+}
+
+func (c *AdminController) Create(ctx *fiber.Ctx) error {
+	request := new(model.CreateAdminRequest)
+
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Failed to parse request body: %v", err)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidRequestMessage, nil)
+	}
+
+	if errors := helper.ValidateStruct(ctx, request); errors != nil {
+		c.Log.Warnf("Validation failed: %v", errors)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidRequestMessage, errors)
+	}
+
+	response, err := c.UseCase.Create(ctx.Context(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to create admin: %v", err)
+		return helper.ResponseErrorFromErr(ctx, err, constants.FailedCreateMessage, nil)
+	}
+
+	return helper.ResponseSuccess(ctx, constants.SuccessCreateMessage, response)
+}
+
+func (c *AdminController) GetAll(ctx *fiber.Ctx) error {
+	page, _ := strconv.Atoi(ctx.Query("page", "1"))
+	size, _ := strconv.Atoi(ctx.Query("size", "10"))
+
+	admins, paging, err := c.UseCase.FindAll(ctx.Context(), page, size)
+	if err != nil {
+		return helper.ResponseErrorFromErr(ctx, err, constants.FailedGetDataMessage, nil)
+	}
+
+	return helper.ResponseSuccessPagination(ctx, admins, constants.SuccessGetDataMessage, paging)
+}
+
+func (c *AdminController) FindById(ctx *fiber.Ctx) error {
+	id, err := strconv.ParseInt(ctx.Params("admin_id"), 10, 64)
+	if err != nil {
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidParamsMessage, nil)
+	}
+
+	response, err := c.UseCase.FindById(ctx.Context(), id)
+	if err != nil {
+		return helper.ResponseErrorFromErr(ctx, err, constants.FailedFindDataMessage, nil)
+	}
+
+	return helper.ResponseSuccess(ctx, constants.SuccessFindDataMessage, response)
+}
+
+func (c *AdminController) Update(ctx *fiber.Ctx) error {
+	id, err := strconv.ParseInt(ctx.Params("admin_id"), 10, 64)
+	if err != nil {
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidParamsMessage, nil)
+	}
+
+	request := new(model.UpdateAdminRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Failed to parse request body: %v", err)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidRequestMessage, nil)
+	}
+	request.ID = id
+
+	if errors := helper.ValidateStruct(ctx, request); errors != nil {
+		c.Log.Warnf("Validation failed: %v", errors)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidRequestMessage, errors)
+	}
+
+	response, err := c.UseCase.Update(ctx.Context(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to update admin: %v", err)
+		return helper.ResponseErrorFromErr(ctx, err, constants.FailedUpdateMessage, nil)
+	}
+
+	return helper.ResponseSuccess(ctx, constants.SuccessUpdateMessage, response)
+}
+
+func (c *AdminController) UpdateStatus(ctx *fiber.Ctx) error {
+	auth := ctx.Locals("auth").(*model.AuthAdmin)
+
+	id, err := strconv.ParseInt(ctx.Params("admin_id"), 10, 64)
+	if err != nil {
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidParamsMessage, nil)
+	}
+
+	request := new(model.UpdateAdminStatusRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Failed to parse request body: %v", err)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidRequestMessage, nil)
+	}
+	request.ID = id
+
+	if errors := helper.ValidateStruct(ctx, request); errors != nil {
+		c.Log.Warnf("Validation failed: %v", errors)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidRequestMessage, errors)
+	}
+
+	response, err := c.UseCase.UpdateStatus(ctx.Context(), auth, request)
+	if err != nil {
+		c.Log.Warnf("Failed to update admin status: %v", err)
+		return helper.ResponseErrorFromErr(ctx, err, constants.FailedUpdateMessage, nil)
+	}
+
+	return helper.ResponseSuccess(ctx, constants.SuccessUpdateMessage, response)
+}
+
+func (c *AdminController) Delete(ctx *fiber.Ctx) error {
+	auth := ctx.Locals("auth").(*model.AuthAdmin)
+
+	id, err := strconv.ParseInt(ctx.Params("admin_id"), 10, 64)
+	if err != nil {
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidParamsMessage, nil)
+	}
+
+	if err := c.UseCase.Delete(ctx.Context(), auth, id); err != nil {
+		c.Log.Warnf("Failed to delete admin: %v", err)
+		return helper.ResponseErrorFromErr(ctx, err, constants.FailedDeleteMessage, nil)
+	}
+
+	return helper.ResponseSuccessWithoutData(ctx, constants.SuccessDeleteMessage, nil)
+}
+
+func (c *AdminController) Unlock(ctx *fiber.Ctx) error {
+	id, err := strconv.ParseInt(ctx.Params("admin_id"), 10, 64)
+	if err != nil {
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidParamsMessage, nil)
+	}
+
+	if err := c.UseCase.Unlock(ctx.Context(), id); err != nil {
+		c.Log.Warnf("Failed to unlock admin: %v", err)
+		return helper.ResponseErrorFromErr(ctx, err, constants.FailedUpdateMessage, nil)
+	}
+
+	return helper.ResponseSuccessWithoutData(ctx, constants.SuccessUnlockMessage, nil)
+}
@@ -0,0 +1,71 @@
+package http
+
+import (
+	"strconv"
+	"test-kerja-mkp/internal/constants"
+	"test-kerja-mkp/internal/helper"
+	"test-kerja-mkp/internal/model"
+	"test-kerja-mkp/internal/usecase"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type AuditController struct {
+	Log     *logrus.Logger
+	UseCase *usecase.AuditUseCase
+}
+
+func NewAuditController(usecase *usecase.AuditUseCase, log *logrus.Logger) *AuditController {
+	return &AuditController{
+		Log:     log,
+		UseCase: usecase,
+	}
+}
+
+func (c *AuditController) Search(ctx *fiber.Ctx) error {
+	request := &model.SearchAuditLogRequest{
+		Action:     ctx.Query("action"),
+		EntityType: ctx.Query("entity_type"),
+		EntityID:   ctx.Query("entity_id"),
+		Page:       ctx.QueryInt("page", 1),
+		Size:       ctx.QueryInt("size", 10),
+	}
+
+	if actorID := ctx.Query("id_admin"); actorID != "" {
+		id, err := strconv.ParseInt(actorID, 10, 64)
+		if err != nil {
+			return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidParamsMessage, nil)
+		}
+		request.ActorID = id
+	}
+	if success := ctx.Query("success"); success != "" {
+		value, err := strconv.ParseBool(success)
+		if err != nil {
+			return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidParamsMessage, nil)
+		}
+		request.Success = &value
+	}
+	for param, target := range map[string]**time.Time{"from": &request.From, "to": &request.To} {
+		if value := ctx.Query(param); value != "" {
+			parsed, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidParamsMessage, nil)
+			}
+			*target = &parsed
+		}
+	}
+
+	if errors := helper.ValidateStruct(ctx, request); errors != nil {
+		c.Log.Warnf("Validation failed: %v", errors)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidParamsMessage, errors)
+	}
+
+	logs, paging, err := c.UseCase.Search(ctx.Context(), request)
+	if err != nil {
+		return helper.ResponseErrorFromErr(ctx, err, constants.FailedGetDataMessage, nil)
+	}
+
+	return helper.ResponseSuccessPagination(ctx, logs, constants.SuccessGetDataMessage, paging)
+}
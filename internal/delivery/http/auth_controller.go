@@ -35,6 +35,9 @@ func (c *AuthController) Login(ctx *fiber.Ctx) error {
 		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidRequestMessage, err)
 	}
 
+	request.IPAddress = ctx.IP()
+	request.UserAgent = ctx.Get(fiber.HeaderUserAgent)
+
 	response, err := c.UseCase.LoginAdmin(ctx.Context(), request)
 
 	if err != nil {
@@ -44,3 +47,57 @@ func (c *AuthController) Login(ctx *fiber.Ctx) error {
 
 	return helper.ResponseSuccess(ctx, constants.SuccessLoginMessage, response)
 }
+
+func (c *AuthController) Refresh(ctx *fiber.Ctx) error {
+	request := new(model.RefreshTokenRequest)
+
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Errorf("Failed to parse request body: %v", err)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidRequestMessage, nil)
+	}
+
+	if err := helper.ValidateStruct(ctx, request); err != nil {
+		c.Log.Errorf("Validation failed: %v", err)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidRequestMessage, err)
+	}
+
+	request.IPAddress = ctx.IP()
+	request.UserAgent = ctx.Get(fiber.HeaderUserAgent)
+
+	response, err := c.UseCase.Refresh(ctx.Context(), request)
+	if err != nil {
+		c.Log.Errorf("Failed to refresh token: %v", err)
+		return helper.ResponseErrorFromErr(ctx, err, constants.FailedRefreshTokenMessage, nil)
+	}
+
+	return helper.ResponseSuccess(ctx, constants.SuccessRefreshTokenMessage, response)
+}
+
+func (c *AuthController) Logout(ctx *fiber.Ctx) error {
+	auth := ctx.Locals("auth").(*model.AuthAdmin)
+
+	if err := c.UseCase.Logout(ctx.Context(), auth); err != nil {
+		c.Log.Errorf("Failed to logout admin: %v", err)
+		return helper.ResponseErrorFromErr(ctx, err, constants.FailedLogoutMessage, nil)
+	}
+
+	return helper.ResponseSuccessWithoutData(ctx, constants.SuccessLogoutMessage, nil)
+}
+
+func (c *AuthController) LogoutAll(ctx *fiber.Ctx) error {
+	auth := ctx.Locals("auth").(*model.AuthAdmin)
+
+	if err := c.UseCase.LogoutAll(ctx.Context(), auth); err != nil {
+		c.Log.Errorf("Failed to logout admin from all sessions: %v", err)
+		return helper.ResponseErrorFromErr(ctx, err, constants.FailedLogoutMessage, nil)
+	}
+
+	return helper.ResponseSuccessWithoutData(ctx, constants.SuccessLogoutMessage, nil)
+}
+
+// JWKS serves the JSON Web Key Set as is, without the usual response
+// envelope, so standard JWT libraries can consume it.
+func (c *AuthController) JWKS(ctx *fiber.Ctx) error {
+	ctx.Set(fiber.HeaderCacheControl, "public, max-age=300")
+	return ctx.JSON(c.UseCase.JWKS())
+}
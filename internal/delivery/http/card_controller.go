@@ -0,0 +1,171 @@
+package http
+
+import (
+	"strconv"
+	"test-kerja-mkp/internal/constants"
+	"test-kerja-mkp/internal/helper"
+	"test-kerja-mkp/internal/model"
+	"test-kerja-mkp/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type CardController struct {
+	Log     *logrus.Logger
+	UseCase *usecase.CardUseCase
+}
+
+func NewCardController(usecase *usecase.CardUseCase, log *logrus.Logger) *CardController {
+	return &CardController{
+		Log:     log,
+		UseCase: usecase,
+	}
+}
+
+func (c *CardController) GetAll(ctx *fiber.Ctx) error {
+	request := &model.SearchCardRequest{
+		Status:     ctx.Query("status"),
+		MinBalance: ctx.Query("min_balance"),
+		MaxBalance: ctx.Query("max_balance"),
+		Sort:       ctx.Query("sort"),
+		Order:      ctx.Query("order"),
+		Page:       ctx.QueryInt("page", 1),
+		Size:       ctx.QueryInt("size", 10),
+	}
+
+	if errors := helper.ValidateStruct(ctx, request); errors != nil {
+		c.Log.Warnf("Validation failed: %v", errors)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidParamsMessage, errors)
+	}
+
+	cards, paging, err := c.UseCase.FindAll(ctx.Context(), request)
+	if err != nil {
+		return helper.ResponseErrorFromErr(ctx, err, constants.FailedGetDataMessage, nil)
+	}
+
+	return helper.ResponseSuccessPagination(ctx, cards, constants.SuccessGetDataMessage, paging)
+}
+
+func (c *CardController) FindById(ctx *fiber.Ctx) error {
+	cardNumber, err := strconv.ParseInt(ctx.Params("card_number"), 10, 64)
+	if err != nil {
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidParamsMessage, nil)
+	}
+
+	response, err := c.UseCase.FindById(ctx.Context(), cardNumber)
+	if err != nil {
+		return helper.ResponseErrorFromErr(ctx, err, constants.FailedFindDataMessage, nil)
+	}
+
+	return helper.ResponseSuccess(ctx, constants.SuccessFindDataMessage, response)
+}
+
+func (c *CardController) Issue(ctx *fiber.Ctx) error {
+	response, err := c.UseCase.Issue(ctx.Context())
+	if err != nil {
+		c.Log.Warnf("Failed to issue card: %v", err)
+		return helper.ResponseErrorFromErr(ctx, err, constants.FailedCreateMessage, nil)
+	}
+
+	return helper.ResponseSuccess(ctx, constants.SuccessCreateMessage, response)
+}
+
+func (c *CardController) UpdateStatus(ctx *fiber.Ctx) error {
+	cardNumber, err := strconv.ParseInt(ctx.Params("card_number"), 10, 64)
+	if err != nil {
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidParamsMessage, nil)
+	}
+
+	request := new(model.UpdateCardStatusRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Failed to parse request body: %v", err)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidRequestMessage, nil)
+	}
+	request.CardNumber = cardNumber
+
+	if errors := helper.ValidateStruct(ctx, request); errors != nil {
+		c.Log.Warnf("Validation failed: %v", errors)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidRequestMessage, errors)
+	}
+
+	response, err := c.UseCase.UpdateStatus(ctx.Context(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to update card status: %v", err)
+		return helper.ResponseErrorFromErr(ctx, err, constants.FailedUpdateMessage, nil)
+	}
+
+	return helper.ResponseSuccess(ctx, constants.SuccessUpdateMessage, response)
+}
+
+func (c *CardController) Block(ctx *fiber.Ctx) error {
+	auth := ctx.Locals("auth").(*model.AuthAdmin)
+
+	cardNumber, err := strconv.ParseInt(ctx.Params("card_number"), 10, 64)
+	if err != nil {
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidParamsMessage, nil)
+	}
+
+	request := new(model.BlockCardRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Failed to parse request body: %v", err)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidRequestMessage, nil)
+	}
+	request.CardNumber = cardNumber
+
+	if errors := helper.ValidateStruct(ctx, request); errors != nil {
+		c.Log.Warnf("Validation failed: %v", errors)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidRequestMessage, errors)
+	}
+
+	response, err := c.UseCase.Block(ctx.Context(), auth, request)
+	if err != nil {
+		c.Log.Warnf("Failed to block card: %v", err)
+		return helper.ResponseErrorFromErr(ctx, err, constants.FailedUpdateMessage, nil)
+	}
+
+	return helper.ResponseSuccess(ctx, constants.SuccessBlockCardMessage, response)
+}
+
+func (c *CardController) Unblock(ctx *fiber.Ctx) error {
+	auth := ctx.Locals("auth").(*model.AuthAdmin)
+
+	cardNumber, err := strconv.ParseInt(ctx.Params("card_number"), 10, 64)
+	if err != nil {
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidParamsMessage, nil)
+	}
+
+	request := &model.UnblockCardRequest{CardNumber: cardNumber}
+	response, err := c.UseCase.Unblock(ctx.Context(), auth, request)
+	if err != nil {
+		c.Log.Warnf("Failed to unblock card: %v", err)
+		return helper.ResponseErrorFromErr(ctx, err, constants.FailedUpdateMessage, nil)
+	}
+
+	return helper.ResponseSuccess(ctx, constants.SuccessUnblockCardMessage, response)
+}
+
+// Hotlist is downloaded by the authenticated gate. Without ?since=N it returns
+// the full hotlist, otherwise the changes after version N.
+func (c *CardController) Hotlist(ctx *fiber.Ctx) error {
+	request := new(model.HotlistRequest)
+	if since := ctx.Query("since"); since != "" {
+		version, err := strconv.ParseInt(since, 10, 64)
+		if err != nil {
+			return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidParamsMessage, nil)
+		}
+		request.Since = &version
+	}
+
+	if errors := helper.ValidateStruct(ctx, request); errors != nil {
+		c.Log.Warnf("Validation failed: %v", errors)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidParamsMessage, errors)
+	}
+
+	response, err := c.UseCase.Hotlist(ctx.Context(), request)
+	if err != nil {
+		return helper.ResponseErrorFromErr(ctx, err, constants.FailedGetDataMessage, nil)
+	}
+
+	return helper.ResponseSuccess(ctx, constants.SuccessGetDataMessage, response)
+}
@@ -0,0 +1,129 @@
+package http
+
+import (
+	"strconv"
+	"test-kerja-mkp/internal/constants"
+	"test-kerja-mkp/internal/helper"
+	"test-kerja-mkp/internal/model"
+	"test-kerja-mkp/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type CardProductController struct {
+	Log     *logrus.Logger
+	UseCase *usecase.CardProductUseCase
+}
+
+func NewCardProductController(usecase *usecase.CardProductUseCase, log *logrus.Logger) *CardProductController {
+	return &CardProductController{
+		Log:     log,
+		UseCase: usecase,
+	}
+}
+
+func (c *CardProductController) GetAll(ctx *fiber.Ctx) error {
+	responses, err := c.UseCase.FindAll(ctx.Context())
+	if err != nil {
+		return helper.ResponseErrorFromErr(ctx, err, constants.FailedGetDataMessage, nil)
+	}
+
+	return helper.ResponseSuccess(ctx, constants.SuccessGetDataMessage, responses)
+}
+
+func (c *CardProductController) Create(ctx *fiber.Ctx) error {
+	request := new(model.CreateCardProductRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Failed to parse request body: %v", err)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidRequestMessage, nil)
+	}
+
+	if errors := helper.ValidateStruct(ctx, request); errors != nil {
+		c.Log.Warnf("Validation failed: %v", errors)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidRequestMessage, errors)
+	}
+
+	response, err := c.UseCase.Create(ctx.Context(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to create card product: %v", err)
+		return helper.ResponseErrorFromErr(ctx, err, constants.FailedCreateMessage, nil)
+	}
+
+	return helper.ResponseSuccess(ctx, constants.SuccessCreateMessage, response)
+}
+
+func (c *CardProductController) Update(ctx *fiber.Ctx) error {
+	productID, err := strconv.ParseInt(ctx.Params("product_id"), 10, 64)
+	if err != nil {
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidParamsMessage, nil)
+	}
+
+	request := new(model.UpdateCardProductRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Failed to parse request body: %v", err)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidRequestMessage, nil)
+	}
+	request.ID = productID
+
+	if errors := helper.ValidateStruct(ctx, request); errors != nil {
+		c.Log.Warnf("Validation failed: %v", errors)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidRequestMessage, errors)
+	}
+
+	response, err := c.UseCase.Update(ctx.Context(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to update card product: %v", err)
+		return helper.ResponseErrorFromErr(ctx, err, constants.FailedUpdateMessage, nil)
+	}
+
+	return helper.ResponseSuccess(ctx, constants.SuccessUpdateMessage, response)
+}
+
+func (c *CardProductController) AssignToCard(ctx *fiber.Ctx) error {
+	cardNumber, err := strconv.ParseInt(ctx.Params("card_number"), 10, 64)
+	if err != nil {
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidParamsMessage, nil)
+	}
+
+	request := new(model.AssignCardProductRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Failed to parse request body: %v", err)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidRequestMessage, nil)
+	}
+	request.CardNumber = cardNumber
+
+	if errors := helper.ValidateStruct(ctx, request); errors != nil {
+		c.Log.Warnf("Validation failed: %v", errors)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidRequestMessage, errors)
+	}
+
+	response, err := c.UseCase.AssignToCard(ctx.Context(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to assign card product: %v", err)
+		return helper.ResponseErrorFromErr(ctx, err, constants.FailedUpdateMessage, nil)
+	}
+
+	return helper.ResponseSuccess(ctx, constants.SuccessUpdateMessage, response)
+}
+
+// ConcessionUsage reports the usage of card products between ?from and ?to,
+// both local dates formatted as 2006-01-02.
+func (c *CardProductController) ConcessionUsage(ctx *fiber.Ctx) error {
+	request := &model.ConcessionUsageRequest{
+		From: ctx.Query("from"),
+		To:   ctx.Query("to"),
+	}
+
+	if errors := helper.ValidateStruct(ctx, request); errors != nil {
+		c.Log.Warnf("Validation failed: %v", errors)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidParamsMessage, errors)
+	}
+
+	responses, err := c.UseCase.ConcessionUsage(ctx.Context(), request)
+	if err != nil {
+		return helper.ResponseErrorFromErr(ctx, err, constants.FailedGetDataMessage, nil)
+	}
+
+	return helper.ResponseSuccess(ctx, constants.SuccessGetDataMessage, responses)
+}
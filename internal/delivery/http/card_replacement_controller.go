@@ -0,0 +1,53 @@
+package http
+
+import (
+	"strconv"
+	"test-kerja-mkp/internal/constants"
+	"test-kerja-mkp/internal/helper"
+	"test-kerja-mkp/internal/model"
+	"test-kerja-mkp/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type CardReplacementController struct {
+	Log     *logrus.Logger
+	UseCase *usecase.CardReplacementUseCase
+}
+
+func NewCardReplacementController(usecase *usecase.CardReplacementUseCase, log *logrus.Logger) *CardReplacementController {
+	return &CardReplacementController{
+		Log:     log,
+		UseCase: usecase,
+	}
+}
+
+func (c *CardReplacementController) Replace(ctx *fiber.Ctx) error {
+	auth := ctx.Locals("auth").(*model.AuthAdmin)
+
+	cardNumber, err := strconv.ParseInt(ctx.Params("card_number"), 10, 64)
+	if err != nil {
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidParamsMessage, nil)
+	}
+
+	request := new(model.ReplaceCardRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Failed to parse request body: %v", err)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidRequestMessage, nil)
+	}
+	request.CardNumber = cardNumber
+
+	if errors := helper.ValidateStruct(ctx, request); errors != nil {
+		c.Log.Warnf("Validation failed: %v", errors)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidRequestMessage, errors)
+	}
+
+	response, err := c.UseCase.Replace(ctx.Context(), auth, request)
+	if err != nil {
+		c.Log.Warnf("Failed to replace card: %v", err)
+		return helper.ResponseErrorFromErr(ctx, err, constants.FailedUpdateMessage, nil)
+	}
+
+	return helper.ResponseSuccess(ctx, constants.SuccessReplaceCardMessage, response)
+}
@@ -0,0 +1,64 @@
+package http
+
+import (
+	"test-kerja-mkp/internal/constants"
+	"test-kerja-mkp/internal/helper"
+	"test-kerja-mkp/internal/model"
+	"test-kerja-mkp/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type ChannelPartnerController struct {
+	Log     *logrus.Logger
+	UseCase *usecase.ChannelPartnerUseCase
+}
+
+func NewChannelPartnerController(usecase *usecase.ChannelPartnerUseCase, log *logrus.Logger) *ChannelPartnerController {
+	return &ChannelPartnerController{
+		Log:     log,
+		UseCase: usecase,
+	}
+}
+
+func (c *ChannelPartnerController) GetAll(ctx *fiber.Ctx) error {
+	responses, err := c.UseCase.FindAll(ctx.Context())
+	if err != nil {
+		return helper.ResponseErrorFromErr(ctx, err, constants.FailedGetDataMessage, nil)
+	}
+
+	return helper.ResponseSuccess(ctx, constants.SuccessGetDataMessage, responses)
+}
+
+func (c *ChannelPartnerController) Create(ctx *fiber.Ctx) error {
+	auth := ctx.Locals("auth").(*model.AuthAdmin)
+
+	request := new(model.CreateChannelPartnerRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Failed to parse request body: %v", err)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidRequestMessage, nil)
+	}
+
+	if errors := helper.ValidateStruct(ctx, request); errors != nil {
+		c.Log.Warnf("Validation failed: %v", errors)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidRequestMessage, errors)
+	}
+
+	response, err := c.UseCase.Create(ctx.Context(), auth, request)
+	if err != nil {
+		c.Log.Warnf("Failed to create channel partner: %v", err)
+		return helper.ResponseErrorFromErr(ctx, err, constants.FailedCreateMessage, nil)
+	}
+
+	return helper.ResponseSuccess(ctx, constants.SuccessCreateMessage, response)
+}
+
+func (c *ChannelPartnerController) Disable(ctx *fiber.Ctx) error {
+	if err := c.UseCase.Disable(ctx.Context(), ctx.Params("partner_id")); err != nil {
+		c.Log.Warnf("Failed to disable channel partner: %v", err)
+		return helper.ResponseErrorFromErr(ctx, err, constants.FailedUpdateMessage, nil)
+	}
+
+	return helper.ResponseSuccessWithoutData(ctx, constants.SuccessDisablePartnerMessage, nil)
+}
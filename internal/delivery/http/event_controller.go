@@ -0,0 +1,139 @@
+package http
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"test-kerja-mkp/internal/constants"
+	"test-kerja-mkp/internal/helper"
+	"test-kerja-mkp/internal/model"
+	"test-kerja-mkp/internal/usecase"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// eventKeepAliveInterval is how often a comment is sent on an idle stream, so
+// proxies keep the connection open and disconnected clients are noticed.
+const eventKeepAliveInterval = 15 * time.Second
+
+type EventController struct {
+	Log         *logrus.Logger
+	Broker      *helper.EventBroker
+	AuthUseCase *usecase.AuthUseCase
+}
+
+func NewEventController(broker *helper.EventBroker, authUseCase *usecase.AuthUseCase, log *logrus.Logger) *EventController {
+	return &EventController{
+		Log:         log,
+		Broker:      broker,
+		AuthUseCase: authUseCase,
+	}
+}
+
+// Stream sends the real-time events as Server-Sent Events. The comma separated
+// query parameters terminal_id and type narrow the stream down. When the
+// client falls behind, the events that no longer fit its buffer are dropped
+// and a "dropped" event tells it how many were lost. The stream is closed when
+// the access token expires, and on every keep-alive the token and its session
+// are checked again so a logout or a disabled admin ends the stream too.
+func (c *EventController) Stream(ctx *fiber.Ctx) error {
+	auth := ctx.Locals("auth").(*model.AuthAdmin)
+
+	request := &model.EventStreamRequest{
+		Types: splitQuery(ctx.Query("type")),
+	}
+	for _, value := range splitQuery(ctx.Query("terminal_id")) {
+		id, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidParamsMessage, nil)
+		}
+		request.TerminalIDs = append(request.TerminalIDs, id)
+	}
+
+	if errors := helper.ValidateStruct(ctx, request); errors != nil {
+		c.Log.Warnf("Validation failed: %v", errors)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidParamsMessage, errors)
+	}
+
+	ctx.Set(fiber.HeaderContentType, "text/event-stream")
+	ctx.Set(fiber.HeaderCacheControl, "no-cache")
+	ctx.Set(fiber.HeaderConnection, "keep-alive")
+	ctx.Set("X-Accel-Buffering", "no")
+
+	// The stream writer runs after the handler returns, when the fiber context
+	// is already released, so nothing from ctx may be used inside it.
+	broker, authUseCase, log := c.Broker, c.AuthUseCase, c.Log
+	ctx.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		subscription := broker.Subscribe(request.TerminalIDs, request.Types)
+		defer broker.Unsubscribe(subscription)
+
+		keepAlive := time.NewTicker(eventKeepAliveInterval)
+		defer keepAlive.Stop()
+
+		expiry := time.NewTimer(time.Until(auth.ExpiresAt))
+		defer expiry.Stop()
+
+		fmt.Fprint(w, "retry: 5000\n\n")
+		if err := w.Flush(); err != nil {
+			return
+		}
+
+		for {
+			select {
+			case event := <-subscription.Events:
+				if dropped := subscription.Dropped(); dropped > 0 {
+					if err := writeEvent(w, "", "dropped", &model.EventsDroppedResponse{Count: dropped}); err != nil {
+						log.Warnf("Failed to write event: %v", err)
+						return
+					}
+				}
+				if err := writeEvent(w, strconv.FormatUint(event.ID, 10), event.Type, event); err != nil {
+					log.Warnf("Failed to write event: %v", err)
+					return
+				}
+			case <-keepAlive.C:
+				if err := authUseCase.CheckSession(context.Background(), auth); err != nil {
+					log.Warnf("Closing event stream of admin %d: %v", auth.ID, err)
+					return
+				}
+				fmt.Fprint(w, ": keep-alive\n\n")
+			case <-expiry.C:
+				log.Warnf("Closing event stream of admin %d: token expired", auth.ID)
+				return
+			}
+
+			// A failed flush means the client went away.
+			if err := w.Flush(); err != nil {
+				return
+			}
+		}
+	})
+	return nil
+}
+
+func writeEvent(w *bufio.Writer, id string, eventType string, data any) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	if id != "" {
+		fmt.Fprintf(w, "id: %s\n", id)
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", eventType, payload)
+	return err
+}
+
+func splitQuery(value string) []string {
+	var values []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			values = append(values, item)
+		}
+	}
+	return values
+}
@@ -0,0 +1,138 @@
+package http
+
+import (
+	"strconv"
+	"test-kerja-mkp/internal/constants"
+	"test-kerja-mkp/internal/helper"
+	"test-kerja-mkp/internal/model"
+	"test-kerja-mkp/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// defaultGateCommandTTL is how long, in seconds, a command waits for the gate
+// when the request does not say.
+const defaultGateCommandTTL = 300
+
+type GateCommandController struct {
+	Log     *logrus.Logger
+	UseCase *usecase.GateCommandUseCase
+}
+
+func NewGateCommandController(usecase *usecase.GateCommandUseCase, log *logrus.Logger) *GateCommandController {
+	return &GateCommandController{
+		Log:     log,
+		UseCase: usecase,
+	}
+}
+
+func (c *GateCommandController) GetAll(ctx *fiber.Ctx) error {
+	gateID, err := strconv.ParseInt(ctx.Params("gate_id"), 10, 64)
+	if err != nil {
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidParamsMessage, nil)
+	}
+
+	responses, err := c.UseCase.FindAllByGateId(ctx.Context(), gateID)
+	if err != nil {
+		return helper.ResponseErrorFromErr(ctx, err, constants.FailedGetDataMessage, nil)
+	}
+
+	return helper.ResponseSuccess(ctx, constants.SuccessGetDataMessage, responses)
+}
+
+func (c *GateCommandController) Issue(ctx *fiber.Ctx) error {
+	auth := ctx.Locals("auth").(*model.AuthAdmin)
+
+	gateID, err := strconv.ParseInt(ctx.Params("gate_id"), 10, 64)
+	if err != nil {
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidParamsMessage, nil)
+	}
+
+	request := &model.IssueGateCommandRequest{TTL: defaultGateCommandTTL}
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Failed to parse request body: %v", err)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidRequestMessage, nil)
+	}
+	request.GateID = gateID
+
+	if errors := helper.ValidateStruct(ctx, request); errors != nil {
+		c.Log.Warnf("Validation failed: %v", errors)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidRequestMessage, errors)
+	}
+
+	response, err := c.UseCase.Issue(ctx.Context(), auth, request)
+	if err != nil {
+		c.Log.Warnf("Failed to issue gate command: %v", err)
+		return helper.ResponseErrorFromErr(ctx, err, constants.FailedCreateMessage, nil)
+	}
+
+	return helper.ResponseSuccess(ctx, constants.SuccessCreateMessage, response)
+}
+
+func (c *GateCommandController) Cancel(ctx *fiber.Ctx) error {
+	gateID, err := strconv.ParseInt(ctx.Params("gate_id"), 10, 64)
+	if err != nil {
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidParamsMessage, nil)
+	}
+	commandID, err := strconv.ParseInt(ctx.Params("command_id"), 10, 64)
+	if err != nil {
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidParamsMessage, nil)
+	}
+
+	request := &model.CancelGateCommandRequest{GateID: gateID, ID: commandID}
+	if err := c.UseCase.Cancel(ctx.Context(), request); err != nil {
+		c.Log.Warnf("Failed to cancel gate command: %v", err)
+		return helper.ResponseErrorFromErr(ctx, err, constants.FailedUpdateMessage, nil)
+	}
+
+	return helper.ResponseSuccessWithoutData(ctx, constants.SuccessCancelGateCommandMessage, nil)
+}
+
+// Pull returns the open commands of the authenticated gate. With ?wait=N the
+// request is held for up to N seconds until a command is issued.
+func (c *GateCommandController) Pull(ctx *fiber.Ctx) error {
+	gate := ctx.Locals("gate").(*model.AuthGate)
+
+	request := &model.PullGateCommandRequest{Wait: ctx.QueryInt("wait", 0)}
+	if errors := helper.ValidateStruct(ctx, request); errors != nil {
+		c.Log.Warnf("Validation failed: %v", errors)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidParamsMessage, errors)
+	}
+
+	responses, err := c.UseCase.Pull(ctx.Context(), gate, request)
+	if err != nil {
+		return helper.ResponseErrorFromErr(ctx, err, constants.FailedGetDataMessage, nil)
+	}
+
+	return helper.ResponseSuccess(ctx, constants.SuccessGetDataMessage, responses)
+}
+
+func (c *GateCommandController) Ack(ctx *fiber.Ctx) error {
+	gate := ctx.Locals("gate").(*model.AuthGate)
+
+	commandID, err := strconv.ParseInt(ctx.Params("command_id"), 10, 64)
+	if err != nil {
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidParamsMessage, nil)
+	}
+
+	request := new(model.AckGateCommandRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Failed to parse request body: %v", err)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidRequestMessage, nil)
+	}
+	request.ID = commandID
+
+	if errors := helper.ValidateStruct(ctx, request); errors != nil {
+		c.Log.Warnf("Validation failed: %v", errors)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidRequestMessage, errors)
+	}
+
+	response, err := c.UseCase.Ack(ctx.Context(), gate, request)
+	if err != nil {
+		c.Log.Warnf("Failed to acknowledge gate command: %v", err)
+		return helper.ResponseErrorFromErr(ctx, err, constants.FailedUpdateMessage, nil)
+	}
+
+	return helper.ResponseSuccess(ctx, constants.SuccessAckGateCommandMessage, response)
+}
@@ -0,0 +1,147 @@
+package http
+
+import (
+	"strconv"
+	"test-kerja-mkp/internal/constants"
+	"test-kerja-mkp/internal/helper"
+	"test-kerja-mkp/internal/model"
+	"test-kerja-mkp/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type GateController struct {
+	Log     *logrus.Logger
+	UseCase *usecase.GateUseCase
+}
+
+func NewGateController(usecase *usecase.GateUseCase, log *logrus.Logger) *GateController {
+	return &GateController{
+		Log:     log,
+		UseCase: usecase,
+	}
+}
+
+func (c *GateController) GetAll(ctx *fiber.Ctx) error {
+	terminalID, err := strconv.ParseInt(ctx.Params("terminal_id"), 10, 64)
+	if err != nil {
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidParamsMessage, nil)
+	}
+
+	responses, err := c.UseCase.FindAllByTerminalId(ctx.Context(), terminalID, ctx.QueryBool("include_decommissioned", false))
+	if err != nil {
+		return helper.ResponseErrorFromErr(ctx, err, constants.FailedGetDataMessage, nil)
+	}
+
+	return helper.ResponseSuccess(ctx, constants.SuccessGetDataMessage, responses)
+}
+
+func (c *GateController) Create(ctx *fiber.Ctx) error {
+	terminalID, err := strconv.ParseInt(ctx.Params("terminal_id"), 10, 64)
+	if err != nil {
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidParamsMessage, nil)
+	}
+
+	request := new(model.CreateGateRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Failed to parse request body: %v", err)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidRequestMessage, nil)
+	}
+	request.TerminalID = terminalID
+
+	if errors := helper.ValidateStruct(ctx, request); errors != nil {
+		c.Log.Warnf("Validation failed: %v", errors)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidRequestMessage, errors)
+	}
+
+	response, err := c.UseCase.Create(ctx.Context(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to create gate: %v", err)
+		return helper.ResponseErrorFromErr(ctx, err, constants.FailedCreateMessage, nil)
+	}
+
+	return helper.ResponseSuccess(ctx, constants.SuccessCreateMessage, response)
+}
+
+func (c *GateController) Rename(ctx *fiber.Ctx) error {
+	terminalID, gateID, err := gateParams(ctx)
+	if err != nil {
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidParamsMessage, nil)
+	}
+
+	request := new(model.RenameGateRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Failed to parse request body: %v", err)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidRequestMessage, nil)
+	}
+	request.TerminalID = terminalID
+	request.ID = gateID
+
+	if errors := helper.ValidateStruct(ctx, request); errors != nil {
+		c.Log.Warnf("Validation failed: %v", errors)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidRequestMessage, errors)
+	}
+
+	response, err := c.UseCase.Rename(ctx.Context(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to rename gate: %v", err)
+		return helper.ResponseErrorFromErr(ctx, err, constants.FailedUpdateMessage, nil)
+	}
+
+	return helper.ResponseSuccess(ctx, constants.SuccessUpdateMessage, response)
+}
+
+func (c *GateController) Move(ctx *fiber.Ctx) error {
+	terminalID, gateID, err := gateParams(ctx)
+	if err != nil {
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidParamsMessage, nil)
+	}
+
+	request := new(model.MoveGateRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Failed to parse request body: %v", err)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidRequestMessage, nil)
+	}
+	request.TerminalID = terminalID
+	request.ID = gateID
+
+	if errors := helper.ValidateStruct(ctx, request); errors != nil {
+		c.Log.Warnf("Validation failed: %v", errors)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidRequestMessage, errors)
+	}
+
+	response, err := c.UseCase.Move(ctx.Context(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to move gate: %v", err)
+		return helper.ResponseErrorFromErr(ctx, err, constants.FailedUpdateMessage, nil)
+	}
+
+	return helper.ResponseSuccess(ctx, constants.SuccessUpdateMessage, response)
+}
+
+func (c *GateController) Decommission(ctx *fiber.Ctx) error {
+	terminalID, gateID, err := gateParams(ctx)
+	if err != nil {
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidParamsMessage, nil)
+	}
+
+	if err := c.UseCase.Decommission(ctx.Context(), terminalID, gateID); err != nil {
+		c.Log.Warnf("Failed to decommission gate: %v", err)
+		return helper.ResponseErrorFromErr(ctx, err, constants.FailedDeleteMessage, nil)
+	}
+
+	return helper.ResponseSuccessWithoutData(ctx, constants.SuccessDecommissionGateMessage, nil)
+}
+
+func gateParams(ctx *fiber.Ctx) (int64, int64, error) {
+	terminalID, err := strconv.ParseInt(ctx.Params("terminal_id"), 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	gateID, err := strconv.ParseInt(ctx.Params("gate_id"), 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	return terminalID, gateID, nil
+}
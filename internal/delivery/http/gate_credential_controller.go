@@ -0,0 +1,102 @@
+package http
+
+import (
+	"context"
+	"strconv"
+	"test-kerja-mkp/internal/constants"
+	"test-kerja-mkp/internal/helper"
+	"test-kerja-mkp/internal/model"
+	"test-kerja-mkp/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type GateCredentialController struct {
+	Log     *logrus.Logger
+	UseCase *usecase.GateCredentialUseCase
+}
+
+func NewGateCredentialController(usecase *usecase.GateCredentialUseCase, log *logrus.Logger) *GateCredentialController {
+	return &GateCredentialController{
+		Log:     log,
+		UseCase: usecase,
+	}
+}
+
+func (c *GateCredentialController) GetAll(ctx *fiber.Ctx) error {
+	gateID, err := strconv.ParseInt(ctx.Params("gate_id"), 10, 64)
+	if err != nil {
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidParamsMessage, nil)
+	}
+
+	response, err := c.UseCase.FindAllByGateId(ctx.Context(), gateID)
+	if err != nil {
+		return helper.ResponseErrorFromErr(ctx, err, constants.FailedGetDataMessage, nil)
+	}
+
+	return helper.ResponseSuccess(ctx, constants.SuccessGetDataMessage, response)
+}
+
+func (c *GateCredentialController) Issue(ctx *fiber.Ctx) error {
+	return c.issue(ctx, c.UseCase.Issue)
+}
+
+func (c *GateCredentialController) Rotate(ctx *fiber.Ctx) error {
+	return c.issue(ctx, c.UseCase.Rotate)
+}
+
+func (c *GateCredentialController) Revoke(ctx *fiber.Ctx) error {
+	gateID, err := strconv.ParseInt(ctx.Params("gate_id"), 10, 64)
+	if err != nil {
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidParamsMessage, nil)
+	}
+
+	request := &model.RevokeGateCredentialRequest{
+		GateID:       gateID,
+		CredentialID: ctx.Params("credential_id"),
+	}
+
+	if err := c.UseCase.Revoke(ctx.Context(), request); err != nil {
+		c.Log.Warnf("Failed to revoke gate credential: %v", err)
+		return helper.ResponseErrorFromErr(ctx, err, constants.FailedDeleteMessage, nil)
+	}
+
+	return helper.ResponseSuccessWithoutData(ctx, constants.SuccessRevokeGateCredentialMessage, nil)
+}
+
+// Me returns the gate the request was authenticated as, so a device can
+// check its credential after provisioning.
+func (c *GateCredentialController) Me(ctx *fiber.Ctx) error {
+	gate := ctx.Locals("gate").(*model.AuthGate)
+	return helper.ResponseSuccess(ctx, constants.SuccessFindDataMessage, gate)
+}
+
+func (c *GateCredentialController) issue(ctx *fiber.Ctx, issue func(context.Context, *model.AuthAdmin, *model.GateCredentialRequest) (*model.GateCredentialSecretResponse, error)) error {
+	auth := ctx.Locals("auth").(*model.AuthAdmin)
+
+	gateID, err := strconv.ParseInt(ctx.Params("gate_id"), 10, 64)
+	if err != nil {
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidParamsMessage, nil)
+	}
+
+	request := new(model.GateCredentialRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Failed to parse request body: %v", err)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidRequestMessage, nil)
+	}
+	request.GateID = gateID
+
+	if errors := helper.ValidateStruct(ctx, request); errors != nil {
+		c.Log.Warnf("Validation failed: %v", errors)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidRequestMessage, errors)
+	}
+
+	response, err := issue(ctx.Context(), auth, request)
+	if err != nil {
+		c.Log.Warnf("Failed to issue gate credential: %v", err)
+		return helper.ResponseErrorFromErr(ctx, err, constants.FailedCreateMessage, nil)
+	}
+
+	return helper.ResponseSuccess(ctx, constants.SuccessCreateMessage, response)
+}
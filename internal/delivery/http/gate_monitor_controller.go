@@ -0,0 +1,76 @@
+package http
+
+import (
+	"strconv"
+	"test-kerja-mkp/internal/constants"
+	"test-kerja-mkp/internal/helper"
+	"test-kerja-mkp/internal/model"
+	"test-kerja-mkp/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type GateMonitorController struct {
+	Log     *logrus.Logger
+	UseCase *usecase.GateMonitorUseCase
+}
+
+func NewGateMonitorController(usecase *usecase.GateMonitorUseCase, log *logrus.Logger) *GateMonitorController {
+	return &GateMonitorController{
+		Log:     log,
+		UseCase: usecase,
+	}
+}
+
+// Heartbeat is called periodically by the authenticated gate device.
+func (c *GateMonitorController) Heartbeat(ctx *fiber.Ctx) error {
+	gate := ctx.Locals("gate").(*model.AuthGate)
+
+	request := new(model.GateHeartbeatRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Failed to parse request body: %v", err)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidRequestMessage, nil)
+	}
+
+	if errors := helper.ValidateStruct(ctx, request); errors != nil {
+		c.Log.Warnf("Validation failed: %v", errors)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidRequestMessage, errors)
+	}
+
+	response, err := c.UseCase.Heartbeat(ctx.Context(), gate, request)
+	if err != nil {
+		c.Log.Warnf("Failed to record heartbeat: %v", err)
+		return helper.ResponseErrorFromErr(ctx, err, constants.FailedUpdateMessage, nil)
+	}
+
+	return helper.ResponseSuccess(ctx, constants.SuccessHeartbeatMessage, response)
+}
+
+func (c *GateMonitorController) Dashboard(ctx *fiber.Ctx) error {
+	terminalID, err := strconv.ParseInt(ctx.Params("terminal_id"), 10, 64)
+	if err != nil {
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidParamsMessage, nil)
+	}
+
+	response, err := c.UseCase.Dashboard(ctx.Context(), terminalID)
+	if err != nil {
+		return helper.ResponseErrorFromErr(ctx, err, constants.FailedGetDataMessage, nil)
+	}
+
+	return helper.ResponseSuccess(ctx, constants.SuccessGetDataMessage, response)
+}
+
+func (c *GateMonitorController) History(ctx *fiber.Ctx) error {
+	terminalID, gateID, err := gateParams(ctx)
+	if err != nil {
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidParamsMessage, nil)
+	}
+
+	responses, err := c.UseCase.History(ctx.Context(), terminalID, gateID)
+	if err != nil {
+		return helper.ResponseErrorFromErr(ctx, err, constants.FailedGetDataMessage, nil)
+	}
+
+	return helper.ResponseSuccess(ctx, constants.SuccessGetDataMessage, responses)
+}
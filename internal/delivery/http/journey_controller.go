@@ -0,0 +1,71 @@
+package http
+
+import (
+	"test-kerja-mkp/internal/constants"
+	"test-kerja-mkp/internal/helper"
+	"test-kerja-mkp/internal/model"
+	"test-kerja-mkp/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type JourneyController struct {
+	Log     *logrus.Logger
+	UseCase *usecase.JourneyUseCase
+}
+
+func NewJourneyController(usecase *usecase.JourneyUseCase, log *logrus.Logger) *JourneyController {
+	return &JourneyController{
+		Log:     log,
+		UseCase: usecase,
+	}
+}
+
+// CheckIn is called by the authenticated gate when a card is tapped on entry.
+func (c *JourneyController) CheckIn(ctx *fiber.Ctx) error {
+	gate := ctx.Locals("gate").(*model.AuthGate)
+
+	request := new(model.CheckInRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Failed to parse request body: %v", err)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidRequestMessage, nil)
+	}
+
+	if errors := helper.ValidateStruct(ctx, request); errors != nil {
+		c.Log.Warnf("Validation failed: %v", errors)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidRequestMessage, errors)
+	}
+
+	response, err := c.UseCase.CheckIn(ctx.Context(), gate, request)
+	if err != nil {
+		c.Log.Warnf("Failed to check in: %v", err)
+		return helper.ResponseErrorFromErr(ctx, err, constants.FailedCheckInMessage, nil)
+	}
+
+	return helper.ResponseSuccess(ctx, constants.SuccessCheckInMessage, response)
+}
+
+// CheckOut is called by the authenticated gate when a card is tapped on exit.
+func (c *JourneyController) CheckOut(ctx *fiber.Ctx) error {
+	gate := ctx.Locals("gate").(*model.AuthGate)
+
+	request := new(model.CheckOutRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Failed to parse request body: %v", err)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidRequestMessage, nil)
+	}
+
+	if errors := helper.ValidateStruct(ctx, request); errors != nil {
+		c.Log.Warnf("Validation failed: %v", errors)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidRequestMessage, errors)
+	}
+
+	response, err := c.UseCase.CheckOut(ctx.Context(), gate, request)
+	if err != nil {
+		c.Log.Warnf("Failed to check out: %v", err)
+		return helper.ResponseErrorFromErr(ctx, err, constants.FailedCheckOutMessage, nil)
+	}
+
+	return helper.ResponseSuccess(ctx, constants.SuccessCheckOutMessage, response)
+}
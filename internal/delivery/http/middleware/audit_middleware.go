@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"errors"
+	"test-kerja-mkp/internal/model"
+	"test-kerja-mkp/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// NewAudit records every mutating admin request in the audit log. It puts a
+// *model.AuditEntry in ctx.Locals("audit") for the use cases to describe the
+// change and persists it after the handler has run, including the actor from
+// ctx.Locals("auth") and the resulting status code.
+func NewAudit(auditUseCase *usecase.AuditUseCase) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		switch ctx.Method() {
+		case fiber.MethodGet, fiber.MethodHead, fiber.MethodOptions:
+			return ctx.Next()
+		}
+
+		entry := &model.AuditEntry{
+			Method:    ctx.Method(),
+			Path:      ctx.Path(),
+			IPAddress: ctx.IP(),
+			UserAgent: ctx.Get(fiber.HeaderUserAgent),
+		}
+		ctx.Locals("audit", entry)
+
+		err := ctx.Next()
+
+		entry.StatusCode = ctx.Response().StatusCode()
+		if err != nil {
+			entry.StatusCode = fiber.StatusInternalServerError
+			var fiberErr *fiber.Error
+			if errors.As(err, &fiberErr) {
+				entry.StatusCode = fiberErr.Code
+			}
+		}
+		if auth, ok := ctx.Locals("auth").(*model.AuthAdmin); ok && entry.ActorID == nil {
+			entry.ActorID = &auth.ID
+		}
+		if entry.Action == "" {
+			entry.Action = ctx.Method() + " " + ctx.Route().Path
+		}
+
+		auditUseCase.Record(ctx.UserContext(), entry)
+		return err
+	}
+}
@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"test-kerja-mkp/internal/constants"
+	"test-kerja-mkp/internal/helper"
+	"test-kerja-mkp/internal/model"
+	"test-kerja-mkp/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	HeaderGateAPIKey    = "X-Gate-Api-Key"
+	HeaderGateKeyID     = "X-Gate-Key-Id"
+	HeaderGateTimestamp = "X-Gate-Timestamp"
+	HeaderGateNonce     = "X-Gate-Nonce"
+	HeaderGateSignature = "X-Gate-Signature"
+)
+
+// NewAuthGate authenticates gate devices with an API key or an HMAC signed
+// request and stores the *model.AuthGate in ctx.Locals("gate").
+func NewAuthGate(gateCredentialUseCase *usecase.GateCredentialUseCase) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		request := &model.VerifyGateRequest{
+			APIKey:    ctx.Get(HeaderGateAPIKey),
+			KeyID:     ctx.Get(HeaderGateKeyID),
+			Timestamp: ctx.Get(HeaderGateTimestamp),
+			Nonce:     ctx.Get(HeaderGateNonce),
+			Signature: ctx.Get(HeaderGateSignature),
+			Method:    ctx.Method(),
+			Path:      ctx.OriginalURL(),
+			Body:      ctx.Body(),
+		}
+
+		gate, err := gateCredentialUseCase.Verify(ctx.UserContext(), request)
+		if err != nil {
+			gateCredentialUseCase.Log.Warnf("Failed authenticate gate : %+v", err)
+			return helper.ResponseError(ctx, fiber.StatusUnauthorized, constants.InvalidGateCredentialMessage, nil)
+		}
+
+		gateCredentialUseCase.Log.Debugf("Gate : %+v", gate.GateID)
+		ctx.Locals("gate", gate)
+		return ctx.Next()
+	}
+}
@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"test-kerja-mkp/internal/constants"
+	"test-kerja-mkp/internal/helper"
+	"test-kerja-mkp/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const HeaderPartnerAPIKey = "X-Partner-Api-Key"
+
+// NewAuthPartner authenticates channel partners with their API key and stores
+// the *model.AuthPartner in ctx.Locals("partner").
+func NewAuthPartner(channelPartnerUseCase *usecase.ChannelPartnerUseCase) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		partner, err := channelPartnerUseCase.Verify(ctx.UserContext(), ctx.Get(HeaderPartnerAPIKey))
+		if err != nil {
+			channelPartnerUseCase.Log.Warnf("Failed authenticate channel partner : %+v", err)
+			return helper.ResponseError(ctx, fiber.StatusUnauthorized, constants.InvalidPartnerKeyMessage, nil)
+		}
+
+		channelPartnerUseCase.Log.Debugf("Partner : %+v", partner.ID)
+		ctx.Locals("partner", partner)
+		return ctx.Next()
+	}
+}
@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"test-kerja-mkp/internal/constants"
+	"test-kerja-mkp/internal/helper"
+	"test-kerja-mkp/internal/model"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// NewPermission only lets the request through when the authenticated admin
+// holds at least one of the given permissions. It must run after NewAuthAdmin.
+func NewPermission(permissions ...string) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		auth, ok := ctx.Locals("auth").(*model.AuthAdmin)
+		if !ok {
+			return helper.ResponseError(ctx, fiber.StatusUnauthorized, constants.InvalidToken, nil)
+		}
+
+		for _, permission := range permissions {
+			if auth.HasPermission(permission) {
+				return ctx.Next()
+			}
+		}
+
+		return helper.ResponseError(ctx, fiber.StatusForbidden, constants.ForbiddenMessage, fiber.Map{
+			"required_permissions": permissions,
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package http
+
+import (
+	"strconv"
+	"test-kerja-mkp/internal/constants"
+	"test-kerja-mkp/internal/helper"
+	"test-kerja-mkp/internal/model"
+	"test-kerja-mkp/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type PasswordController struct {
+	Log     *logrus.Logger
+	UseCase *usecase.PasswordUseCase
+}
+
+func NewPasswordController(usecase *usecase.PasswordUseCase, log *logrus.Logger) *PasswordController {
+	return &PasswordController{
+		Log:     log,
+		UseCase: usecase,
+	}
+}
+
+func (c *PasswordController) ChangePassword(ctx *fiber.Ctx) error {
+	auth := ctx.Locals("auth").(*model.AuthAdmin)
+
+	request := new(model.ChangePasswordRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Failed to parse request body: %v", err)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidRequestMessage, nil)
+	}
+
+	if errors := helper.ValidateStruct(ctx, request); errors != nil {
+		c.Log.Warnf("Validation failed: %v", errors)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidRequestMessage, errors)
+	}
+
+	if err := c.UseCase.ChangePassword(ctx.Context(), auth, request); err != nil {
+		c.Log.Warnf("Failed to change password: %v", err)
+		return helper.ResponseErrorFromErr(ctx, err, constants.FailedChangePasswordMessage, nil)
+	}
+
+	return helper.ResponseSuccessWithoutData(ctx, constants.SuccessChangePasswordMessage, nil)
+}
+
+func (c *PasswordController) IssueReset(ctx *fiber.Ctx) error {
+	auth := ctx.Locals("auth").(*model.AuthAdmin)
+
+	id, err := strconv.ParseInt(ctx.Params("admin_id"), 10, 64)
+	if err != nil {
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidParamsMessage, nil)
+	}
+
+	response, err := c.UseCase.IssueReset(ctx.Context(), auth, id)
+	if err != nil {
+		c.Log.Warnf("Failed to issue password reset: %v", err)
+		return helper.ResponseErrorFromErr(ctx, err, constants.FailedCreateMessage, nil)
+	}
+
+	return helper.ResponseSuccess(ctx, constants.SuccessCreateMessage, response)
+}
+
+func (c *PasswordController) ResetPassword(ctx *fiber.Ctx) error {
+	request := new(model.ResetPasswordRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Failed to parse request body: %v", err)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidRequestMessage, nil)
+	}
+
+	if errors := helper.ValidateStruct(ctx, request); errors != nil {
+		c.Log.Warnf("Validation failed: %v", errors)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidRequestMessage, errors)
+	}
+
+	if err := c.UseCase.ResetPassword(ctx.Context(), request); err != nil {
+		c.Log.Warnf("Failed to reset password: %v", err)
+		return helper.ResponseErrorFromErr(ctx, err, constants.FailedChangePasswordMessage, nil)
+	}
+
+	return helper.ResponseSuccessWithoutData(ctx, constants.SuccessChangePasswordMessage, nil)
+}
@@ -0,0 +1,86 @@
+package http
+
+import (
+	"strconv"
+	"test-kerja-mkp/internal/constants"
+	"test-kerja-mkp/internal/helper"
+	"test-kerja-mkp/internal/model"
+	"test-kerja-mkp/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type RiderController struct {
+	Log     *logrus.Logger
+	UseCase *usecase.RiderUseCase
+}
+
+func NewRiderController(usecase *usecase.RiderUseCase, log *logrus.Logger) *RiderController {
+	return &RiderController{
+		Log:     log,
+		UseCase: usecase,
+	}
+}
+
+func (c *RiderController) Create(ctx *fiber.Ctx) error {
+	request := new(model.CreateRiderRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Failed to parse request body: %v", err)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidRequestMessage, nil)
+	}
+
+	if errors := helper.ValidateStruct(ctx, request); errors != nil {
+		c.Log.Warnf("Validation failed: %v", errors)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidRequestMessage, errors)
+	}
+
+	response, err := c.UseCase.Create(ctx.Context(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to create rider: %v", err)
+		return helper.ResponseErrorFromErr(ctx, err, constants.FailedCreateMessage, nil)
+	}
+
+	return helper.ResponseSuccess(ctx, constants.SuccessCreateMessage, response)
+}
+
+func (c *RiderController) FindById(ctx *fiber.Ctx) error {
+	riderID, err := strconv.ParseInt(ctx.Params("rider_id"), 10, 64)
+	if err != nil {
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidParamsMessage, nil)
+	}
+
+	response, err := c.UseCase.FindById(ctx.Context(), riderID)
+	if err != nil {
+		return helper.ResponseErrorFromErr(ctx, err, constants.FailedFindDataMessage, nil)
+	}
+
+	return helper.ResponseSuccess(ctx, constants.SuccessFindDataMessage, response)
+}
+
+func (c *RiderController) RegisterCard(ctx *fiber.Ctx) error {
+	riderID, err := strconv.ParseInt(ctx.Params("rider_id"), 10, 64)
+	if err != nil {
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidParamsMessage, nil)
+	}
+
+	request := new(model.RegisterCardRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Failed to parse request body: %v", err)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidRequestMessage, nil)
+	}
+	request.RiderID = riderID
+
+	if errors := helper.ValidateStruct(ctx, request); errors != nil {
+		c.Log.Warnf("Validation failed: %v", errors)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidRequestMessage, errors)
+	}
+
+	response, err := c.UseCase.RegisterCard(ctx.Context(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to register card: %v", err)
+		return helper.ResponseErrorFromErr(ctx, err, constants.FailedUpdateMessage, nil)
+	}
+
+	return helper.ResponseSuccess(ctx, constants.SuccessRegisterCardMessage, response)
+}
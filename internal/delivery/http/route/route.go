@@ -1,33 +1,160 @@
 package route
 
 import (
+	"test-kerja-mkp/internal/constants"
 	"test-kerja-mkp/internal/delivery/http"
+	"test-kerja-mkp/internal/delivery/http/middleware"
 
 	"github.com/gofiber/fiber/v2"
 )
 
 type RouteConfig struct {
-	App                   *fiber.App
-	AuthController        *http.AuthController
-	TerminalController    *http.TerminalController
-	AuthMiddleware        fiber.Handler
+	App                        *fiber.App
+	AuthController             *http.AuthController
+	AdminController            *http.AdminController
+	PasswordController         *http.PasswordController
+	TwoFactorController        *http.TwoFactorController
+	TerminalController         *http.TerminalController
+	TerminalScheduleController *http.TerminalScheduleController
+	CardController             *http.CardController
+	CardReplacementController  *http.CardReplacementController
+	CardProductController      *http.CardProductController
+	RiderController            *http.RiderController
+	JourneyController          *http.JourneyController
+	TopUpController            *http.TopUpController
+	ChannelPartnerController   *http.ChannelPartnerController
+	GateController             *http.GateController
+	GateMonitorController      *http.GateMonitorController
+	GateCommandController      *http.GateCommandController
+	GateCredentialController   *http.GateCredentialController
+	AuditController            *http.AuditController
+	EventController            *http.EventController
+	AuthMiddleware             fiber.Handler
+	GateAuthMiddleware         fiber.Handler
+	PartnerAuthMiddleware      fiber.Handler
+	AuditMiddleware            fiber.Handler
 }
 
 func (c *RouteConfig) Setup() {
+	// The audit middleware wraps guest and authenticated admin routes alike so
+	// logins are recorded too.
+	c.App.Use("/api/admin", c.AuditMiddleware)
+
 	c.SetupGuestRoute()
+	c.SetupGateRoute()
+	c.SetupPartnerRoute()
 	c.SetupAuthRoute()
 }
 
 func (c *RouteConfig) SetupGuestRoute() {
+	c.App.Get("/.well-known/jwks.json", c.AuthController.JWKS)
 	c.App.Post("/api/admin/auth/login", c.AuthController.Login)
+	c.App.Post("/api/admin/auth/refresh", c.AuthController.Refresh)
+	c.App.Post("/api/admin/auth/reset-password", c.PasswordController.ResetPassword)
+	c.App.Post("/api/admin/auth/2fa/verify", c.TwoFactorController.VerifyLogin)
+	c.App.Post("/api/admin/auth/2fa/enroll", c.TwoFactorController.EnrollWithChallenge)
+	c.App.Post("/api/admin/auth/2fa/enroll/confirm", c.TwoFactorController.ConfirmWithChallenge)
+	c.App.Get("/api/terminals/nearby", c.TerminalController.Nearby)
+	c.App.Get("/api/terminals/:terminal_id/status", c.TerminalScheduleController.Status)
+
+}
+
+// SetupGateRoute registers the routes called by gate devices. They must be
+// registered before SetupAuthRoute so the admin middleware does not run.
+func (c *RouteConfig) SetupGateRoute() {
+	gate := c.App.Group("/api/gate", c.GateAuthMiddleware)
 
+	gate.Get("/me", c.GateCredentialController.Me)
+	gate.Post("/heartbeat", c.GateMonitorController.Heartbeat)
+	gate.Get("/commands", c.GateCommandController.Pull)
+	gate.Post("/commands/:command_id/ack", c.GateCommandController.Ack)
+	gate.Get("/hotlist", c.CardController.Hotlist)
+	gate.Post("/journeys/checkin", c.JourneyController.CheckIn)
+	gate.Post("/journeys/checkout", c.JourneyController.CheckOut)
+}
+
+// SetupPartnerRoute registers the routes called by channel partners. Like the
+// gate routes, they must be registered before SetupAuthRoute.
+func (c *RouteConfig) SetupPartnerRoute() {
+	partner := c.App.Group("/api/partner", c.PartnerAuthMiddleware)
+
+	partner.Post("/cards/:card_number/topup", c.TopUpController.PartnerTopUp)
 }
 
 func (c *RouteConfig) SetupAuthRoute() {
 	c.App.Use(c.AuthMiddleware)
 
-	c.App.Get("/api/admin/terminal", c.TerminalController.GetAll)
-	c.App.Put("/api/admin/terminal/:terminal_id", c.TerminalController.Update)
-	c.App.Get("/api/admin/terminal/:terminal_id", c.TerminalController.FindById)
-	c.App.Post("/api/admin/terminal", c.TerminalController.Create)
+	c.App.Post("/api/admin/auth/logout", c.AuthController.Logout)
+	c.App.Post("/api/admin/auth/logout-all", c.AuthController.LogoutAll)
+	c.App.Post("/api/admin/auth/change-password", c.PasswordController.ChangePassword)
+	c.App.Post("/api/admin/auth/2fa/setup", c.TwoFactorController.Setup)
+	c.App.Post("/api/admin/auth/2fa/confirm", c.TwoFactorController.Confirm)
+	c.App.Post("/api/admin/auth/2fa/disable", c.TwoFactorController.Disable)
+	c.App.Post("/api/admin/auth/2fa/recovery-codes", c.TwoFactorController.RegenerateRecoveryCodes)
+
+	c.App.Get("/api/admin/admins", middleware.NewPermission(constants.PermissionAdminRead), c.AdminController.GetAll)
+	c.App.Post("/api/admin/admins", middleware.NewPermission(constants.PermissionAdminWrite), c.AdminController.Create)
+	c.App.Get("/api/admin/admins/:admin_id", middleware.NewPermission(constants.PermissionAdminRead), c.AdminController.FindById)
+	c.App.Put("/api/admin/admins/:admin_id", middleware.NewPermission(constants.PermissionAdminWrite), c.AdminController.Update)
+	c.App.Put("/api/admin/admins/:admin_id/status", middleware.NewPermission(constants.PermissionAdminWrite), c.AdminController.UpdateStatus)
+	c.App.Delete("/api/admin/admins/:admin_id", middleware.NewPermission(constants.PermissionAdminWrite), c.AdminController.Delete)
+	c.App.Post("/api/admin/admins/:admin_id/unlock", middleware.NewPermission(constants.PermissionAdminWrite), c.AdminController.Unlock)
+	c.App.Post("/api/admin/admins/:admin_id/password-reset", middleware.NewPermission(constants.PermissionAdminWrite), c.PasswordController.IssueReset)
+	c.App.Post("/api/admin/admins/:admin_id/2fa-enrollment", middleware.NewPermission(constants.PermissionAdminWrite), c.TwoFactorController.IssueEnrollment)
+
+	c.App.Get("/api/admin/terminal", middleware.NewPermission(constants.PermissionTerminalRead), c.TerminalController.GetAll)
+	c.App.Get("/api/admin/terminal/export", middleware.NewPermission(constants.PermissionTerminalRead), c.TerminalController.Export)
+	c.App.Post("/api/admin/terminal/import", middleware.NewPermission(constants.PermissionTerminalWrite), c.TerminalController.Import)
+	c.App.Put("/api/admin/terminal/:terminal_id", middleware.NewPermission(constants.PermissionTerminalWrite), c.TerminalController.Update)
+	c.App.Get("/api/admin/terminal/:terminal_id", middleware.NewPermission(constants.PermissionTerminalRead), c.TerminalController.FindById)
+	c.App.Post("/api/admin/terminal", middleware.NewPermission(constants.PermissionTerminalWrite), c.TerminalController.Create)
+	c.App.Delete("/api/admin/terminal/:terminal_id", middleware.NewPermission(constants.PermissionTerminalWrite), c.TerminalController.Delete)
+	c.App.Post("/api/admin/terminal/:terminal_id/restore", middleware.NewPermission(constants.PermissionTerminalWrite), c.TerminalController.Restore)
+	c.App.Get("/api/admin/terminal/:terminal_id/hours", middleware.NewPermission(constants.PermissionTerminalRead), c.TerminalScheduleController.GetHours)
+	c.App.Put("/api/admin/terminal/:terminal_id/hours", middleware.NewPermission(constants.PermissionTerminalWrite), c.TerminalScheduleController.UpdateHours)
+	c.App.Get("/api/admin/terminal/:terminal_id/closures", middleware.NewPermission(constants.PermissionTerminalRead), c.TerminalScheduleController.GetClosures)
+	c.App.Post("/api/admin/terminal/:terminal_id/closures", middleware.NewPermission(constants.PermissionTerminalWrite), c.TerminalScheduleController.CreateClosure)
+	c.App.Delete("/api/admin/terminal/:terminal_id/closures/:closure_id", middleware.NewPermission(constants.PermissionTerminalWrite), c.TerminalScheduleController.DeleteClosure)
+	c.App.Get("/api/admin/terminal/:terminal_id/gates", middleware.NewPermission(constants.PermissionGateRead), c.GateController.GetAll)
+	c.App.Get("/api/admin/terminal/:terminal_id/gates/status", middleware.NewPermission(constants.PermissionGateRead), c.GateMonitorController.Dashboard)
+	c.App.Get("/api/admin/terminal/:terminal_id/gates/:gate_id/history", middleware.NewPermission(constants.PermissionGateRead), c.GateMonitorController.History)
+	c.App.Post("/api/admin/terminal/:terminal_id/gates", middleware.NewPermission(constants.PermissionGateWrite), c.GateController.Create)
+	c.App.Put("/api/admin/terminal/:terminal_id/gates/:gate_id", middleware.NewPermission(constants.PermissionGateWrite), c.GateController.Rename)
+	c.App.Post("/api/admin/terminal/:terminal_id/gates/:gate_id/move", middleware.NewPermission(constants.PermissionGateWrite), c.GateController.Move)
+	c.App.Delete("/api/admin/terminal/:terminal_id/gates/:gate_id", middleware.NewPermission(constants.PermissionGateWrite), c.GateController.Decommission)
+
+	c.App.Get("/api/admin/cards", middleware.NewPermission(constants.PermissionCardRead), c.CardController.GetAll)
+	c.App.Post("/api/admin/cards", middleware.NewPermission(constants.PermissionCardWrite), c.CardController.Issue)
+	c.App.Get("/api/admin/cards/:card_number", middleware.NewPermission(constants.PermissionCardRead), c.CardController.FindById)
+	c.App.Put("/api/admin/cards/:card_number/status", middleware.NewPermission(constants.PermissionCardWrite), c.CardController.UpdateStatus)
+	c.App.Post("/api/admin/cards/:card_number/block", middleware.NewPermission(constants.PermissionCardWrite), c.CardController.Block)
+	c.App.Post("/api/admin/cards/:card_number/unblock", middleware.NewPermission(constants.PermissionCardWrite), c.CardController.Unblock)
+	c.App.Post("/api/admin/cards/:card_number/replace", middleware.NewPermission(constants.PermissionCardWrite), c.CardReplacementController.Replace)
+	c.App.Put("/api/admin/cards/:card_number/product", middleware.NewPermission(constants.PermissionCardWrite), c.CardProductController.AssignToCard)
+
+	c.App.Get("/api/admin/card-products", middleware.NewPermission(constants.PermissionFareRead), c.CardProductController.GetAll)
+	c.App.Post("/api/admin/card-products", middleware.NewPermission(constants.PermissionFareWrite), c.CardProductController.Create)
+	c.App.Put("/api/admin/card-products/:product_id", middleware.NewPermission(constants.PermissionFareWrite), c.CardProductController.Update)
+	c.App.Get("/api/admin/reports/concessions", middleware.NewPermission(constants.PermissionFareRead), c.CardProductController.ConcessionUsage)
+
+	c.App.Post("/api/admin/riders", middleware.NewPermission(constants.PermissionRiderWrite), c.RiderController.Create)
+	c.App.Get("/api/admin/riders/:rider_id", middleware.NewPermission(constants.PermissionRiderRead), c.RiderController.FindById)
+	c.App.Post("/api/admin/riders/:rider_id/cards", middleware.NewPermission(constants.PermissionRiderWrite), c.RiderController.RegisterCard)
+	c.App.Post("/api/admin/cards/:card_number/topup", middleware.NewPermission(constants.PermissionCardWrite), c.TopUpController.TopUp)
+
+	c.App.Get("/api/admin/partners", middleware.NewPermission(constants.PermissionPartnerRead), c.ChannelPartnerController.GetAll)
+	c.App.Post("/api/admin/partners", middleware.NewPermission(constants.PermissionPartnerWrite), c.ChannelPartnerController.Create)
+	c.App.Delete("/api/admin/partners/:partner_id", middleware.NewPermission(constants.PermissionPartnerWrite), c.ChannelPartnerController.Disable)
+
+	c.App.Get("/api/admin/audit-logs", middleware.NewPermission(constants.PermissionAuditRead), c.AuditController.Search)
+
+	c.App.Get("/api/admin/events", middleware.NewPermission(constants.PermissionMonitorRead), c.EventController.Stream)
+
+	c.App.Get("/api/admin/gates/:gate_id/credentials", middleware.NewPermission(constants.PermissionGateRead), c.GateCredentialController.GetAll)
+	c.App.Post("/api/admin/gates/:gate_id/credentials", middleware.NewPermission(constants.PermissionGateWrite), c.GateCredentialController.Issue)
+	c.App.Post("/api/admin/gates/:gate_id/credentials/rotate", middleware.NewPermission(constants.PermissionGateWrite), c.GateCredentialController.Rotate)
+	c.App.Delete("/api/admin/gates/:gate_id/credentials/:credential_id", middleware.NewPermission(constants.PermissionGateWrite), c.GateCredentialController.Revoke)
+	c.App.Get("/api/admin/gates/:gate_id/commands", middleware.NewPermission(constants.PermissionGateRead), c.GateCommandController.GetAll)
+	c.App.Post("/api/admin/gates/:gate_id/commands", middleware.NewPermission(constants.PermissionGateWrite), c.GateCommandController.Issue)
+	c.App.Delete("/api/admin/gates/:gate_id/commands/:command_id", middleware.NewPermission(constants.PermissionGateWrite), c.GateCommandController.Cancel)
 }
@@ -1,11 +1,21 @@
 package http
 
 import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
 	"test-kerja-mkp/internal/constants"
 	"test-kerja-mkp/internal/helper"
 	"test-kerja-mkp/internal/model"
+	"test-kerja-mkp/internal/model/converter"
 	"test-kerja-mkp/internal/usecase"
 	"strconv"
+	"strings"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/sirupsen/logrus"
@@ -24,9 +34,10 @@ func NewTerminalController(usecase *usecase.TerminalUseCase, log *logrus.Logger)
 }
 
 func (c *TerminalController) Create(ctx *fiber.Ctx) error {
-	request := &model.CreateTerminalRequest{
-		Name:      ctx.FormValue("name"),
-		Location:  ctx.FormValue("location"),
+	request := new(model.CreateTerminalRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Failed to parse request body: %v", err)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidRequestMessage, nil)
 	}
 
 	if errors := helper.ValidateStruct(ctx, request); errors != nil {
@@ -44,13 +55,287 @@ func (c *TerminalController) Create(ctx *fiber.Ctx) error {
 }
 
 func (c *TerminalController) GetAll(ctx *fiber.Ctx) error {
-	page, _ := strconv.Atoi(ctx.Query("page", "1"))
-	size, _ := strconv.Atoi(ctx.Query("size", "10"))
+	request := &model.SearchTerminalRequest{
+		Search:  ctx.Query("search"),
+		Deleted: ctx.Query("deleted"),
+		Sort:    ctx.Query("sort"),
+		Order:   ctx.Query("order"),
+		Page:    ctx.QueryInt("page", 1),
+		Size:    ctx.QueryInt("size", 10),
+	}
+
+	if hasOnlineGates := ctx.Query("has_online_gates"); hasOnlineGates != "" {
+		value, err := strconv.ParseBool(hasOnlineGates)
+		if err != nil {
+			return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidParamsMessage, nil)
+		}
+		request.HasOnlineGates = &value
+	}
+
+	if errors := helper.ValidateStruct(ctx, request); errors != nil {
+		c.Log.Warnf("Validation failed: %v", errors)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidParamsMessage, errors)
+	}
 
-	Terminals, paging, err := c.UseCase.FindAll(ctx.Context(), page, size)
+	Terminals, paging, err := c.UseCase.FindAll(ctx.Context(), request)
 	if err != nil {
 		return helper.ResponseErrorFromErr(ctx, err, constants.FailedGetDataMessage, nil)
 	}
 
 	return helper.ResponseSuccessPagination(ctx, Terminals, constants.SuccessGetDataMessage, paging)
-}
\ No newline at end of file
+}
+
+func (c *TerminalController) Nearby(ctx *fiber.Ctx) error {
+	request := &model.NearbyTerminalRequest{
+		Radius: 5,
+		Limit:  ctx.QueryInt("limit", 20),
+	}
+
+	var err error
+	if request.Latitude, err = strconv.ParseFloat(ctx.Query("lat"), 64); err != nil {
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidParamsMessage, nil)
+	}
+	if request.Longitude, err = strconv.ParseFloat(ctx.Query("lng"), 64); err != nil {
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidParamsMessage, nil)
+	}
+	if radius := ctx.Query("radius"); radius != "" {
+		if request.Radius, err = strconv.ParseFloat(radius, 64); err != nil {
+			return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidParamsMessage, nil)
+		}
+	}
+
+	if errors := helper.ValidateStruct(ctx, request); errors != nil {
+		c.Log.Warnf("Validation failed: %v", errors)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidParamsMessage, errors)
+	}
+
+	responses, err := c.UseCase.FindNearby(ctx.Context(), request)
+	if err != nil {
+		return helper.ResponseErrorFromErr(ctx, err, constants.FailedGetDataMessage, nil)
+	}
+
+	return helper.ResponseSuccess(ctx, constants.SuccessGetDataMessage, responses)
+}
+
+func (c *TerminalController) FindById(ctx *fiber.Ctx) error {
+	id, err := strconv.ParseInt(ctx.Params("terminal_id"), 10, 64)
+	if err != nil {
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidParamsMessage, nil)
+	}
+
+	response, err := c.UseCase.FindById(ctx.Context(), id)
+	if err != nil {
+		return helper.ResponseErrorFromErr(ctx, err, constants.FailedFindDataMessage, nil)
+	}
+
+	return helper.ResponseSuccess(ctx, constants.SuccessFindDataMessage, response)
+}
+
+func (c *TerminalController) Update(ctx *fiber.Ctx) error {
+	id, err := strconv.ParseInt(ctx.Params("terminal_id"), 10, 64)
+	if err != nil {
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidParamsMessage, nil)
+	}
+
+	request := new(model.UpdateTerminalRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Failed to parse request body: %v", err)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidRequestMessage, nil)
+	}
+	request.ID = id
+
+	if errors := helper.ValidateStruct(ctx, request); errors != nil {
+		c.Log.Warnf("Validation failed: %v", errors)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidRequestMessage, errors)
+	}
+
+	response, err := c.UseCase.Update(ctx.Context(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to update Terminal: %v", err)
+		return helper.ResponseErrorFromErr(ctx, err, constants.FailedUpdateMessage, nil)
+	}
+
+	return helper.ResponseSuccess(ctx, constants.SuccessUpdateMessage, response)
+}
+
+func (c *TerminalController) Delete(ctx *fiber.Ctx) error {
+	id, err := strconv.ParseInt(ctx.Params("terminal_id"), 10, 64)
+	if err != nil {
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidParamsMessage, nil)
+	}
+
+	if err := c.UseCase.Delete(ctx.Context(), id); err != nil {
+		c.Log.Warnf("Failed to delete Terminal: %v", err)
+		return helper.ResponseErrorFromErr(ctx, err, constants.FailedDeleteMessage, nil)
+	}
+
+	return helper.ResponseSuccessWithoutData(ctx, constants.SuccessDeleteMessage, nil)
+}
+
+func (c *TerminalController) Restore(ctx *fiber.Ctx) error {
+	id, err := strconv.ParseInt(ctx.Params("terminal_id"), 10, 64)
+	if err != nil {
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidParamsMessage, nil)
+	}
+
+	response, err := c.UseCase.Restore(ctx.Context(), id)
+	if err != nil {
+		c.Log.Warnf("Failed to restore Terminal: %v", err)
+		return helper.ResponseErrorFromErr(ctx, err, constants.FailedRestoreMessage, nil)
+	}
+
+	return helper.ResponseSuccess(ctx, constants.SuccessRestoreMessage, response)
+}
+
+// Import bulk creates terminals from an uploaded csv or json file. Every row is
+// validated first and all invalid rows are reported together; nothing is
+// created unless the whole file is valid. dry_run=true stops short of saving.
+func (c *TerminalController) Import(ctx *fiber.Ctx) error {
+	file, err := ctx.FormFile("file")
+	if err != nil {
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidImportFileMessage, nil)
+	}
+
+	format, err := importExportFormat(ctx.Query("format"), filepath.Ext(file.Filename))
+	if err != nil {
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidImportFileMessage, nil)
+	}
+
+	reader, err := file.Open()
+	if err != nil {
+		c.Log.Warnf("Failed to open import file: %v", err)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidImportFileMessage, nil)
+	}
+	defer reader.Close()
+
+	var rows []*model.CreateTerminalRequest
+	fieldErrors := make(map[int]map[string]string)
+	if format == "csv" {
+		rows, err = decodeTerminalCSV(reader, fieldErrors)
+	} else {
+		err = json.NewDecoder(reader).Decode(&rows)
+	}
+	if err != nil {
+		c.Log.Warnf("Failed to decode import file: %v", err)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidImportFileMessage, err.Error())
+	}
+	if len(rows) == 0 {
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidImportFileMessage, nil)
+	}
+
+	var rowErrors []*model.ImportRowError
+	for i, row := range rows {
+		errors := fieldErrors[i]
+		if row == nil {
+			errors = map[string]string{"row": "row must be an object"}
+		} else if validationErrors := helper.ValidateStruct(ctx, row); validationErrors != nil {
+			if errors == nil {
+				errors = validationErrors
+			}
+			for field, message := range validationErrors {
+				if _, ok := errors[field]; !ok {
+					errors[field] = message
+				}
+			}
+		}
+		if errors != nil {
+			rowErrors = append(rowErrors, &model.ImportRowError{Row: i + 1, Errors: errors})
+		}
+	}
+	if len(rowErrors) > 0 {
+		return helper.ResponseError(ctx, fiber.StatusUnprocessableEntity, constants.InvalidImportRowsMessage, rowErrors)
+	}
+
+	request := &model.ImportTerminalRequest{
+		DryRun: ctx.QueryBool("dry_run", false),
+		Rows:   rows,
+	}
+
+	if errors := helper.ValidateStruct(ctx, request); errors != nil {
+		c.Log.Warnf("Validation failed: %v", errors)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidImportFileMessage, errors)
+	}
+
+	response, err := c.UseCase.Import(ctx.Context(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to import Terminal: %v", err)
+		return helper.ResponseErrorFromErr(ctx, err, constants.FailedImportMessage, nil)
+	}
+
+	return helper.ResponseSuccess(ctx, constants.SuccessImportMessage, response)
+}
+
+// Export streams the terminal list in the import format.
+func (c *TerminalController) Export(ctx *fiber.Ctx) error {
+	format, err := importExportFormat(ctx.Query("format", "csv"), "")
+	if err != nil {
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidParamsMessage, nil)
+	}
+
+	filename := "terminals." + format
+	if format == "csv" {
+		ctx.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+	} else {
+		ctx.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSONCharsetUTF8)
+	}
+	ctx.Set(fiber.HeaderContentDisposition, `attachment; filename="`+filename+`"`)
+
+	// The stream writer runs after the handler returns, when the fiber context
+	// is already released, so nothing from ctx may be used inside it.
+	useCase, log := c.UseCase, c.Log
+	ctx.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		if err := useCase.Export(context.Background(), format, w); err != nil {
+			log.Warnf("Failed to export Terminal: %v", err)
+		}
+		if err := w.Flush(); err != nil {
+			log.Warnf("Failed to flush Terminal export: %v", err)
+		}
+	})
+	return nil
+}
+
+func importExportFormat(format string, ext string) (string, error) {
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(ext), ".")
+	}
+	switch format {
+	case "csv", "json":
+		return format, nil
+	}
+	return "", fmt.Errorf("unsupported format %q", format)
+}
+
+// decodeTerminalCSV reads the rows of a csv import. Values that cannot be
+// parsed are put in fieldErrors under the index of their row.
+func decodeTerminalCSV(reader io.Reader, fieldErrors map[int]map[string]string) ([]*model.CreateTerminalRequest, error) {
+	records := csv.NewReader(reader)
+	records.TrimLeadingSpace = true
+
+	header, err := records.Read()
+	if err != nil {
+		return nil, err
+	}
+	columns, err := converter.TerminalCSVColumns(header)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []*model.CreateTerminalRequest
+	for {
+		record, err := records.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		row, invalid := converter.TerminalRowFromCSV(columns, record)
+		if invalid != nil {
+			fieldErrors[len(rows)] = invalid
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
@@ -0,0 +1,150 @@
+package http
+
+import (
+	"strconv"
+	"test-kerja-mkp/internal/constants"
+	"test-kerja-mkp/internal/helper"
+	"test-kerja-mkp/internal/model"
+	"test-kerja-mkp/internal/usecase"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type TerminalScheduleController struct {
+	Log     *logrus.Logger
+	UseCase *usecase.TerminalScheduleUseCase
+}
+
+func NewTerminalScheduleController(usecase *usecase.TerminalScheduleUseCase, log *logrus.Logger) *TerminalScheduleController {
+	return &TerminalScheduleController{
+		Log:     log,
+		UseCase: usecase,
+	}
+}
+
+func (c *TerminalScheduleController) GetHours(ctx *fiber.Ctx) error {
+	terminalID, err := strconv.ParseInt(ctx.Params("terminal_id"), 10, 64)
+	if err != nil {
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidParamsMessage, nil)
+	}
+
+	responses, err := c.UseCase.FindHours(ctx.Context(), terminalID)
+	if err != nil {
+		return helper.ResponseErrorFromErr(ctx, err, constants.FailedGetDataMessage, nil)
+	}
+
+	return helper.ResponseSuccess(ctx, constants.SuccessGetDataMessage, responses)
+}
+
+func (c *TerminalScheduleController) UpdateHours(ctx *fiber.Ctx) error {
+	terminalID, err := strconv.ParseInt(ctx.Params("terminal_id"), 10, 64)
+	if err != nil {
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidParamsMessage, nil)
+	}
+
+	request := new(model.UpdateOperatingHoursRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Failed to parse request body: %v", err)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidRequestMessage, nil)
+	}
+	request.TerminalID = terminalID
+
+	if errors := helper.ValidateStruct(ctx, request); errors != nil {
+		c.Log.Warnf("Validation failed: %v", errors)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidRequestMessage, errors)
+	}
+
+	responses, err := c.UseCase.UpdateHours(ctx.Context(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to update operating hours: %v", err)
+		return helper.ResponseErrorFromErr(ctx, err, constants.FailedUpdateMessage, nil)
+	}
+
+	return helper.ResponseSuccess(ctx, constants.SuccessUpdateMessage, responses)
+}
+
+func (c *TerminalScheduleController) GetClosures(ctx *fiber.Ctx) error {
+	terminalID, err := strconv.ParseInt(ctx.Params("terminal_id"), 10, 64)
+	if err != nil {
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidParamsMessage, nil)
+	}
+
+	responses, err := c.UseCase.FindClosures(ctx.Context(), terminalID)
+	if err != nil {
+		return helper.ResponseErrorFromErr(ctx, err, constants.FailedGetDataMessage, nil)
+	}
+
+	return helper.ResponseSuccess(ctx, constants.SuccessGetDataMessage, responses)
+}
+
+func (c *TerminalScheduleController) CreateClosure(ctx *fiber.Ctx) error {
+	auth := ctx.Locals("auth").(*model.AuthAdmin)
+
+	terminalID, err := strconv.ParseInt(ctx.Params("terminal_id"), 10, 64)
+	if err != nil {
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidParamsMessage, nil)
+	}
+
+	request := new(model.CreateTerminalClosureRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Failed to parse request body: %v", err)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidRequestMessage, nil)
+	}
+	request.TerminalID = terminalID
+
+	if errors := helper.ValidateStruct(ctx, request); errors != nil {
+		c.Log.Warnf("Validation failed: %v", errors)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidRequestMessage, errors)
+	}
+
+	response, err := c.UseCase.CreateClosure(ctx.Context(), auth, request)
+	if err != nil {
+		c.Log.Warnf("Failed to create terminal closure: %v", err)
+		return helper.ResponseErrorFromErr(ctx, err, constants.FailedCreateMessage, nil)
+	}
+
+	return helper.ResponseSuccess(ctx, constants.SuccessCreateMessage, response)
+}
+
+func (c *TerminalScheduleController) DeleteClosure(ctx *fiber.Ctx) error {
+	terminalID, err := strconv.ParseInt(ctx.Params("terminal_id"), 10, 64)
+	if err != nil {
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidParamsMessage, nil)
+	}
+	closureID, err := strconv.ParseInt(ctx.Params("closure_id"), 10, 64)
+	if err != nil {
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidParamsMessage, nil)
+	}
+
+	if err := c.UseCase.DeleteClosure(ctx.Context(), terminalID, closureID); err != nil {
+		c.Log.Warnf("Failed to delete terminal closure: %v", err)
+		return helper.ResponseErrorFromErr(ctx, err, constants.FailedDeleteMessage, nil)
+	}
+
+	return helper.ResponseSuccessWithoutData(ctx, constants.SuccessDeleteMessage, nil)
+}
+
+// Status tells whether the terminal is open now, or at the RFC3339 time in
+// the "at" query parameter.
+func (c *TerminalScheduleController) Status(ctx *fiber.Ctx) error {
+	terminalID, err := strconv.ParseInt(ctx.Params("terminal_id"), 10, 64)
+	if err != nil {
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidParamsMessage, nil)
+	}
+
+	at := time.Now()
+	if value := ctx.Query("at"); value != "" {
+		if at, err = time.Parse(time.RFC3339, value); err != nil {
+			return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidParamsMessage, nil)
+		}
+	}
+
+	response, err := c.UseCase.Status(ctx.Context(), terminalID, at)
+	if err != nil {
+		return helper.ResponseErrorFromErr(ctx, err, constants.FailedFindDataMessage, nil)
+	}
+
+	return helper.ResponseSuccess(ctx, constants.SuccessFindDataMessage, response)
+}
@@ -0,0 +1,61 @@
+package http
+
+import (
+	"strconv"
+	"test-kerja-mkp/internal/constants"
+	"test-kerja-mkp/internal/helper"
+	"test-kerja-mkp/internal/model"
+	"test-kerja-mkp/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type TopUpController struct {
+	Log     *logrus.Logger
+	UseCase *usecase.TopUpUseCase
+}
+
+func NewTopUpController(usecase *usecase.TopUpUseCase, log *logrus.Logger) *TopUpController {
+	return &TopUpController{
+		Log:     log,
+		UseCase: usecase,
+	}
+}
+
+// TopUp is the admin top-up.
+func (c *TopUpController) TopUp(ctx *fiber.Ctx) error {
+	return c.topUp(ctx, nil)
+}
+
+// PartnerTopUp is the top-up of the authenticated channel partner.
+func (c *TopUpController) PartnerTopUp(ctx *fiber.Ctx) error {
+	return c.topUp(ctx, ctx.Locals("partner").(*model.AuthPartner))
+}
+
+func (c *TopUpController) topUp(ctx *fiber.Ctx, partner *model.AuthPartner) error {
+	cardNumber, err := strconv.ParseInt(ctx.Params("card_number"), 10, 64)
+	if err != nil {
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidParamsMessage, nil)
+	}
+
+	request := new(model.TopUpRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Failed to parse request body: %v", err)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidRequestMessage, nil)
+	}
+	request.CardNumber = cardNumber
+
+	if errors := helper.ValidateStruct(ctx, request); errors != nil {
+		c.Log.Warnf("Validation failed: %v", errors)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidRequestMessage, errors)
+	}
+
+	response, err := c.UseCase.TopUp(ctx.Context(), partner, request)
+	if err != nil {
+		c.Log.Warnf("Failed to top up card: %v", err)
+		return helper.ResponseErrorFromErr(ctx, err, constants.FailedTopUpMessage, nil)
+	}
+
+	return helper.ResponseSuccess(ctx, constants.SuccessTopUpMessage, response)
+}
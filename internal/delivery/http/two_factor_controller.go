@@ -0,0 +1,192 @@
+package http
+
+import (
+	"strconv"
+	"test-kerja-mkp/internal/constants"
+	"test-kerja-mkp/internal/helper"
+	"test-kerja-mkp/internal/model"
+	"test-kerja-mkp/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type TwoFactorController struct {
+	Log     *logrus.Logger
+	UseCase *usecase.TwoFactorUseCase
+}
+
+func NewTwoFactorController(usecase *usecase.TwoFactorUseCase, log *logrus.Logger) *TwoFactorController {
+	return &TwoFactorController{
+		Log:     log,
+		UseCase: usecase,
+	}
+}
+
+func (c *TwoFactorController) VerifyLogin(ctx *fiber.Ctx) error {
+	request := new(model.VerifyTwoFactorLoginRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Failed to parse request body: %v", err)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidRequestMessage, nil)
+	}
+
+	if errors := helper.ValidateStruct(ctx, request); errors != nil {
+		c.Log.Warnf("Validation failed: %v", errors)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidRequestMessage, errors)
+	}
+
+	request.IPAddress = ctx.IP()
+	request.UserAgent = ctx.Get(fiber.HeaderUserAgent)
+
+	response, err := c.UseCase.VerifyLogin(ctx.Context(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to verify two-factor login: %v", err)
+		return helper.ResponseErrorFromErr(ctx, err, constants.FailedLoginMessage, nil)
+	}
+
+	return helper.ResponseSuccess(ctx, constants.SuccessLoginMessage, response)
+}
+
+func (c *TwoFactorController) EnrollWithChallenge(ctx *fiber.Ctx) error {
+	request := new(model.EnrollTwoFactorChallengeRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Failed to parse request body: %v", err)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidRequestMessage, nil)
+	}
+
+	if errors := helper.ValidateStruct(ctx, request); errors != nil {
+		c.Log.Warnf("Validation failed: %v", errors)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidRequestMessage, errors)
+	}
+
+	request.IPAddress = ctx.IP()
+
+	response, err := c.UseCase.EnrollWithChallenge(ctx.Context(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to start two-factor enrollment: %v", err)
+		return helper.ResponseErrorFromErr(ctx, err, constants.FailedTwoFactorMessage, nil)
+	}
+
+	return helper.ResponseSuccess(ctx, constants.SuccessTwoFactorSetupMessage, response)
+}
+
+func (c *TwoFactorController) ConfirmWithChallenge(ctx *fiber.Ctx) error {
+	request := new(model.ConfirmTwoFactorChallengeRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Failed to parse request body: %v", err)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidRequestMessage, nil)
+	}
+
+	if errors := helper.ValidateStruct(ctx, request); errors != nil {
+		c.Log.Warnf("Validation failed: %v", errors)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidRequestMessage, errors)
+	}
+
+	request.IPAddress = ctx.IP()
+	request.UserAgent = ctx.Get(fiber.HeaderUserAgent)
+
+	response, err := c.UseCase.ConfirmWithChallenge(ctx.Context(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to confirm two-factor enrollment: %v", err)
+		return helper.ResponseErrorFromErr(ctx, err, constants.FailedLoginMessage, nil)
+	}
+
+	return helper.ResponseSuccess(ctx, constants.SuccessLoginMessage, response)
+}
+
+func (c *TwoFactorController) IssueEnrollment(ctx *fiber.Ctx) error {
+	auth := ctx.Locals("auth").(*model.AuthAdmin)
+
+	id, err := strconv.ParseInt(ctx.Params("admin_id"), 10, 64)
+	if err != nil {
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidParamsMessage, nil)
+	}
+
+	response, err := c.UseCase.IssueEnrollment(ctx.Context(), auth, id)
+	if err != nil {
+		c.Log.Warnf("Failed to issue two-factor enrollment: %v", err)
+		return helper.ResponseErrorFromErr(ctx, err, constants.FailedCreateMessage, nil)
+	}
+
+	return helper.ResponseSuccess(ctx, constants.SuccessCreateMessage, response)
+}
+
+func (c *TwoFactorController) Setup(ctx *fiber.Ctx) error {
+	auth := ctx.Locals("auth").(*model.AuthAdmin)
+
+	response, err := c.UseCase.Setup(ctx.Context(), auth.ID)
+	if err != nil {
+		c.Log.Warnf("Failed to set up two-factor: %v", err)
+		return helper.ResponseErrorFromErr(ctx, err, constants.FailedTwoFactorMessage, nil)
+	}
+
+	return helper.ResponseSuccess(ctx, constants.SuccessTwoFactorSetupMessage, response)
+}
+
+func (c *TwoFactorController) Confirm(ctx *fiber.Ctx) error {
+	auth := ctx.Locals("auth").(*model.AuthAdmin)
+
+	request := new(model.ConfirmTwoFactorRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Failed to parse request body: %v", err)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidRequestMessage, nil)
+	}
+
+	if errors := helper.ValidateStruct(ctx, request); errors != nil {
+		c.Log.Warnf("Validation failed: %v", errors)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidRequestMessage, errors)
+	}
+
+	response, err := c.UseCase.Confirm(ctx.Context(), auth, request)
+	if err != nil {
+		c.Log.Warnf("Failed to confirm two-factor: %v", err)
+		return helper.ResponseErrorFromErr(ctx, err, constants.FailedTwoFactorMessage, nil)
+	}
+
+	return helper.ResponseSuccess(ctx, constants.SuccessTwoFactorEnableMessage, response)
+}
+
+func (c *TwoFactorController) Disable(ctx *fiber.Ctx) error {
+	auth := ctx.Locals("auth").(*model.AuthAdmin)
+
+	request := new(model.DisableTwoFactorRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Failed to parse request body: %v", err)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidRequestMessage, nil)
+	}
+
+	if errors := helper.ValidateStruct(ctx, request); errors != nil {
+		c.Log.Warnf("Validation failed: %v", errors)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidRequestMessage, errors)
+	}
+
+	if err := c.UseCase.Disable(ctx.Context(), auth, request); err != nil {
+		c.Log.Warnf("Failed to disable two-factor: %v", err)
+		return helper.ResponseErrorFromErr(ctx, err, constants.FailedTwoFactorMessage, nil)
+	}
+
+	return helper.ResponseSuccessWithoutData(ctx, constants.SuccessTwoFactorDisableMessage, nil)
+}
+
+func (c *TwoFactorController) RegenerateRecoveryCodes(ctx *fiber.Ctx) error {
+	auth := ctx.Locals("auth").(*model.AuthAdmin)
+
+	request := new(model.ConfirmTwoFactorRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Failed to parse request body: %v", err)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidRequestMessage, nil)
+	}
+
+	if errors := helper.ValidateStruct(ctx, request); errors != nil {
+		c.Log.Warnf("Validation failed: %v", errors)
+		return helper.ResponseError(ctx, fiber.StatusBadRequest, constants.InvalidRequestMessage, errors)
+	}
+
+	response, err := c.UseCase.RegenerateRecoveryCodes(ctx.Context(), auth, request)
+	if err != nil {
+		c.Log.Warnf("Failed to regenerate recovery codes: %v", err)
+		return helper.ResponseErrorFromErr(ctx, err, constants.FailedTwoFactorMessage, nil)
+	}
+
+	return helper.ResponseSuccess(ctx, constants.SuccessCreateMessage, response)
+}
@@ -1,8 +1,28 @@
 package entity
 
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
 type Admin struct {
-	ID       int    `json:"id"`
-	Name    string `json:"name"`
-	Username string `json:"username"`
-	Password string `json:"password"`
+	ID           int64          `json:"id" gorm:"primaryKey;autoIncrement;column:id_admin"`
+	Name         string         `json:"name" gorm:"column:name;type:varchar(100);not null"`
+	Username     string         `json:"username" gorm:"column:username;type:varchar(100);not null"`
+	Password     string         `json:"-" gorm:"column:password;type:varchar(100);not null"`
+	RoleID       int64          `json:"id_role" gorm:"column:id_role;not null"`
+	IsActive     bool           `json:"is_active" gorm:"column:is_active;not null;default:true"`
+	Role         *Role          `json:"role,omitempty" gorm:"foreignKey:RoleID;references:ID"`
+	TOTPSecret   string         `json:"-" gorm:"column:totp_secret;type:varchar(64)"`
+	TOTPEnabled  bool           `json:"totp_enabled" gorm:"column:totp_enabled;not null;default:false"`
+	TOTPLastStep int64          `json:"-" gorm:"column:totp_last_step;not null;default:0"`
+	CreatedAt    time.Time      `json:"created_at" gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt    time.Time      `json:"updated_at" gorm:"column:updated_at;autoUpdateTime"`
+	DeletedAt    gorm.DeletedAt `json:"-" gorm:"column:deleted_at;index"`
+}
+
+// TableName overrides the table name used by Admin to `admin`
+func (Admin) TableName() string {
+	return "admin"
 }
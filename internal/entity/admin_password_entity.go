@@ -0,0 +1,34 @@
+package entity
+
+import "time"
+
+// AdminPasswordHistory keeps previous password hashes of an admin so that
+// recently used passwords can be refused.
+type AdminPasswordHistory struct {
+	ID           int64     `json:"id" gorm:"primaryKey;autoIncrement;column:id"`
+	AdminID      int64     `json:"id_admin" gorm:"column:id_admin;not null"`
+	PasswordHash string    `json:"-" gorm:"column:password_hash;type:varchar(100);not null"`
+	CreatedAt    time.Time `json:"created_at" gorm:"column:created_at;autoCreateTime"`
+}
+
+// TableName overrides the table name used by AdminPasswordHistory to `admin_password_history`
+func (AdminPasswordHistory) TableName() string {
+	return "admin_password_history"
+}
+
+// AdminPasswordReset is a one-time, time-limited password reset token issued
+// by another admin. Only the SHA-256 hash of the token is stored.
+type AdminPasswordReset struct {
+	ID        int64      `json:"id" gorm:"primaryKey;autoIncrement;column:id"`
+	AdminID   int64      `json:"id_admin" gorm:"column:id_admin;not null"`
+	TokenHash string     `json:"-" gorm:"column:token_hash;type:varchar(64);not null;unique"`
+	CreatedBy int64      `json:"created_by" gorm:"column:created_by;not null"`
+	ExpiresAt time.Time  `json:"expires_at" gorm:"column:expires_at;not null"`
+	UsedAt    *time.Time `json:"used_at" gorm:"column:used_at"`
+	CreatedAt time.Time  `json:"created_at" gorm:"column:created_at;autoCreateTime"`
+}
+
+// TableName overrides the table name used by AdminPasswordReset to `admin_password_reset`
+func (AdminPasswordReset) TableName() string {
+	return "admin_password_reset"
+}
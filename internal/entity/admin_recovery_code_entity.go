@@ -0,0 +1,41 @@
+package entity
+
+import "time"
+
+// AdminRecoveryCode is a single-use code that replaces a TOTP code when the
+// admin has lost their authenticator. Only the SHA-256 hash is stored.
+type AdminRecoveryCode struct {
+	ID        int64      `json:"id" gorm:"primaryKey;autoIncrement;column:id"`
+	AdminID   int64      `json:"id_admin" gorm:"column:id_admin;not null"`
+	CodeHash  string     `json:"-" gorm:"column:code_hash;type:varchar(64);not null"`
+	UsedAt    *time.Time `json:"used_at" gorm:"column:used_at"`
+	CreatedAt time.Time  `json:"created_at" gorm:"column:created_at;autoCreateTime"`
+}
+
+// TableName overrides the table name used by AdminRecoveryCode to `admin_recovery_code`
+func (AdminRecoveryCode) TableName() string {
+	return "admin_recovery_code"
+}
+
+// AdminTwoFactorEnrollment is a one-time, time-limited token issued by another
+// admin that allows first-login 2FA enrollment for a role requiring 2FA. Only
+// the SHA-256 hash of the token is stored.
+type AdminTwoFactorEnrollment struct {
+	ID        int64      `json:"id" gorm:"primaryKey;autoIncrement;column:id"`
+	AdminID   int64      `json:"id_admin" gorm:"column:id_admin;not null"`
+	TokenHash string     `json:"-" gorm:"column:token_hash;type:varchar(64);not null;unique"`
+	CreatedBy int64      `json:"created_by" gorm:"column:created_by;not null"`
+	ExpiresAt time.Time  `json:"expires_at" gorm:"column:expires_at;not null"`
+	UsedAt    *time.Time `json:"used_at" gorm:"column:used_at"`
+	CreatedAt time.Time  `json:"created_at" gorm:"column:created_at;autoCreateTime"`
+}
+
+// TableName overrides the table name used by AdminTwoFactorEnrollment to `admin_2fa_enrollment`
+func (AdminTwoFactorEnrollment) TableName() string {
+	return "admin_2fa_enrollment"
+}
+
+// IsUsable reports whether the enrollment token can still be used by the admin.
+func (e *AdminTwoFactorEnrollment) IsUsable(adminID int64, now time.Time) bool {
+	return e.AdminID == adminID && e.UsedAt == nil && e.ExpiresAt.After(now)
+}
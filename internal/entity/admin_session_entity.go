@@ -0,0 +1,43 @@
+package entity
+
+import "time"
+
+// AdminSession groups every refresh token issued from a single login.
+// Revoking the session revokes the whole refresh token family.
+type AdminSession struct {
+	ID            string     `json:"id_session" gorm:"primaryKey;column:id_session;type:varchar(36)"`
+	AdminID       int64      `json:"id_admin" gorm:"column:id_admin;not null"`
+	IPAddress     string     `json:"ip_address" gorm:"column:ip_address;type:varchar(45)"`
+	UserAgent     string     `json:"user_agent" gorm:"column:user_agent;type:varchar(255)"`
+	ExpiresAt     time.Time  `json:"expires_at" gorm:"column:expires_at;not null"`
+	RevokedAt     *time.Time `json:"revoked_at" gorm:"column:revoked_at"`
+	RevokedReason string     `json:"revoked_reason" gorm:"column:revoked_reason;type:varchar(50)"`
+	CreatedAt     time.Time  `json:"created_at" gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt     time.Time  `json:"updated_at" gorm:"column:updated_at;autoUpdateTime"`
+}
+
+// TableName overrides the table name used by AdminSession to `admin_session`
+func (AdminSession) TableName() string {
+	return "admin_session"
+}
+
+// IsActive reports whether the session can still be used at the given time.
+func (s *AdminSession) IsActive(now time.Time) bool {
+	return s.RevokedAt == nil && s.ExpiresAt.After(now)
+}
+
+// AdminRefreshToken is a single, one-time-use refresh token. Only the SHA-256
+// hash of the token is stored.
+type AdminRefreshToken struct {
+	ID        int64      `json:"id_refresh_token" gorm:"primaryKey;autoIncrement;column:id_refresh_token"`
+	SessionID string     `json:"id_session" gorm:"column:id_session;type:varchar(36);not null"`
+	TokenHash string     `json:"-" gorm:"column:token_hash;type:varchar(64);not null;unique"`
+	ExpiresAt time.Time  `json:"expires_at" gorm:"column:expires_at;not null"`
+	UsedAt    *time.Time `json:"used_at" gorm:"column:used_at"`
+	CreatedAt time.Time  `json:"created_at" gorm:"column:created_at;autoCreateTime"`
+}
+
+// TableName overrides the table name used by AdminRefreshToken to `admin_refresh_token`
+func (AdminRefreshToken) TableName() string {
+	return "admin_refresh_token"
+}
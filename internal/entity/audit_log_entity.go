@@ -0,0 +1,31 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// AuditLog records one admin action. Rows are append-only; the database
+// rejects updates and deletes.
+type AuditLog struct {
+	ID            int64           `json:"id_audit_log" gorm:"primaryKey;autoIncrement;column:id_audit_log"`
+	ActorID       *int64          `json:"id_admin" gorm:"column:id_admin"`
+	ActorUsername string          `json:"username" gorm:"column:username;type:varchar(100)"`
+	Action        string          `json:"action" gorm:"column:action;type:varchar(100);not null"`
+	EntityType    string          `json:"entity_type" gorm:"column:entity_type;type:varchar(50)"`
+	EntityID      string          `json:"entity_id" gorm:"column:entity_id;type:varchar(64)"`
+	Before        json.RawMessage `json:"before" gorm:"column:before_data;type:jsonb"`
+	After         json.RawMessage `json:"after" gorm:"column:after_data;type:jsonb"`
+	Success       bool            `json:"success" gorm:"column:success;not null"`
+	StatusCode    int             `json:"status_code" gorm:"column:status_code;not null"`
+	Method        string          `json:"method" gorm:"column:method;type:varchar(10);not null"`
+	Path          string          `json:"path" gorm:"column:path;type:varchar(255);not null"`
+	IPAddress     string          `json:"ip_address" gorm:"column:ip_address;type:varchar(45)"`
+	UserAgent     string          `json:"user_agent" gorm:"column:user_agent;type:varchar(255)"`
+	CreatedAt     time.Time       `json:"created_at" gorm:"column:created_at;autoCreateTime"`
+}
+
+// TableName overrides the table name used by AuditLog to `audit_log`
+func (AuditLog) TableName() string {
+	return "audit_log"
+}
@@ -0,0 +1,63 @@
+package entity
+
+import "time"
+
+const (
+	CardStatusActive  = "active"
+	CardStatusBlocked = "blocked"
+	CardStatusExpired = "expired"
+)
+
+const (
+	CardBlockReasonLost    = "lost"
+	CardBlockReasonStolen  = "stolen"
+	CardBlockReasonFraud   = "fraud"
+	CardBlockReasonDamaged = "damaged"
+	CardBlockReasonOther   = "other"
+)
+
+// Card is a stored-value e-ticketing card, identified by its card number. A
+// card registered to a rider can be replaced, moving its balance to the card
+// ReplacedBy.
+type Card struct {
+	CardNumber  int64      `json:"card_number" gorm:"primaryKey;autoIncrement;column:card_number"`
+	Balance     Money      `json:"balance" gorm:"column:balance;type:decimal(12,2);default:0"`
+	Status      string     `json:"status" gorm:"column:status;type:varchar(20);default:active"`
+	ProductID   *int64     `json:"id_product" gorm:"column:id_product"`
+	BlockReason *string    `json:"block_reason" gorm:"column:block_reason;type:varchar(20)"`
+	BlockNote   *string    `json:"block_note" gorm:"column:block_note;type:varchar(255)"`
+	BlockedAt   *time.Time `json:"blocked_at" gorm:"column:blocked_at"`
+	RiderID     *int64     `json:"id_rider" gorm:"column:id_rider"`
+	ReplacedBy  *int64     `json:"replaced_by" gorm:"column:replaced_by"`
+	CreatedAt   time.Time  `json:"created_at" gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt   time.Time  `json:"updated_at" gorm:"column:updated_at;autoUpdateTime"`
+}
+
+// TableName overrides the table name used by Card to `cards`
+func (Card) TableName() string {
+	return "cards"
+}
+
+// IsHotlisted reports whether gates must refuse the card.
+func (c *Card) IsHotlisted() bool {
+	return c.Status == CardStatusBlocked
+}
+
+// Block blocks the card with the reason and the optional note.
+func (c *Card) Block(reason string, note string, now time.Time) {
+	c.Status = CardStatusBlocked
+	c.BlockReason = &reason
+	c.BlockNote = nil
+	if note != "" {
+		c.BlockNote = &note
+	}
+	c.BlockedAt = &now
+}
+
+// Unblock activates the card again and clears the block.
+func (c *Card) Unblock() {
+	c.Status = CardStatusActive
+	c.BlockReason = nil
+	c.BlockNote = nil
+	c.BlockedAt = nil
+}
@@ -0,0 +1,25 @@
+package entity
+
+import "time"
+
+const (
+	CardHotlistAdd    = "add"
+	CardHotlistRemove = "remove"
+)
+
+// CardHotlist is an entry of the append-only change log of the hotlist. Its id
+// is the hotlist version: a gate holding version N needs the entries after N
+// to catch up.
+type CardHotlist struct {
+	Version    int64     `json:"id_version" gorm:"primaryKey;autoIncrement;column:id_version"`
+	CardNumber int64     `json:"card_number" gorm:"column:card_number;not null"`
+	Action     string    `json:"action" gorm:"column:action;type:varchar(10);not null"`
+	Reason     *string   `json:"reason" gorm:"column:reason;type:varchar(20)"`
+	CreatedBy  int64     `json:"created_by" gorm:"column:created_by;not null"`
+	CreatedAt  time.Time `json:"created_at" gorm:"column:created_at;autoCreateTime"`
+}
+
+// TableName overrides the table name used by CardHotlist to `card_hotlist`
+func (CardHotlist) TableName() string {
+	return "card_hotlist"
+}
@@ -0,0 +1,70 @@
+package entity
+
+import "time"
+
+const (
+	CardProductDiscountPercentage = "percentage"
+	CardProductDiscountFixed      = "fixed"
+	CardProductDiscountFree       = "free"
+)
+
+// CardProductMaxDiscountValue is the largest value the decimal(8,2)
+// discount_value column holds, 999999.99.
+const CardProductMaxDiscountValue Money = 999999*MoneyScale + 99
+
+// CardProduct is a card type such as a student or senior card. Its discount
+// applies to the regular fare of the journeys of its cards from ValidFrom,
+// until ValidUntil when set. DiscountValue is a percentage for percentage
+// discounts and an amount in rupiah for fixed ones.
+type CardProduct struct {
+	ID            int64      `json:"id_product" gorm:"primaryKey;autoIncrement;column:id_product"`
+	Code          string     `json:"code" gorm:"column:code;type:varchar(30);not null"`
+	Name          string     `json:"name" gorm:"column:name;type:varchar(100);not null"`
+	Concession    bool       `json:"concession" gorm:"column:concession;not null;default:false"`
+	DiscountType  string     `json:"discount_type" gorm:"column:discount_type;type:varchar(20);not null"`
+	DiscountValue Money      `json:"discount_value" gorm:"column:discount_value;type:decimal(8,2);not null;default:0"`
+	ValidFrom     time.Time  `json:"valid_from" gorm:"column:valid_from;type:date;not null"`
+	ValidUntil    *time.Time `json:"valid_until" gorm:"column:valid_until;type:date"`
+	CreatedAt     time.Time  `json:"created_at" gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt     time.Time  `json:"updated_at" gorm:"column:updated_at;autoUpdateTime"`
+}
+
+// TableName overrides the table name used by CardProduct to `card_product`
+func (CardProduct) TableName() string {
+	return "card_product"
+}
+
+// IsValidOn reports whether the product applies on the local date, formatted
+// as 2006-01-02.
+func (p *CardProduct) IsValidOn(date string) bool {
+	if p.ValidFrom.Format(time.DateOnly) > date {
+		return false
+	}
+	return p.ValidUntil == nil || p.ValidUntil.Format(time.DateOnly) >= date
+}
+
+// Apply returns the fare after the discount, never below zero. Percentage
+// discounts are rounded to the nearest cent.
+func (p *CardProduct) Apply(fare Money) Money {
+	switch p.DiscountType {
+	case CardProductDiscountFree:
+		return 0
+	case CardProductDiscountPercentage:
+		// DiscountValue holds hundredths of a percent.
+		fare -= (fare*p.DiscountValue + 100*MoneyScale/2) / (100 * MoneyScale)
+	case CardProductDiscountFixed:
+		fare -= p.DiscountValue
+	}
+	return max(fare, 0)
+}
+
+// ConcessionUsage sums the journeys charged with a card product.
+type ConcessionUsage struct {
+	ProductID   int64  `gorm:"column:id_product"`
+	Code        string `gorm:"column:code"`
+	Name        string `gorm:"column:name"`
+	Concession  bool   `gorm:"column:concession"`
+	Journeys    int64  `gorm:"column:journeys"`
+	RegularFare Money  `gorm:"column:regular_fare"`
+	FareCharged Money  `gorm:"column:fare_charged"`
+}
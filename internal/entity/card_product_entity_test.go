@@ -0,0 +1,37 @@
+package entity
+
+import "testing"
+
+func TestCardProductApply(t *testing.T) {
+	tests := []struct {
+		name  string
+		typ   string
+		value Money
+		fare  Money
+		want  Money
+	}{
+		{name: "regular", fare: 350000, want: 350000},
+		{name: "percentage", typ: CardProductDiscountPercentage, value: 5000, fare: 350000, want: 175000},
+		// 33.33% of 10.01 is 3.336333, rounded to 3.34.
+		{name: "percentage rounds half up", typ: CardProductDiscountPercentage, value: 3333, fare: 1001, want: 667},
+		// 12.5% of 0.04 is 0.005, rounded to 0.01.
+		{name: "percentage rounds the half cent", typ: CardProductDiscountPercentage, value: 1250, fare: 4, want: 3},
+		// 12.5% of 0.03 is 0.00375, rounded to 0.
+		{name: "percentage rounds down", typ: CardProductDiscountPercentage, value: 1250, fare: 3, want: 3},
+		{name: "full percentage", typ: CardProductDiscountPercentage, value: 10000, fare: 350000, want: 0},
+		{name: "fixed", typ: CardProductDiscountFixed, value: 100000, fare: 350000, want: 250000},
+		{name: "fixed equal to the fare", typ: CardProductDiscountFixed, value: 350000, fare: 350000, want: 0},
+		{name: "fixed above the fare", typ: CardProductDiscountFixed, value: 500000, fare: 350000, want: 0},
+		{name: "fixed maximum", typ: CardProductDiscountFixed, value: CardProductMaxDiscountValue, fare: 350000, want: 0},
+		{name: "free", typ: CardProductDiscountFree, fare: 350000, want: 0},
+		{name: "free on zero fare", typ: CardProductDiscountFree, fare: 0, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			product := &CardProduct{DiscountType: tt.typ, DiscountValue: tt.value}
+			if got := product.Apply(tt.fare); got != tt.want {
+				t.Fatalf("Apply(%s) = %s, want %s", tt.fare, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package entity
+
+import "time"
+
+// ChannelPartner is an external sales channel, such as a bank or a retail
+// chain, allowed to top up cards through the partner API. Only the SHA-256
+// hash of its API key is stored.
+type ChannelPartner struct {
+	ID         string     `json:"id_partner" gorm:"primaryKey;column:id_partner;type:varchar(36)"`
+	Name       string     `json:"name" gorm:"column:name;type:varchar(100);not null"`
+	KeyHash    string     `json:"-" gorm:"column:key_hash;type:varchar(64);not null"`
+	CreatedBy  int64      `json:"created_by" gorm:"column:created_by;not null"`
+	DisabledAt *time.Time `json:"disabled_at" gorm:"column:disabled_at"`
+	LastUsedAt *time.Time `json:"last_used_at" gorm:"column:last_used_at"`
+	CreatedAt  time.Time  `json:"created_at" gorm:"column:created_at;autoCreateTime"`
+}
+
+// TableName overrides the table name used by ChannelPartner to `channel_partner`
+func (ChannelPartner) TableName() string {
+	return "channel_partner"
+}
@@ -0,0 +1,21 @@
+package entity
+
+import "time"
+
+// FareMatrix is the regular fare between two terminals from EffectiveDate,
+// until EndDate when set.
+type FareMatrix struct {
+	ID            int64      `json:"id" gorm:"primaryKey;autoIncrement;column:id"`
+	FromTerminal  int64      `json:"from_terminal" gorm:"column:from_terminal;not null"`
+	ToTerminal    int64      `json:"to_terminal" gorm:"column:to_terminal;not null"`
+	RegularFare   Money      `json:"regular_fare" gorm:"column:regular_fare;type:decimal(8,2);not null"`
+	EffectiveDate time.Time  `json:"effective_date" gorm:"column:effective_date;type:date;not null"`
+	EndDate       *time.Time `json:"end_date" gorm:"column:end_date;type:date"`
+	CreatedAt     time.Time  `json:"created_at" gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt     time.Time  `json:"updated_at" gorm:"column:updated_at;autoUpdateTime"`
+}
+
+// TableName overrides the table name used by FareMatrix to `fare_matrix`
+func (FareMatrix) TableName() string {
+	return "fare_matrix"
+}
@@ -0,0 +1,63 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	GateCommandOpen              = "open"
+	GateCommandEmergencyMode     = "emergency_mode"
+	GateCommandReloadConfig      = "reload_config"
+	GateCommandFlushOfflineQueue = "flush_offline_queue"
+)
+
+const (
+	GateCommandStatusPending      = "pending"
+	GateCommandStatusDelivered    = "delivered"
+	GateCommandStatusAcknowledged = "acknowledged"
+	GateCommandStatusFailed       = "failed"
+)
+
+// GateCommandResultExpired and GateCommandResultCancelled are the results of
+// commands failed by the server rather than by the gate.
+const (
+	GateCommandResultExpired   = "expired"
+	GateCommandResultCancelled = "cancelled"
+)
+
+// GateCommand is a command queued for a gate device. Commands are delivered
+// until the gate acknowledges them or they expire, so a gate must ignore a
+// command id it has already executed.
+type GateCommand struct {
+	ID          int64           `json:"id_command" gorm:"primaryKey;autoIncrement;column:id_command"`
+	GateID      int64           `json:"id_gates" gorm:"column:id_gates;not null"`
+	Command     string          `json:"command" gorm:"column:command;type:varchar(30);not null"`
+	Payload     json.RawMessage `json:"payload" gorm:"column:payload;type:jsonb"`
+	Status      string          `json:"status" gorm:"column:status;type:varchar(20);not null;default:pending"`
+	Result      *string         `json:"result" gorm:"column:result;type:varchar(255)"`
+	IssuedBy    int64           `json:"issued_by" gorm:"column:issued_by;not null"`
+	ExpiresAt   time.Time       `json:"expires_at" gorm:"column:expires_at;not null"`
+	DeliveredAt *time.Time      `json:"delivered_at" gorm:"column:delivered_at"`
+	CompletedAt *time.Time      `json:"completed_at" gorm:"column:completed_at"`
+	CreatedAt   time.Time       `json:"created_at" gorm:"column:created_at;autoCreateTime"`
+}
+
+// TableName overrides the table name used by GateCommand to `gate_command`
+func (GateCommand) TableName() string {
+	return "gate_command"
+}
+
+// IsOpen reports whether the command still waits for the gate.
+func (c *GateCommand) IsOpen() bool {
+	return c.Status == GateCommandStatusPending || c.Status == GateCommandStatusDelivered
+}
+
+// Complete closes the command with the given status and result.
+func (c *GateCommand) Complete(status string, result string, now time.Time) {
+	c.Status = status
+	c.CompletedAt = &now
+	if result != "" {
+		c.Result = &result
+	}
+}
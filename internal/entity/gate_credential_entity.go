@@ -0,0 +1,37 @@
+package entity
+
+import "time"
+
+const (
+	GateAuthTypeAPIKey = "api_key"
+	GateAuthTypeHMAC   = "hmac"
+)
+
+// GateCredential authenticates a gate device. API keys are stored as a
+// SHA-256 hash; HMAC secrets are stored as is because the server needs them
+// to recompute request signatures.
+type GateCredential struct {
+	ID         string     `json:"id_credential" gorm:"primaryKey;column:id_credential;type:varchar(36)"`
+	GateID     int64      `json:"id_gates" gorm:"column:id_gates;not null"`
+	AuthType   string     `json:"auth_type" gorm:"column:auth_type;type:varchar(20);not null"`
+	KeyHash    string     `json:"-" gorm:"column:key_hash;type:varchar(64)"`
+	Secret     string     `json:"-" gorm:"column:secret;type:varchar(128)"`
+	CreatedBy  int64      `json:"created_by" gorm:"column:created_by;not null"`
+	ExpiresAt  *time.Time `json:"expires_at" gorm:"column:expires_at"`
+	RevokedAt  *time.Time `json:"revoked_at" gorm:"column:revoked_at"`
+	LastUsedAt *time.Time `json:"last_used_at" gorm:"column:last_used_at"`
+	Gate       *Gate      `json:"gate,omitempty" gorm:"foreignKey:GateID;references:ID"`
+	CreatedAt  time.Time  `json:"created_at" gorm:"column:created_at;autoCreateTime"`
+}
+
+// TableName overrides the table name used by GateCredential to `gate_credential`
+func (GateCredential) TableName() string {
+	return "gate_credential"
+}
+
+// IsActive reports whether the credential can still be used at the given time.
+// Rotated credentials stay active until ExpiresAt so gates can be updated
+// without downtime.
+func (c *GateCredential) IsActive(now time.Time) bool {
+	return c.RevokedAt == nil && (c.ExpiresAt == nil || c.ExpiresAt.After(now))
+}
@@ -0,0 +1,83 @@
+package entity
+
+import "time"
+
+const (
+	GateStatusOnline         = "online"
+	GateStatusOffline        = "offline"
+	GateStatusError          = "error"
+	GateStatusMaintenance    = "maintenance"
+	GateStatusDecommissioned = "decommissioned"
+)
+
+const (
+	GateStatusReasonHeartbeat    = "heartbeat"
+	GateStatusReasonSilence      = "silence"
+	GateStatusReasonDecommission = "decommission"
+)
+
+// Gate is a validation gate of a terminal. The heartbeat fields hold what the
+// device reported last; StatusChangedAt is when Status took its current value.
+type Gate struct {
+	ID                  int64      `json:"id_gates" gorm:"primaryKey;autoIncrement;column:id_gates"`
+	TerminalID          int64      `json:"id_terminal" gorm:"column:id_terminal;not null"`
+	GateNumber          string     `json:"gate_number" gorm:"column:gate_number;type:varchar(50);not null"`
+	Status              string     `json:"status" gorm:"column:status;type:varchar(20);default:offline"`
+	StatusChangedAt     *time.Time `json:"status_changed_at" gorm:"column:status_changed_at"`
+	LastHeartbeatAt     *time.Time `json:"last_heartbeat_at" gorm:"column:last_heartbeat_at"`
+	FirmwareVersion     *string    `json:"firmware_version" gorm:"column:firmware_version;type:varchar(50)"`
+	ClockOffsetMs       *int64     `json:"clock_offset_ms" gorm:"column:clock_offset_ms"`
+	PendingOfflineCount int        `json:"pending_offline_count" gorm:"column:pending_offline_count;not null;default:0"`
+	DecommissionedAt    *time.Time `json:"decommissioned_at" gorm:"column:decommissioned_at"`
+	CreatedAt           time.Time  `json:"created_at" gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt           time.Time  `json:"updated_at" gorm:"column:updated_at;autoUpdateTime"`
+	Terminal            *Terminal  `json:"terminal,omitempty" gorm:"foreignKey:TerminalID;references:IDTerminal"`
+}
+
+// TableName overrides the table name used by Gate to `gates`
+func (Gate) TableName() string {
+	return "gates"
+}
+
+// IsDecommissioned reports whether the gate was taken out of service for good.
+func (g *Gate) IsDecommissioned() bool {
+	return g.DecommissionedAt != nil
+}
+
+// IsDown reports whether the gate is in service but not accepting passengers.
+func (g *Gate) IsDown() bool {
+	return !g.IsDecommissioned() && g.Status != GateStatusOnline
+}
+
+// ChangeStatus sets the status of the gate and returns the history entry for
+// the change, or nil when the status stays the same.
+func (g *Gate) ChangeStatus(status string, reason string, now time.Time) *GateStatusHistory {
+	if g.Status == status {
+		return nil
+	}
+	history := &GateStatusHistory{
+		GateID:     g.ID,
+		FromStatus: g.Status,
+		ToStatus:   status,
+		Reason:     reason,
+		ChangedAt:  now,
+	}
+	g.Status = status
+	g.StatusChangedAt = &now
+	return history
+}
+
+// GateStatusHistory records every status change of a gate.
+type GateStatusHistory struct {
+	ID         int64     `json:"id_status_history" gorm:"primaryKey;autoIncrement;column:id_status_history"`
+	GateID     int64     `json:"id_gates" gorm:"column:id_gates;not null"`
+	FromStatus string    `json:"from_status" gorm:"column:from_status;type:varchar(20);not null"`
+	ToStatus   string    `json:"to_status" gorm:"column:to_status;type:varchar(20);not null"`
+	Reason     string    `json:"reason" gorm:"column:reason;type:varchar(20);not null"`
+	ChangedAt  time.Time `json:"changed_at" gorm:"column:changed_at;not null"`
+}
+
+// TableName overrides the table name used by GateStatusHistory to `gate_status_history`
+func (GateStatusHistory) TableName() string {
+	return "gate_status_history"
+}
@@ -0,0 +1,40 @@
+package entity
+
+import "time"
+
+const (
+	JourneyStatusActive     = "active"
+	JourneyStatusCompleted  = "completed"
+	JourneyStatusIncomplete = "incomplete"
+	JourneyStatusCancelled  = "cancelled"
+	JourneyStatusPenalty    = "penalty"
+)
+
+// Journey is a trip of a card from its check-in to its check-out. While it is
+// active, MaxFareHeld is the highest fare the rider may be charged. ProductID
+// is the card product whose discount applies to the journey, and RegularFare
+// the fare before that discount.
+type Journey struct {
+	ID                  string     `json:"id_journey" gorm:"primaryKey;column:id_journey;type:varchar(32)"`
+	CardNumber          int64      `json:"card_number" gorm:"column:card_number;not null"`
+	OriginTerminal      int64      `json:"origin_terminal" gorm:"column:origin_terminal;not null"`
+	DestinationTerminal *int64     `json:"destination_terminal" gorm:"column:destination_terminal"`
+	CheckInGate         int64      `json:"checkin_gate" gorm:"column:checkin_gate;not null"`
+	CheckOutGate        *int64     `json:"checkout_gate" gorm:"column:checkout_gate"`
+	CheckInTime         time.Time  `json:"checkin_time" gorm:"column:checkin_time;not null"`
+	CheckOutTime        *time.Time `json:"checkout_time" gorm:"column:checkout_time"`
+	ProductID           *int64     `json:"id_product" gorm:"column:id_product"`
+	RegularFare         *Money     `json:"regular_fare" gorm:"column:regular_fare;type:decimal(8,2)"`
+	FareCharged         *Money     `json:"fare_charged" gorm:"column:fare_charged;type:decimal(8,2)"`
+	MaxFareHeld         Money      `json:"max_fare_held" gorm:"column:max_fare_held;type:decimal(8,2);not null"`
+	Status              string     `json:"journey_status" gorm:"column:journey_status;not null;default:active"`
+	TravelDuration      *int       `json:"travel_duration" gorm:"column:travel_duration"`
+	CreatedOffline      bool       `json:"created_offline" gorm:"column:created_offline;not null;default:false"`
+	CreatedAt           time.Time  `json:"created_at" gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt           time.Time  `json:"updated_at" gorm:"column:updated_at;autoUpdateTime"`
+}
+
+// TableName overrides the table name used by Journey to `journeys`
+func (Journey) TableName() string {
+	return "journeys"
+}
@@ -0,0 +1,23 @@
+package entity
+
+import "time"
+
+// LoginThrottle tracks failed login attempts for a throttle key, which is
+// either a username ("username:<name>") or a client IP ("ip:<address>").
+type LoginThrottle struct {
+	Key          string     `json:"throttle_key" gorm:"primaryKey;column:throttle_key;type:varchar(150)"`
+	FailedCount  int        `json:"failed_count" gorm:"column:failed_count;not null;default:0"`
+	LastFailedAt time.Time  `json:"last_failed_at" gorm:"column:last_failed_at;not null"`
+	LockedUntil  *time.Time `json:"locked_until" gorm:"column:locked_until"`
+	UpdatedAt    time.Time  `json:"updated_at" gorm:"column:updated_at;autoUpdateTime"`
+}
+
+// TableName overrides the table name used by LoginThrottle to `login_throttle`
+func (LoginThrottle) TableName() string {
+	return "login_throttle"
+}
+
+// IsLocked reports whether the key is locked out at the given time.
+func (t *LoginThrottle) IsLocked(now time.Time) bool {
+	return t.LockedUntil != nil && t.LockedUntil.After(now)
+}
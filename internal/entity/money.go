@@ -0,0 +1,123 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// MoneyScale is the number of hundredths in one rupiah.
+const MoneyScale = 100
+
+// maxMoneyDigits bounds the integer part so every amount fits in an int64.
+const maxMoneyDigits = 15
+
+var ErrInvalidMoney = errors.New("invalid money amount")
+
+// Money is an amount in hundredths of a rupiah. It maps to the DECIMAL(_,2)
+// columns exactly, so balances and fares never go through float64. It is
+// written to the database and to JSON as a decimal string such as "1500.50".
+type Money int64
+
+// ParseMoney parses a plain decimal such as "1500", "1500.5" or "-20.25".
+// Exponents, more than two decimals and more than one sign are rejected.
+func ParseMoney(value string) (Money, error) {
+	value = strings.TrimSpace(value)
+	negative := false
+	if strings.HasPrefix(value, "-") {
+		negative = true
+		value = value[1:]
+	} else {
+		value = strings.TrimPrefix(value, "+")
+	}
+
+	whole, fraction, hasFraction := strings.Cut(value, ".")
+	if whole == "" || len(whole) > maxMoneyDigits || len(fraction) > 2 || (hasFraction && fraction == "") {
+		return 0, ErrInvalidMoney
+	}
+	fraction += strings.Repeat("0", 2-len(fraction))
+	for _, digit := range whole + fraction {
+		if digit < '0' || digit > '9' {
+			return 0, ErrInvalidMoney
+		}
+	}
+
+	amount, err := strconv.ParseInt(whole+fraction, 10, 64)
+	if err != nil {
+		return 0, ErrInvalidMoney
+	}
+	if negative {
+		amount = -amount
+	}
+	return Money(amount), nil
+}
+
+func (m Money) String() string {
+	sign := ""
+	value := int64(m)
+	if value < 0 {
+		sign = "-"
+		value = -value
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, value/MoneyScale, value%MoneyScale)
+}
+
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
+
+func (m *Money) Scan(src any) error {
+	switch value := src.(type) {
+	case nil:
+		*m = 0
+		return nil
+	case string:
+		return m.scanString(value)
+	case []byte:
+		return m.scanString(string(value))
+	case int64:
+		*m = Money(value * MoneyScale)
+		return nil
+	}
+	return fmt.Errorf("cannot scan %T into Money", src)
+}
+
+func (m *Money) scanString(value string) error {
+	// NUMERIC without a scale may come back with trailing zeros beyond two
+	// decimals, such as "1500.5000".
+	if whole, fraction, ok := strings.Cut(value, "."); ok && len(fraction) > 2 {
+		value = whole + "." + strings.TrimRight(fraction, "0")
+		value = strings.TrimSuffix(value, ".")
+	}
+	amount, err := ParseMoney(value)
+	if err != nil {
+		return fmt.Errorf("cannot scan %q into Money: %w", value, err)
+	}
+	*m = amount
+	return nil
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.String())
+}
+
+// UnmarshalJSON accepts a JSON number or a decimal string. null leaves the
+// amount unchanged.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var number json.Number
+	if err := json.Unmarshal(data, &number); err != nil {
+		return ErrInvalidMoney
+	}
+	amount, err := ParseMoney(number.String())
+	if err != nil {
+		return err
+	}
+	*m = amount
+	return nil
+}
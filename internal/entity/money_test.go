@@ -0,0 +1,184 @@
+package entity
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		value string
+		want  Money
+		err   bool
+	}{
+		{value: "1500", want: 150000},
+		{value: "1500.5", want: 150050},
+		{value: "1500.50", want: 150050},
+		{value: "-20.25", want: -2025},
+		{value: "+20.25", want: 2025},
+		{value: "0.01", want: 1},
+		{value: " 7 ", want: 700},
+		{value: "999999999999999", want: 99999999999999900},
+		{value: "999999999999999.99", want: 99999999999999999},
+		{value: "-999999999999999.99", want: -99999999999999999},
+		{value: "1000000000000000", err: true},
+		{value: "1e3", err: true},
+		{value: "1E3", err: true},
+		{value: "1500.505", err: true},
+		{value: "1500.5000", err: true},
+		{value: "-+5", err: true},
+		{value: "+-5", err: true},
+		{value: "--5", err: true},
+		{value: "", err: true},
+		{value: "-", err: true},
+		{value: ".5", err: true},
+		{value: "5.", err: true},
+		{value: "1,500", err: true},
+		{value: "1 500", err: true},
+		{value: "0x10", err: true},
+		{value: "NaN", err: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseMoney(tt.value)
+		if tt.err {
+			if !errors.Is(err, ErrInvalidMoney) {
+				t.Errorf("ParseMoney(%q) = %v, %v; want ErrInvalidMoney", tt.value, got, err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("ParseMoney(%q) = %v, %v; want %v", tt.value, int64(got), err, int64(tt.want))
+		}
+	}
+}
+
+func TestMoneyString(t *testing.T) {
+	tests := []struct {
+		value Money
+		want  string
+	}{
+		{value: 0, want: "0.00"},
+		{value: 1, want: "0.01"},
+		{value: 150050, want: "1500.50"},
+		{value: -2025, want: "-20.25"},
+		{value: -5, want: "-0.05"},
+	}
+	for _, tt := range tests {
+		if got := tt.value.String(); got != tt.want {
+			t.Errorf("Money(%d).String() = %q, want %q", int64(tt.value), got, tt.want)
+		}
+	}
+}
+
+func TestMoneyScan(t *testing.T) {
+	tests := []struct {
+		name string
+		src  any
+		want Money
+		err  bool
+	}{
+		{name: "nil", src: nil, want: 0},
+		{name: "decimal string", src: "1500.50", want: 150050},
+		{name: "numeric with trailing zeros", src: "1500.5000", want: 150050},
+		{name: "numeric with only zero decimals", src: "1500.0000", want: 150000},
+		{name: "bytes", src: []byte("-20.25"), want: -2025},
+		{name: "int64", src: int64(1500), want: 150000},
+		{name: "three significant decimals", src: "1500.505", err: true},
+		{name: "float64", src: float64(1500.5), err: true},
+		{name: "garbage", src: "abc", err: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			money := Money(42)
+			err := money.Scan(tt.src)
+			if tt.err {
+				if err == nil {
+					t.Fatalf("Scan(%v) = %v, want error", tt.src, int64(money))
+				}
+				return
+			}
+			if err != nil || money != tt.want {
+				t.Fatalf("Scan(%v) = %v, %v; want %v", tt.src, int64(money), err, int64(tt.want))
+			}
+		})
+	}
+}
+
+func TestMoneyValue(t *testing.T) {
+	value, err := Money(150050).Value()
+	if err != nil || value != "1500.50" {
+		t.Fatalf("Value() = %v, %v; want \"1500.50\"", value, err)
+	}
+}
+
+func TestMoneyJSON(t *testing.T) {
+	type payload struct {
+		Amount Money `json:"amount"`
+	}
+
+	tests := []struct {
+		name string
+		json string
+		want Money
+		err  bool
+	}{
+		{name: "string", json: `{"amount":"1500.50"}`, want: 150050},
+		{name: "number", json: `{"amount":1500.5}`, want: 150050},
+		{name: "integer", json: `{"amount":1500}`, want: 150000},
+		{name: "negative string", json: `{"amount":"-20.25"}`, want: -2025},
+		{name: "null", json: `{"amount":null}`, want: 0},
+		{name: "exponent number", json: `{"amount":1e3}`, err: true},
+		{name: "exponent string", json: `{"amount":"1e3"}`, err: true},
+		{name: "three decimals", json: `{"amount":1500.505}`, err: true},
+		{name: "empty string", json: `{"amount":""}`, err: true},
+		{name: "signs", json: `{"amount":"-+5"}`, err: true},
+		{name: "boolean", json: `{"amount":true}`, err: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got payload
+			err := json.Unmarshal([]byte(tt.json), &got)
+			if tt.err {
+				if err == nil {
+					t.Fatalf("Unmarshal(%s) = %v, want error", tt.json, int64(got.Amount))
+				}
+				return
+			}
+			if err != nil || got.Amount != tt.want {
+				t.Fatalf("Unmarshal(%s) = %v, %v; want %v", tt.json, int64(got.Amount), err, int64(tt.want))
+			}
+		})
+	}
+
+	// Unbalanced quotes are not valid JSON and must not be trimmed into a
+	// number.
+	for _, data := range []string{`"1500`, `1500"`, `""1500""`} {
+		var money Money
+		if err := money.UnmarshalJSON([]byte(data)); err == nil {
+			t.Errorf("UnmarshalJSON(%s) = %v, want error", data, int64(money))
+		}
+	}
+}
+
+func TestMoneyJSONRoundTrip(t *testing.T) {
+	for _, money := range []Money{0, 1, 150050, -2025, 99999999999999999} {
+		data, err := json.Marshal(money)
+		if err != nil {
+			t.Fatalf("Marshal(%d): %v", int64(money), err)
+		}
+		if data[0] != '"' {
+			t.Fatalf("Marshal(%d) = %s, want a JSON string", int64(money), data)
+		}
+
+		var fromString Money
+		if err := json.Unmarshal(data, &fromString); err != nil || fromString != money {
+			t.Fatalf("round trip of %s = %v, %v; want %v", data, int64(fromString), err, int64(money))
+		}
+
+		var fromNumber Money
+		if err := json.Unmarshal(data[1:len(data)-1], &fromNumber); err != nil || fromNumber != money {
+			t.Fatalf("number round trip of %s = %v, %v; want %v", data, int64(fromNumber), err, int64(money))
+		}
+	}
+}
@@ -0,0 +1,17 @@
+package entity
+
+import "time"
+
+// RevokedToken is an entry of the access token revocation list. Entries only
+// need to be kept until the token itself expires.
+type RevokedToken struct {
+	TokenID   string    `json:"jti" gorm:"primaryKey;column:jti;type:varchar(36)"`
+	AdminID   int64     `json:"id_admin" gorm:"column:id_admin;not null"`
+	ExpiresAt time.Time `json:"expires_at" gorm:"column:expires_at;not null"`
+	RevokedAt time.Time `json:"revoked_at" gorm:"column:revoked_at;autoCreateTime"`
+}
+
+// TableName overrides the table name used by RevokedToken to `revoked_token`
+func (RevokedToken) TableName() string {
+	return "revoked_token"
+}
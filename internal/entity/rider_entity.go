@@ -0,0 +1,20 @@
+package entity
+
+import "time"
+
+// Rider is the identity a card is registered to, so its balance can be
+// recovered when the card is lost or damaged.
+type Rider struct {
+	ID        int64     `json:"id_rider" gorm:"primaryKey;autoIncrement;column:id_rider"`
+	Name      string    `json:"name" gorm:"column:name;type:varchar(100);not null"`
+	IDNumber  string    `json:"id_number" gorm:"column:id_number;type:varchar(30);not null"`
+	Phone     *string   `json:"phone" gorm:"column:phone;type:varchar(20)"`
+	Email     *string   `json:"email" gorm:"column:email;type:varchar(100)"`
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"column:updated_at;autoUpdateTime"`
+}
+
+// TableName overrides the table name used by Rider to `rider`
+func (Rider) TableName() string {
+	return "rider"
+}
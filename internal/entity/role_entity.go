@@ -0,0 +1,37 @@
+package entity
+
+import "time"
+
+type Role struct {
+	ID          int64            `json:"id_role" gorm:"primaryKey;autoIncrement;column:id_role"`
+	Name        string           `json:"name" gorm:"column:name;type:varchar(50);not null;unique"`
+	Description string           `json:"description" gorm:"column:description;type:varchar(255)"`
+	Require2FA  bool             `json:"require_2fa" gorm:"column:require_2fa;not null;default:false"`
+	Permissions []RolePermission `json:"permissions" gorm:"foreignKey:RoleID;references:ID"`
+	CreatedAt   time.Time        `json:"created_at" gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt   time.Time        `json:"updated_at" gorm:"column:updated_at;autoUpdateTime"`
+}
+
+// TableName overrides the table name used by Role to `role`
+func (Role) TableName() string {
+	return "role"
+}
+
+// PermissionCodes returns the permission codes granted to the role.
+func (r *Role) PermissionCodes() []string {
+	codes := make([]string, 0, len(r.Permissions))
+	for _, permission := range r.Permissions {
+		codes = append(codes, permission.Permission)
+	}
+	return codes
+}
+
+type RolePermission struct {
+	RoleID     int64  `json:"id_role" gorm:"primaryKey;column:id_role"`
+	Permission string `json:"permission" gorm:"primaryKey;column:permission;type:varchar(50)"`
+}
+
+// TableName overrides the table name used by RolePermission to `role_permission`
+func (RolePermission) TableName() string {
+	return "role_permission"
+}
@@ -1,13 +1,22 @@
 package entity
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type Terminal struct {
-	IDTerminal int64     `json:"id_terminal" gorm:"primaryKey;autoIncrement;column:id_terminal"`
-	Name       string    `json:"name" gorm:"column:name;type:nvarchar(100);not null" validate:"required,max=100"`
-	Location   string    `json:"location" gorm:"column:location;type:nvarchar(100);not null" validate:"required,max=100"`
-	CreatedAt  time.Time `json:"created_at" gorm:"column:created_at;autoCreateTime"`
-	UpdatedAt  time.Time `json:"updated_at" gorm:"column:updated_at;autoUpdateTime"`
+	IDTerminal int64          `json:"id_terminal" gorm:"primaryKey;autoIncrement;column:id_terminal"`
+	Name       string         `json:"name" gorm:"column:name;type:nvarchar(100);not null" validate:"required,max=100"`
+	Location   string         `json:"location" gorm:"column:location;type:nvarchar(100);not null" validate:"required,max=100"`
+	Address    *string        `json:"address" gorm:"column:address;type:varchar(255)"`
+	RegionCode *string        `json:"region_code" gorm:"column:region_code;type:varchar(20)"`
+	Latitude   *float64       `json:"latitude" gorm:"column:latitude;type:numeric(9,6)"`
+	Longitude  *float64       `json:"longitude" gorm:"column:longitude;type:numeric(9,6)"`
+	CreatedAt  time.Time      `json:"created_at" gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt  time.Time      `json:"updated_at" gorm:"column:updated_at;autoUpdateTime"`
+	DeletedAt  gorm.DeletedAt `json:"deleted_at" gorm:"column:deleted_at;index"`
 }
 
 // TableName overrides the table name used by Terminal to `terminal`
@@ -15,4 +24,9 @@ func (Terminal) TableName() string {
 	return "terminal"
 }
 
-		
\ No newline at end of file
+// TerminalDistance is a terminal with its distance in kilometres from a
+// searched point.
+type TerminalDistance struct {
+	Terminal
+	Distance float64 `gorm:"column:distance"`
+}
@@ -0,0 +1,38 @@
+package entity
+
+import "time"
+
+// TerminalOperatingHour is one opening interval of a terminal on a weekday.
+// DayOfWeek follows time.Weekday (0 is Sunday) and the minutes count from
+// local midnight, CloseMinute 1440 being the end of the day. Service running
+// past midnight is stored as two intervals, one on each day.
+type TerminalOperatingHour struct {
+	ID          int64     `json:"id_operating_hour" gorm:"primaryKey;autoIncrement;column:id_operating_hour"`
+	TerminalID  int64     `json:"id_terminal" gorm:"column:id_terminal;not null"`
+	DayOfWeek   int       `json:"day_of_week" gorm:"column:day_of_week;not null"`
+	OpenMinute  int       `json:"open_minute" gorm:"column:open_minute;not null"`
+	CloseMinute int       `json:"close_minute" gorm:"column:close_minute;not null"`
+	CreatedAt   time.Time `json:"created_at" gorm:"column:created_at;autoCreateTime"`
+}
+
+// TableName overrides the table name used by TerminalOperatingHour to `terminal_operating_hour`
+func (TerminalOperatingHour) TableName() string {
+	return "terminal_operating_hour"
+}
+
+// TerminalClosure closes a terminal for whole local days, from StartDate up to
+// and including EndDate, regardless of its operating hours.
+type TerminalClosure struct {
+	ID         int64     `json:"id_closure" gorm:"primaryKey;autoIncrement;column:id_closure"`
+	TerminalID int64     `json:"id_terminal" gorm:"column:id_terminal;not null"`
+	StartDate  time.Time `json:"start_date" gorm:"column:start_date;type:date;not null"`
+	EndDate    time.Time `json:"end_date" gorm:"column:end_date;type:date;not null"`
+	Reason     string    `json:"reason" gorm:"column:reason;type:varchar(255);not null"`
+	CreatedBy  int64     `json:"created_by" gorm:"column:created_by;not null"`
+	CreatedAt  time.Time `json:"created_at" gorm:"column:created_at;autoCreateTime"`
+}
+
+// TableName overrides the table name used by TerminalClosure to `terminal_closure`
+func (TerminalClosure) TableName() string {
+	return "terminal_closure"
+}
@@ -0,0 +1,45 @@
+package entity
+
+import "time"
+
+const (
+	TransactionTypeCheckIn  = "checkin"
+	TransactionTypeCheckOut = "checkout"
+	TransactionTypeTopUp    = "topup"
+	// TransactionTypeTransferOut and TransactionTypeTransferIn are the paired
+	// entries of a balance moved from a replaced card to its replacement.
+	TransactionTypeTransferOut = "transfer_out"
+	TransactionTypeTransferIn  = "transfer_in"
+)
+
+const (
+	SyncStatusSynced  = "synced"
+	SyncStatusPending = "pending"
+	SyncStatusError   = "error"
+)
+
+// Transaction is a ledger entry of a card. Amount is signed: positive when
+// money is added to the card, negative when it is spent.
+type Transaction struct {
+	ID              int64     `json:"id_transaction" gorm:"primaryKey;autoIncrement;column:id_transaction"`
+	CardNumber      int64     `json:"card_number" gorm:"column:card_number;not null"`
+	JourneyID       *string   `json:"id_journey" gorm:"column:id_journey;type:varchar(32)"`
+	Type            string    `json:"transaction_type" gorm:"column:transaction_type;not null"`
+	Amount          Money     `json:"amount" gorm:"column:amount;type:decimal(10,2);not null"`
+	BalanceBefore   Money     `json:"balance_before" gorm:"column:balance_before;type:decimal(10,2);not null"`
+	BalanceAfter    Money     `json:"balance_after" gorm:"column:balance_after;type:decimal(10,2);not null"`
+	GateID          *int64    `json:"id_gates" gorm:"column:id_gates"`
+	TerminalID      *int64    `json:"id_terminal" gorm:"column:id_terminal"`
+	ReferenceNumber *string   `json:"reference_number" gorm:"column:reference_number;type:varchar(50)"`
+	PartnerID       *string   `json:"id_partner" gorm:"column:id_partner;type:varchar(36)"`
+	Timestamp       time.Time `json:"timestamp" gorm:"column:timestamp;not null"`
+	SyncStatus      string    `json:"sync_status" gorm:"column:sync_status;not null;default:synced"`
+	OfflineCreated  bool      `json:"offline_created" gorm:"column:offline_created;not null;default:false"`
+	HashSignature   *string   `json:"hash_signature" gorm:"column:hash_signature;type:varchar(64)"`
+	CreatedAt       time.Time `json:"created_at" gorm:"column:created_at;autoCreateTime"`
+}
+
+// TableName overrides the table name used by Transaction to `transactions`
+func (Transaction) TableName() string {
+	return "transactions"
+}
@@ -0,0 +1,74 @@
+package helper
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"test-kerja-mkp/internal/model"
+)
+
+// Audit returns the audit entry of the current request. Outside of an
+// audited request it returns a detached entry, so callers never need to
+// check for nil.
+func Audit(ctx context.Context) *model.AuditEntry {
+	if entry, ok := ctx.Value("audit").(*model.AuditEntry); ok {
+		return entry
+	}
+	return new(model.AuditEntry)
+}
+
+// AuditDiff marshals both states and keeps only the top-level fields whose
+// value differs. A nil state is stored as null.
+func AuditDiff(before any, after any) (json.RawMessage, json.RawMessage, error) {
+	beforeFields, err := auditFields(before)
+	if err != nil {
+		return nil, nil, err
+	}
+	afterFields, err := auditFields(after)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if beforeFields != nil && afterFields != nil {
+		for key, value := range beforeFields {
+			if other, ok := afterFields[key]; ok && reflect.DeepEqual(value, other) {
+				delete(beforeFields, key)
+				delete(afterFields, key)
+			}
+		}
+	}
+
+	beforeJSON, err := auditJSON(beforeFields)
+	if err != nil {
+		return nil, nil, err
+	}
+	afterJSON, err := auditJSON(afterFields)
+	if err != nil {
+		return nil, nil, err
+	}
+	return beforeJSON, afterJSON, nil
+}
+
+func auditFields(state any) (map[string]any, error) {
+	if state == nil || reflect.ValueOf(state).Kind() == reflect.Pointer && reflect.ValueOf(state).IsNil() {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+func auditJSON(fields map[string]any) (json.RawMessage, error) {
+	if fields == nil {
+		return nil, nil
+	}
+	return json.Marshal(fields)
+}
@@ -0,0 +1,96 @@
+package helper
+
+import (
+	"slices"
+	"sync"
+	"sync/atomic"
+	"test-kerja-mkp/internal/model"
+	"time"
+)
+
+// EventBroker fans real-time events out to the subscribers of this process.
+// Publishing never blocks: every subscriber has a bounded buffer and events
+// that do not fit are dropped for that subscriber only and counted, so a slow
+// client cannot hold up the use cases or the other clients.
+type EventBroker struct {
+	mu          sync.RWMutex
+	subscribers map[*EventSubscription]struct{}
+	bufferSize  int
+	lastID      atomic.Uint64
+}
+
+// EventSubscription receives the events matching its filter on Events.
+type EventSubscription struct {
+	Events      chan *model.Event
+	terminalIDs []int64
+	types       []string
+	dropped     atomic.Uint64
+}
+
+func NewEventBroker(bufferSize int) *EventBroker {
+	return &EventBroker{
+		subscribers: make(map[*EventSubscription]struct{}),
+		bufferSize:  bufferSize,
+	}
+}
+
+// Subscribe registers a subscriber for the events of the given terminals and
+// types. Empty lists match everything. Call Unsubscribe when done.
+func (b *EventBroker) Subscribe(terminalIDs []int64, types []string) *EventSubscription {
+	subscription := &EventSubscription{
+		Events:      make(chan *model.Event, b.bufferSize),
+		terminalIDs: terminalIDs,
+		types:       types,
+	}
+
+	b.mu.Lock()
+	b.subscribers[subscription] = struct{}{}
+	b.mu.Unlock()
+
+	return subscription
+}
+
+func (b *EventBroker) Unsubscribe(subscription *EventSubscription) {
+	b.mu.Lock()
+	delete(b.subscribers, subscription)
+	b.mu.Unlock()
+}
+
+// Publish sends the event to every matching subscriber.
+func (b *EventBroker) Publish(eventType string, terminalID int64, data any) {
+	event := &model.Event{
+		ID:         b.lastID.Add(1),
+		Type:       eventType,
+		TerminalID: terminalID,
+		Time:       time.Now(),
+		Data:       data,
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for subscription := range b.subscribers {
+		if !subscription.matches(event) {
+			continue
+		}
+		select {
+		case subscription.Events <- event:
+		default:
+			subscription.dropped.Add(1)
+		}
+	}
+}
+
+// Dropped returns the number of events dropped since the last call.
+func (s *EventSubscription) Dropped() uint64 {
+	return s.dropped.Swap(0)
+}
+
+func (s *EventSubscription) matches(event *model.Event) bool {
+	if len(s.terminalIDs) > 0 && !slices.Contains(s.terminalIDs, event.TerminalID) {
+		return false
+	}
+	if len(s.types) > 0 && !slices.Contains(s.types, event.Type) {
+		return false
+	}
+	return true
+}
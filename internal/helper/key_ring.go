@@ -0,0 +1,260 @@
+package helper
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"test-kerja-mkp/internal/model"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	ErrUnknownSigningKey = errors.New("unknown signing key")
+	ErrRetiredSigningKey = errors.New("signing key is retired")
+)
+
+// SigningKeyConfig describes one key of the ring as read from config
+// ("auth.jwt.keys"). HS256 keys use Secret; RS256, ES256 and EdDSA keys use a
+// PEM encoded private key, either inline or from a file.
+type SigningKeyConfig struct {
+	ID             string `mapstructure:"kid"`
+	Algorithm      string `mapstructure:"alg"`
+	Secret         string `mapstructure:"secret"`
+	PrivateKey     string `mapstructure:"privateKey"`
+	PrivateKeyFile string `mapstructure:"privateKeyFile"`
+	// RetireAt is an RFC 3339 time after which tokens signed with the key are
+	// no longer accepted. It should be at least one access token TTL after
+	// the key stopped being the active one.
+	RetireAt string `mapstructure:"retireAt"`
+}
+
+// SigningKey is a parsed key of the ring.
+type SigningKey struct {
+	ID        string
+	Method    jwt.SigningMethod
+	SignKey   any
+	VerifyKey any
+	RetireAt  time.Time
+}
+
+// Retired reports whether tokens signed with the key must be rejected.
+func (k *SigningKey) Retired(now time.Time) bool {
+	return !k.RetireAt.IsZero() && !now.Before(k.RetireAt)
+}
+
+// KeyRing signs tokens with the active key and verifies tokens signed with
+// any key of the ring that is not retired, looked up by the `kid` header.
+type KeyRing struct {
+	active *SigningKey
+	keys   map[string]*SigningKey
+}
+
+// NewKeyRing parses the configured keys. activeID selects the signing key,
+// which must be present and not retired.
+func NewKeyRing(activeID string, configs []SigningKeyConfig) (*KeyRing, error) {
+	ring := &KeyRing{keys: make(map[string]*SigningKey, len(configs))}
+	for _, config := range configs {
+		key, err := parseSigningKey(config)
+		if err != nil {
+			return nil, fmt.Errorf("jwt key %q: %w", config.ID, err)
+		}
+		if _, exists := ring.keys[key.ID]; exists {
+			return nil, fmt.Errorf("jwt key %q: duplicate kid", key.ID)
+		}
+		ring.keys[key.ID] = key
+	}
+
+	active, ok := ring.keys[activeID]
+	if !ok {
+		return nil, fmt.Errorf("active jwt key %q: %w", activeID, ErrUnknownSigningKey)
+	}
+	if active.Retired(time.Now()) {
+		return nil, fmt.Errorf("active jwt key %q: %w", activeID, ErrRetiredSigningKey)
+	}
+	ring.active = active
+	return ring, nil
+}
+
+// Sign signs the claims with the active key and sets the `kid` header.
+func (r *KeyRing) Sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(r.active.Method, claims)
+	token.Header["kid"] = r.active.ID
+	return token.SignedString(r.active.SignKey)
+}
+
+// Parse verifies the token against the key named by its `kid` header. The
+// algorithm of the token must match the algorithm of that key.
+func (r *KeyRing) Parse(tokenStr string) (*jwt.Token, error) {
+	return jwt.Parse(tokenStr, r.keyFunc)
+}
+
+func (r *KeyRing) keyFunc(token *jwt.Token) (any, error) {
+	kid, _ := token.Header["kid"].(string)
+	key, ok := r.keys[kid]
+	if !ok {
+		return nil, ErrUnknownSigningKey
+	}
+	if key.Retired(time.Now()) {
+		return nil, ErrRetiredSigningKey
+	}
+	if token.Method.Alg() != key.Method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method %s", token.Method.Alg())
+	}
+	return key.VerifyKey, nil
+}
+
+// JWKS returns the public keys of the ring that are not retired. HS256 keys
+// are shared secrets and are never published.
+func (r *KeyRing) JWKS() *model.JWKSResponse {
+	now := time.Now()
+	response := &model.JWKSResponse{Keys: make([]model.JWK, 0, len(r.keys))}
+	for _, key := range r.keys {
+		if key.Retired(now) {
+			continue
+		}
+		if jwk, ok := publicJWK(key); ok {
+			response.Keys = append(response.Keys, jwk)
+		}
+	}
+	sort.Slice(response.Keys, func(i, j int) bool {
+		return response.Keys[i].KeyID < response.Keys[j].KeyID
+	})
+	return response
+}
+
+func parseSigningKey(config SigningKeyConfig) (*SigningKey, error) {
+	if config.ID == "" {
+		return nil, errors.New("kid is required")
+	}
+
+	key := &SigningKey{ID: config.ID}
+	if config.RetireAt != "" {
+		retireAt, err := time.Parse(time.RFC3339, config.RetireAt)
+		if err != nil {
+			return nil, fmt.Errorf("invalid retireAt: %w", err)
+		}
+		key.RetireAt = retireAt
+	}
+
+	if config.Algorithm == jwt.SigningMethodHS256.Alg() {
+		if config.Secret == "" {
+			return nil, errors.New("secret is required for HS256")
+		}
+		key.Method = jwt.SigningMethodHS256
+		key.SignKey = []byte(config.Secret)
+		key.VerifyKey = key.SignKey
+		return key, nil
+	}
+
+	privateKey, err := loadPrivateKey(config)
+	if err != nil {
+		return nil, err
+	}
+
+	switch config.Algorithm {
+	case jwt.SigningMethodRS256.Alg():
+		rsaKey, ok := privateKey.(*rsa.PrivateKey)
+		if !ok {
+			return nil, errors.New("RS256 requires an RSA private key")
+		}
+		key.Method = jwt.SigningMethodRS256
+		key.SignKey = rsaKey
+		key.VerifyKey = &rsaKey.PublicKey
+	case jwt.SigningMethodES256.Alg():
+		ecKey, ok := privateKey.(*ecdsa.PrivateKey)
+		if !ok || ecKey.Curve != elliptic.P256() {
+			return nil, errors.New("ES256 requires a P-256 private key")
+		}
+		key.Method = jwt.SigningMethodES256
+		key.SignKey = ecKey
+		key.VerifyKey = &ecKey.PublicKey
+	case jwt.SigningMethodEdDSA.Alg():
+		edKey, ok := privateKey.(ed25519.PrivateKey)
+		if !ok {
+			return nil, errors.New("EdDSA requires an Ed25519 private key")
+		}
+		key.Method = jwt.SigningMethodEdDSA
+		key.SignKey = edKey
+		key.VerifyKey = edKey.Public()
+	default:
+		return nil, fmt.Errorf("unsupported algorithm %q", config.Algorithm)
+	}
+	return key, nil
+}
+
+func loadPrivateKey(config SigningKeyConfig) (crypto.Signer, error) {
+	data := []byte(config.PrivateKey)
+	if config.PrivateKeyFile != "" {
+		content, err := os.ReadFile(config.PrivateKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		data = content
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("private key is not PEM encoded")
+	}
+
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		return x509.ParseECPrivateKey(block.Bytes)
+	}
+
+	privateKey, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	signer, ok := privateKey.(crypto.Signer)
+	if !ok {
+		return nil, errors.New("unsupported private key type")
+	}
+	return signer, nil
+}
+
+func publicJWK(key *SigningKey) (model.JWK, bool) {
+	jwk := model.JWK{
+		KeyID:     key.ID,
+		Algorithm: key.Method.Alg(),
+		Use:       "sig",
+	}
+
+	switch publicKey := key.VerifyKey.(type) {
+	case *rsa.PublicKey:
+		jwk.KeyType = "RSA"
+		jwk.N = base64URL(publicKey.N.Bytes())
+		jwk.E = base64URL(big.NewInt(int64(publicKey.E)).Bytes())
+	case *ecdsa.PublicKey:
+		size := (publicKey.Curve.Params().BitSize + 7) / 8
+		jwk.KeyType = "EC"
+		jwk.Curve = publicKey.Curve.Params().Name
+		jwk.X = base64URL(publicKey.X.FillBytes(make([]byte, size)))
+		jwk.Y = base64URL(publicKey.Y.FillBytes(make([]byte, size)))
+	case ed25519.PublicKey:
+		jwk.KeyType = "OKP"
+		jwk.Curve = "Ed25519"
+		jwk.X = base64URL(publicKey)
+	default:
+		return model.JWK{}, false
+	}
+	return jwk, true
+}
+
+func base64URL(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
@@ -0,0 +1,256 @@
+package helper
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const testHMACSecret = "test-hmac-secret-that-must-stay-private"
+
+func pemPrivateKey(t *testing.T, key any) string {
+	t.Helper()
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("marshal private key: %v", err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+}
+
+func testKeyConfigs(t *testing.T) map[string]SigningKeyConfig {
+	t.Helper()
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate rsa key: %v", err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate ec key: %v", err)
+	}
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("generate ed25519 key: %v", err)
+	}
+
+	return map[string]SigningKeyConfig{
+		"HS256": {ID: "hs", Algorithm: "HS256", Secret: testHMACSecret},
+		"RS256": {ID: "rs", Algorithm: "RS256", PrivateKey: pemPrivateKey(t, rsaKey)},
+		"ES256": {ID: "es", Algorithm: "ES256", PrivateKey: pemPrivateKey(t, ecKey)},
+		"EdDSA": {ID: "ed", Algorithm: "EdDSA", PrivateKey: pemPrivateKey(t, edKey)},
+	}
+}
+
+func TestKeyRingSignAndParse(t *testing.T) {
+	configs := testKeyConfigs(t)
+	for _, alg := range []string{"HS256", "RS256", "ES256", "EdDSA"} {
+		t.Run(alg, func(t *testing.T) {
+			config := configs[alg]
+			ring, err := NewKeyRing(config.ID, []SigningKeyConfig{config})
+			if err != nil {
+				t.Fatalf("NewKeyRing: %v", err)
+			}
+
+			tokenStr, err := ring.Sign(jwt.MapClaims{"uid": 1, "exp": time.Now().Add(time.Minute).Unix()})
+			if err != nil {
+				t.Fatalf("Sign: %v", err)
+			}
+
+			token, err := ring.Parse(tokenStr)
+			if err != nil || !token.Valid {
+				t.Fatalf("Parse: valid=%v err=%v", token != nil && token.Valid, err)
+			}
+			if kid := token.Header["kid"]; kid != config.ID {
+				t.Errorf("kid = %v, want %s", kid, config.ID)
+			}
+			if got := token.Method.Alg(); got != alg {
+				t.Errorf("alg = %s, want %s", got, alg)
+			}
+		})
+	}
+}
+
+func TestKeyRingRejectsUnknownKid(t *testing.T) {
+	signer, err := NewKeyRing("a", []SigningKeyConfig{{ID: "a", Algorithm: "HS256", Secret: testHMACSecret}})
+	if err != nil {
+		t.Fatalf("NewKeyRing: %v", err)
+	}
+	verifier, err := NewKeyRing("b", []SigningKeyConfig{{ID: "b", Algorithm: "HS256", Secret: testHMACSecret}})
+	if err != nil {
+		t.Fatalf("NewKeyRing: %v", err)
+	}
+
+	tokenStr, err := signer.Sign(jwt.MapClaims{"uid": 1})
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	if _, err := verifier.Parse(tokenStr); !errors.Is(err, ErrUnknownSigningKey) {
+		t.Fatalf("Parse error = %v, want %v", err, ErrUnknownSigningKey)
+	}
+
+	if _, err := NewKeyRing("missing", []SigningKeyConfig{{ID: "a", Algorithm: "HS256", Secret: testHMACSecret}}); !errors.Is(err, ErrUnknownSigningKey) {
+		t.Fatalf("NewKeyRing error = %v, want %v", err, ErrUnknownSigningKey)
+	}
+}
+
+func TestKeyRingRejectsAlgorithmMismatch(t *testing.T) {
+	configs := testKeyConfigs(t)
+	ring, err := NewKeyRing("hs", []SigningKeyConfig{configs["HS256"], configs["RS256"]})
+	if err != nil {
+		t.Fatalf("NewKeyRing: %v", err)
+	}
+
+	rsaKey := ring.keys["rs"].VerifyKey.(*rsa.PublicKey)
+	publicDER, err := x509.MarshalPKIXPublicKey(rsaKey)
+	if err != nil {
+		t.Fatalf("marshal public key: %v", err)
+	}
+	publicPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER})
+
+	tests := []struct {
+		name   string
+		method jwt.SigningMethod
+		kid    string
+		key    any
+	}{
+		// The public RSA key is not secret, so HMAC signed with it must not
+		// be accepted for the RSA kid.
+		{name: "HS256 with RSA kid", method: jwt.SigningMethodHS256, kid: "rs", key: publicPEM},
+		{name: "HS256 with RSA kid and DER key", method: jwt.SigningMethodHS256, kid: "rs", key: publicDER},
+		{name: "HS384 with HS256 kid", method: jwt.SigningMethodHS384, kid: "hs", key: []byte(testHMACSecret)},
+		{name: "HS512 with HS256 kid", method: jwt.SigningMethodHS512, kid: "hs", key: []byte(testHMACSecret)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token := jwt.NewWithClaims(tt.method, jwt.MapClaims{"uid": 1})
+			token.Header["kid"] = tt.kid
+			tokenStr, err := token.SignedString(tt.key)
+			if err != nil {
+				t.Fatalf("SignedString: %v", err)
+			}
+			if parsed, err := ring.Parse(tokenStr); err == nil || parsed.Valid {
+				t.Fatalf("Parse accepted a token signed with %s for kid %s", tt.method.Alg(), tt.kid)
+			}
+		})
+	}
+
+	none := jwt.NewWithClaims(jwt.SigningMethodNone, jwt.MapClaims{"uid": 1})
+	none.Header["kid"] = "hs"
+	tokenStr, err := none.SignedString(jwt.UnsafeAllowNoneSignatureType)
+	if err != nil {
+		t.Fatalf("SignedString: %v", err)
+	}
+	if _, err := ring.Parse(tokenStr); err == nil {
+		t.Fatal("Parse accepted an unsigned token")
+	}
+}
+
+func TestKeyRingRetiredKey(t *testing.T) {
+	configs := testKeyConfigs(t)
+	current := configs["ES256"]
+	previous := configs["RS256"]
+	previous.RetireAt = time.Now().Add(time.Hour).Format(time.RFC3339)
+
+	oldRing, err := NewKeyRing(previous.ID, []SigningKeyConfig{previous})
+	if err != nil {
+		t.Fatalf("NewKeyRing: %v", err)
+	}
+	oldToken, err := oldRing.Sign(jwt.MapClaims{"uid": 1})
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+
+	// During the overlap the previous key still verifies, but the ring signs
+	// with the current key only.
+	ring, err := NewKeyRing(current.ID, []SigningKeyConfig{current, previous})
+	if err != nil {
+		t.Fatalf("NewKeyRing: %v", err)
+	}
+	if _, err := ring.Parse(oldToken); err != nil {
+		t.Fatalf("Parse during overlap: %v", err)
+	}
+	newToken, err := ring.Sign(jwt.MapClaims{"uid": 1})
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	parsed, err := ring.Parse(newToken)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if kid := parsed.Header["kid"]; kid != current.ID {
+		t.Fatalf("signed with kid %v, want %s", kid, current.ID)
+	}
+
+	// Once retired the key neither verifies nor can become the active key.
+	previous.RetireAt = time.Now().Add(-time.Minute).Format(time.RFC3339)
+	ring, err = NewKeyRing(current.ID, []SigningKeyConfig{current, previous})
+	if err != nil {
+		t.Fatalf("NewKeyRing: %v", err)
+	}
+	if _, err := ring.Parse(oldToken); !errors.Is(err, ErrRetiredSigningKey) {
+		t.Fatalf("Parse error = %v, want %v", err, ErrRetiredSigningKey)
+	}
+	if _, err := NewKeyRing(previous.ID, []SigningKeyConfig{current, previous}); !errors.Is(err, ErrRetiredSigningKey) {
+		t.Fatalf("NewKeyRing error = %v, want %v", err, ErrRetiredSigningKey)
+	}
+}
+
+func TestKeyRingJWKS(t *testing.T) {
+	configs := testKeyConfigs(t)
+	retired := testKeyConfigs(t)["EdDSA"]
+	retired.ID = "ed-retired"
+	retired.RetireAt = time.Now().Add(-time.Minute).Format(time.RFC3339)
+
+	ring, err := NewKeyRing("hs", []SigningKeyConfig{configs["HS256"], configs["RS256"], configs["ES256"], configs["EdDSA"], retired})
+	if err != nil {
+		t.Fatalf("NewKeyRing: %v", err)
+	}
+
+	jwks := ring.JWKS()
+	payload, err := json.Marshal(jwks)
+	if err != nil {
+		t.Fatalf("marshal jwks: %v", err)
+	}
+	if strings.Contains(string(payload), testHMACSecret) || strings.Contains(string(payload), base64URL([]byte(testHMACSecret))) {
+		t.Fatalf("JWKS exposes the HMAC secret: %s", payload)
+	}
+	if strings.Contains(string(payload), `"d"`) {
+		t.Fatalf("JWKS exposes private key material: %s", payload)
+	}
+
+	want := map[string]struct{ kty, alg, crv string }{
+		"rs": {kty: "RSA", alg: "RS256"},
+		"es": {kty: "EC", alg: "ES256", crv: "P-256"},
+		"ed": {kty: "OKP", alg: "EdDSA", crv: "Ed25519"},
+	}
+	if len(jwks.Keys) != len(want) {
+		t.Fatalf("JWKS has %d keys, want %d: %s", len(jwks.Keys), len(want), payload)
+	}
+	for _, jwk := range jwks.Keys {
+		expected, ok := want[jwk.KeyID]
+		if !ok {
+			t.Errorf("unexpected key %q in JWKS", jwk.KeyID)
+			continue
+		}
+		if jwk.KeyType != expected.kty || jwk.Algorithm != expected.alg || jwk.Curve != expected.crv || jwk.Use != "sig" {
+			t.Errorf("key %q = %+v, want %+v", jwk.KeyID, jwk, expected)
+		}
+	}
+
+	rsaKey := ring.keys["rs"].VerifyKey.(*rsa.PublicKey)
+	for _, jwk := range jwks.Keys {
+		if jwk.KeyID == "rs" && (jwk.N != base64URL(rsaKey.N.Bytes()) || jwk.E != "AQAB") {
+			t.Errorf("RSA JWK n/e do not match the public key")
+		}
+	}
+}
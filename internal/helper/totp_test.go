@@ -0,0 +1,80 @@
+package helper
+
+import (
+	"testing"
+	"time"
+)
+
+// rfc6238Secret is the SHA-1 seed of RFC 6238 Appendix B, "12345678901234567890".
+var rfc6238Secret = totpEncoding.EncodeToString([]byte("12345678901234567890"))
+
+func TestTOTPCodeRFC6238(t *testing.T) {
+	// The RFC lists 8 digit codes; these are their last 6 digits.
+	tests := []struct {
+		unix int64
+		code string
+	}{
+		{unix: 59, code: "287082"},
+		{unix: 1111111109, code: "081804"},
+		{unix: 1111111111, code: "050471"},
+		{unix: 1234567890, code: "005924"},
+		{unix: 2000000000, code: "279037"},
+		{unix: 20000000000, code: "353130"},
+	}
+	for _, tt := range tests {
+		code, err := TOTPCode(rfc6238Secret, TOTPStep(time.Unix(tt.unix, 0)))
+		if err != nil {
+			t.Fatalf("TOTPCode(%d): %v", tt.unix, err)
+		}
+		if code != tt.code {
+			t.Errorf("TOTPCode(%d) = %s, want %s", tt.unix, code, tt.code)
+		}
+	}
+}
+
+func TestTOTPCodeInvalidSecret(t *testing.T) {
+	if _, err := TOTPCode("not base32!", 1); err == nil {
+		t.Fatal("TOTPCode accepted an invalid secret")
+	}
+}
+
+func TestValidateTOTP(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+	current := TOTPStep(now)
+	codeAt := func(step int64) string {
+		code, err := TOTPCode(rfc6238Secret, step)
+		if err != nil {
+			t.Fatalf("TOTPCode: %v", err)
+		}
+		return code
+	}
+
+	tests := []struct {
+		name     string
+		code     string
+		skew     int64
+		lastStep int64
+		wantStep int64
+		wantOK   bool
+	}{
+		{name: "current step", code: codeAt(current), skew: 1, wantStep: current, wantOK: true},
+		{name: "previous step within skew", code: codeAt(current - 1), skew: 1, wantStep: current - 1, wantOK: true},
+		{name: "next step within skew", code: codeAt(current + 1), skew: 1, wantStep: current + 1, wantOK: true},
+		{name: "previous step without skew", code: codeAt(current - 1), skew: 0},
+		{name: "outside skew", code: codeAt(current - 2), skew: 1},
+		{name: "replay of the last step", code: codeAt(current), skew: 1, lastStep: current},
+		{name: "older than the last step", code: codeAt(current - 1), skew: 1, lastStep: current},
+		{name: "newer than the last step", code: codeAt(current + 1), skew: 1, lastStep: current, wantStep: current + 1, wantOK: true},
+		{name: "surrounding spaces", code: " " + codeAt(current) + " ", skew: 1, wantStep: current, wantOK: true},
+		{name: "wrong length", code: codeAt(current)[:5], skew: 1},
+		{name: "wrong code", code: "000000", skew: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step, ok := ValidateTOTP(rfc6238Secret, tt.code, now, tt.skew, tt.lastStep)
+			if ok != tt.wantOK || step != tt.wantStep {
+				t.Fatalf("ValidateTOTP = (%d, %v), want (%d, %v)", step, ok, tt.wantStep, tt.wantOK)
+			}
+		})
+	}
+}
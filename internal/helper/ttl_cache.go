@@ -0,0 +1,95 @@
+package helper
+
+import (
+	"sync"
+	"time"
+)
+
+type ttlCacheItem[V any] struct {
+	value     V
+	expiresAt time.Time
+}
+
+// TTLCache is a small, concurrency safe in-memory cache where every entry
+// expires after its own time to live. Expired entries are removed lazily on
+// read and periodically by a background sweeper.
+type TTLCache[K comparable, V any] struct {
+	mu    sync.RWMutex
+	items map[K]ttlCacheItem[V]
+}
+
+func NewTTLCache[K comparable, V any](cleanupInterval time.Duration) *TTLCache[K, V] {
+	cache := &TTLCache[K, V]{
+		items: make(map[K]ttlCacheItem[V]),
+	}
+
+	if cleanupInterval > 0 {
+		go func() {
+			ticker := time.NewTicker(cleanupInterval)
+			defer ticker.Stop()
+			for range ticker.C {
+				cache.deleteExpired()
+			}
+		}()
+	}
+
+	return cache
+}
+
+func (c *TTLCache[K, V]) Get(key K) (V, bool) {
+	c.mu.RLock()
+	item, ok := c.items[key]
+	c.mu.RUnlock()
+
+	if !ok || time.Now().After(item.expiresAt) {
+		var zero V
+		return zero, false
+	}
+	return item.value, true
+}
+
+func (c *TTLCache[K, V]) Set(key K, value V, ttl time.Duration) {
+	if ttl <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	c.items[key] = ttlCacheItem[V]{value: value, expiresAt: time.Now().Add(ttl)}
+	c.mu.Unlock()
+}
+
+// SetIfAbsent stores the value only when the key is missing or expired and
+// reports whether it did. The check and the write happen under one lock.
+func (c *TTLCache[K, V]) SetIfAbsent(key K, value V, ttl time.Duration) bool {
+	if ttl <= 0 {
+		return false
+	}
+
+	now := time.Now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if item, ok := c.items[key]; ok && !now.After(item.expiresAt) {
+		return false
+	}
+	c.items[key] = ttlCacheItem[V]{value: value, expiresAt: now.Add(ttl)}
+	return true
+}
+
+func (c *TTLCache[K, V]) Delete(key K) {
+	c.mu.Lock()
+	delete(c.items, key)
+	c.mu.Unlock()
+}
+
+func (c *TTLCache[K, V]) deleteExpired() {
+	now := time.Now()
+
+	c.mu.Lock()
+	for key, item := range c.items {
+		if now.After(item.expiresAt) {
+			delete(c.items, key)
+		}
+	}
+	c.mu.Unlock()
+}
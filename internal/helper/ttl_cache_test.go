@@ -0,0 +1,143 @@
+package helper
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTTLCacheExpiry(t *testing.T) {
+	cache := NewTTLCache[string, int](0)
+
+	cache.Set("short", 1, 20*time.Millisecond)
+	cache.Set("long", 2, time.Hour)
+	if value, ok := cache.Get("short"); !ok || value != 1 {
+		t.Fatalf("Get(short) = %d, %v; want 1, true", value, ok)
+	}
+
+	time.Sleep(40 * time.Millisecond)
+	if value, ok := cache.Get("short"); ok {
+		t.Fatalf("Get(short) after expiry = %d, true; want a miss", value)
+	}
+	if value, ok := cache.Get("long"); !ok || value != 2 {
+		t.Fatalf("Get(long) = %d, %v; want 2, true", value, ok)
+	}
+
+	cache.deleteExpired()
+	if _, ok := cache.items["short"]; ok {
+		t.Fatal("deleteExpired kept an expired entry")
+	}
+	if _, ok := cache.items["long"]; !ok {
+		t.Fatal("deleteExpired removed a live entry")
+	}
+}
+
+func TestTTLCacheIgnoresNonPositiveTTL(t *testing.T) {
+	cache := NewTTLCache[string, int](0)
+
+	cache.Set("zero", 1, 0)
+	cache.Set("negative", 1, -time.Second)
+	if cache.SetIfAbsent("absent", 1, 0) {
+		t.Fatal("SetIfAbsent stored an entry without a ttl")
+	}
+	for _, key := range []string{"zero", "negative", "absent"} {
+		if _, ok := cache.Get(key); ok {
+			t.Errorf("Get(%s) hit an entry stored without a ttl", key)
+		}
+	}
+}
+
+func TestTTLCacheDelete(t *testing.T) {
+	cache := NewTTLCache[string, int](0)
+
+	cache.Set("key", 1, time.Hour)
+	cache.Delete("key")
+	if _, ok := cache.Get("key"); ok {
+		t.Fatal("Get hit a deleted entry")
+	}
+}
+
+func TestTTLCacheSetIfAbsent(t *testing.T) {
+	cache := NewTTLCache[string, int](0)
+
+	if !cache.SetIfAbsent("key", 1, 20*time.Millisecond) {
+		t.Fatal("SetIfAbsent on a missing key = false")
+	}
+	if cache.SetIfAbsent("key", 2, time.Hour) {
+		t.Fatal("SetIfAbsent on a live key = true")
+	}
+	if value, _ := cache.Get("key"); value != 1 {
+		t.Fatalf("Get = %d, want the first value 1", value)
+	}
+
+	time.Sleep(40 * time.Millisecond)
+	if !cache.SetIfAbsent("key", 3, time.Hour) {
+		t.Fatal("SetIfAbsent on an expired key = false")
+	}
+	if value, _ := cache.Get("key"); value != 3 {
+		t.Fatalf("Get = %d, want 3", value)
+	}
+}
+
+// Run with -race: every caller races for the same key and exactly one may
+// win, as the gate nonce check relies on.
+func TestTTLCacheSetIfAbsentConcurrent(t *testing.T) {
+	cache := NewTTLCache[string, int](time.Millisecond)
+
+	const callers = 64
+	var wins atomic.Int32
+	var winner atomic.Int32
+	var start, done sync.WaitGroup
+	start.Add(1)
+	for i := 1; i <= callers; i++ {
+		done.Add(1)
+		go func() {
+			defer done.Done()
+			start.Wait()
+			if cache.SetIfAbsent("nonce", i, time.Hour) {
+				wins.Add(1)
+				winner.Store(int32(i))
+			}
+			cache.Get("nonce")
+		}()
+	}
+	start.Done()
+	done.Wait()
+
+	if got := wins.Load(); got != 1 {
+		t.Fatalf("%d callers won SetIfAbsent, want exactly 1", got)
+	}
+	if value, _ := cache.Get("nonce"); value != int(winner.Load()) {
+		t.Fatalf("cached value %d is not the winner's %d", value, winner.Load())
+	}
+}
+
+type cachedAuth struct{ id int64 }
+
+// A nil value marks a revoked token or session. It must be reported as a hit
+// holding nil, never as a miss that sends the caller back to a valid result,
+// and a valid result written with SetIfAbsent must not replace it.
+func TestTTLCacheRevokedEntry(t *testing.T) {
+	cache := NewTTLCache[string, *cachedAuth](0)
+
+	cache.Set("jti:a", &cachedAuth{id: 1}, time.Hour)
+	cache.Set("jti:a", nil, time.Hour)
+	if auth, ok := cache.Get("jti:a"); !ok || auth != nil {
+		t.Fatalf("Get after revocation = %v, %v; want nil, true", auth, ok)
+	}
+
+	if cache.SetIfAbsent("jti:a", &cachedAuth{id: 1}, time.Hour) {
+		t.Fatal("SetIfAbsent replaced a revoked entry")
+	}
+	if auth, ok := cache.Get("jti:a"); !ok || auth != nil {
+		t.Fatalf("Get = %v, %v; want the revoked nil entry", auth, ok)
+	}
+
+	// A revoked entry expiring is a miss, not a valid hit.
+	cache.Set("sid:b", nil, 20*time.Millisecond)
+	time.Sleep(40 * time.Millisecond)
+	if auth, ok := cache.Get("sid:b"); ok || auth != nil {
+		t.Fatalf("Get after expiry = %v, %v; want nil, false", auth, ok)
+	}
+}
@@ -0,0 +1,20 @@
+package model
+
+type CreateAdminRequest struct {
+	Name     string `json:"name" validate:"required,max=100"`
+	Username string `json:"username" validate:"required,min=3,max=100"`
+	Password string `json:"password" validate:"required,max=72"`
+	RoleID   int64  `json:"id_role" validate:"required,gt=0"`
+}
+
+type UpdateAdminRequest struct {
+	ID       int64  `json:"-" validate:"required,gt=0"`
+	Name     string `json:"name" validate:"required,max=100"`
+	Username string `json:"username" validate:"required,min=3,max=100"`
+	RoleID   int64  `json:"id_role" validate:"required,gt=0"`
+}
+
+type UpdateAdminStatusRequest struct {
+	ID       int64 `json:"-" validate:"required,gt=0"`
+	IsActive *bool `json:"is_active" validate:"required"`
+}
@@ -0,0 +1,77 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// AuditEntry is collected while an admin request is handled. The audit
+// middleware puts it in ctx.Locals("audit"); use cases describe what they
+// changed through helper.Audit(ctx) and the middleware persists it once the
+// response is known.
+type AuditEntry struct {
+	ActorID       *int64
+	ActorUsername string
+	Action        string
+	EntityType    string
+	EntityID      string
+	Before        any
+	After         any
+	// Filled by the audit middleware.
+	Method     string
+	Path       string
+	IPAddress  string
+	UserAgent  string
+	StatusCode int
+}
+
+// SetEntity names the record the request acted on.
+func (e *AuditEntry) SetEntity(entityType string, entityID any) {
+	e.EntityType = entityType
+	e.EntityID = fmt.Sprint(entityID)
+}
+
+// SetChange records the state before and after the change. Either side may
+// be nil for creations and deletions. Only changed fields are stored.
+func (e *AuditEntry) SetChange(before any, after any) {
+	e.Before = before
+	e.After = after
+}
+
+// SetActor records the admin acting when the request is not authenticated
+// yet, such as a login.
+func (e *AuditEntry) SetActor(adminID int64, username string) {
+	e.ActorID = &adminID
+	e.ActorUsername = username
+}
+
+type SearchAuditLogRequest struct {
+	ActorID    int64      `json:"id_admin"`
+	Action     string     `json:"action" validate:"max=100"`
+	EntityType string     `json:"entity_type" validate:"max=50"`
+	EntityID   string     `json:"entity_id" validate:"max=64"`
+	Success    *bool      `json:"success"`
+	From       *time.Time `json:"from"`
+	To         *time.Time `json:"to"`
+	Page       int        `json:"page" validate:"min=1"`
+	Size       int        `json:"size" validate:"min=1,max=100"`
+}
+
+type AuditLogResponse struct {
+	ID            int64           `json:"id_audit_log"`
+	ActorID       *int64          `json:"id_admin"`
+	ActorUsername string          `json:"username"`
+	Action        string          `json:"action"`
+	EntityType    string          `json:"entity_type"`
+	EntityID      string          `json:"entity_id"`
+	Before        json.RawMessage `json:"before"`
+	After         json.RawMessage `json:"after"`
+	Success       bool            `json:"success"`
+	StatusCode    int             `json:"status_code"`
+	Method        string          `json:"method"`
+	Path          string          `json:"path"`
+	IPAddress     string          `json:"ip_address"`
+	UserAgent     string          `json:"user_agent"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
@@ -1,15 +1,27 @@
 package model
+
 import (
 	"time"
 )
+
 type LoginAdminRequest struct {
-	Username string `json:"username" validate:"required,min=3,max=100"`
-	Password string `json:"password" validate:"required,min=6,max=100"`
+	Username  string `json:"username" validate:"required,min=3,max=100"`
+	Password  string `json:"password" validate:"required,min=6,max=100"`
+	IPAddress string `json:"-"`
+	UserAgent string `json:"-"`
 }
 
 type LoginAdminResponse struct {
-	Token *TokenResponse `json:"token"`
-	Admin *AdminResponse `json:"admin,omitempty"`
+	Token         *TokenResponse              `json:"token,omitempty"`
+	Admin         *AdminResponse              `json:"admin,omitempty"`
+	TwoFactor     *TwoFactorChallengeResponse `json:"two_factor,omitempty"`
+	RecoveryCodes []string                    `json:"recovery_codes,omitempty"`
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required,max=100"`
+	IPAddress    string `json:"-"`
+	UserAgent    string `json:"-"`
 }
 
 type GetAdminRequest struct {
@@ -17,12 +29,16 @@ type GetAdminRequest struct {
 }
 
 type AdminResponse struct {
-	ID       int64 `json:"id,omitempty"`
-	Name     string `json:"name,omitempty"`
-	Email    string `json:"email,omitempty"`
-	RememberToken    string `json:"remember_token,omitempty"`
-	CreatedAt time.Time  `json:"created_at,omitempty"`
-	UpdatedAt time.Time  `json:"updated_at,omitempty"`
+	ID          int64     `json:"id,omitempty"`
+	Name        string    `json:"name,omitempty"`
+	Username    string    `json:"username,omitempty"`
+	RoleID      int64     `json:"id_role,omitempty"`
+	Role        string    `json:"role,omitempty"`
+	Permissions []string  `json:"permissions,omitempty"`
+	IsActive    bool      `json:"is_active"`
+	TOTPEnabled bool      `json:"totp_enabled"`
+	CreatedAt   time.Time `json:"created_at,omitempty"`
+	UpdatedAt   time.Time `json:"updated_at,omitempty"`
 }
 
 type VerifyAdminRequest struct {
@@ -30,11 +46,28 @@ type VerifyAdminRequest struct {
 }
 
 type TokenResponse struct {
-	AccessToken  string `json:"access_token"`
-	RefreshToken string `json:"refresh_token"`
-	ExpiresIn   int64  `json:"expires_in"`
+	AccessToken           string `json:"access_token"`
+	RefreshToken          string `json:"refresh_token"`
+	ExpiresIn             int64  `json:"expires_in"`
+	RefreshTokenExpiresIn int64  `json:"refresh_token_expires_in"`
 }
 
 type AuthAdmin struct {
-	ID int64
-}
\ No newline at end of file
+	ID          int64
+	SessionID   string
+	TokenID     string
+	ExpiresAt   time.Time
+	Role        string
+	Permissions []string
+}
+
+// HasPermission reports whether the admin was granted the permission, either
+// directly or through the wildcard permission.
+func (a *AuthAdmin) HasPermission(permission string) bool {
+	for _, granted := range a.Permissions {
+		if granted == "*" || granted == permission {
+			return true
+		}
+	}
+	return false
+}
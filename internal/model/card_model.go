@@ -0,0 +1,84 @@
+package model
+
+import "time"
+
+// SearchCardRequest lists cards. MinBalance and MaxBalance are decimal
+// amounts in rupiah with at most two decimals.
+type SearchCardRequest struct {
+	Status     string `json:"status" validate:"omitempty,oneof=active blocked expired"`
+	MinBalance string `json:"min_balance" validate:"omitempty,numeric"`
+	MaxBalance string `json:"max_balance" validate:"omitempty,numeric"`
+	Sort       string `json:"sort" validate:"omitempty,oneof=card_number balance created_at updated_at"`
+	Order      string `json:"order" validate:"omitempty,oneof=asc desc"`
+	Page       int    `json:"page" validate:"min=1"`
+	Size       int    `json:"size" validate:"min=1,max=100"`
+}
+
+// UpdateCardStatusRequest activates or expires a card. Blocking goes through
+// BlockCardRequest so the reason is recorded and the hotlist is updated.
+type UpdateCardStatusRequest struct {
+	CardNumber int64  `json:"-" validate:"required,gt=0"`
+	Status     string `json:"status" validate:"required,oneof=active expired"`
+}
+
+type BlockCardRequest struct {
+	CardNumber int64  `json:"-" validate:"required,gt=0"`
+	Reason     string `json:"reason" validate:"required,oneof=lost stolen fraud damaged other"`
+	Note       string `json:"note" validate:"max=255"`
+}
+
+type UnblockCardRequest struct {
+	CardNumber int64 `json:"-" validate:"required,gt=0"`
+}
+
+// ReplaceCardRequest replaces the registered card CardNumber with the card
+// NewCardNumber, handed to the rider in its place.
+type ReplaceCardRequest struct {
+	CardNumber    int64  `json:"-" validate:"required,gt=0"`
+	NewCardNumber int64  `json:"new_card_number" validate:"required,gt=0,nefield=CardNumber"`
+	Reason        string `json:"reason" validate:"required,oneof=lost stolen damaged"`
+	Note          string `json:"note" validate:"max=255"`
+}
+
+// CardReplacementResponse carries the amount moved as a decimal string.
+type CardReplacementResponse struct {
+	OldCard *CardResponse `json:"old_card"`
+	NewCard *CardResponse `json:"new_card"`
+	Amount  string        `json:"amount"`
+}
+
+// CardResponse carries the balance as a decimal string such as "1500.50".
+type CardResponse struct {
+	CardNumber  int64      `json:"card_number"`
+	Balance     string     `json:"balance"`
+	Status      string     `json:"status"`
+	ProductID   *int64     `json:"id_product"`
+	BlockReason *string    `json:"block_reason"`
+	BlockNote   *string    `json:"block_note"`
+	BlockedAt   *time.Time `json:"blocked_at"`
+	RiderID     *int64     `json:"id_rider"`
+	ReplacedBy  *int64     `json:"replaced_by"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// HotlistRequest asks for the changes of the hotlist after version Since. A
+// nil Since asks for the full hotlist.
+type HotlistRequest struct {
+	Since *int64 `json:"since" validate:"omitempty,min=0"`
+}
+
+// HotlistResponse brings a gate to hotlist Version. When Full is true Added
+// is the whole hotlist and the gate must drop what it had; otherwise the gate
+// adds Added and removes Removed.
+type HotlistResponse struct {
+	Version int64                   `json:"version"`
+	Full    bool                    `json:"full"`
+	Added   []*HotlistEntryResponse `json:"added"`
+	Removed []int64                 `json:"removed"`
+}
+
+type HotlistEntryResponse struct {
+	CardNumber int64   `json:"card_number"`
+	Reason     *string `json:"reason"`
+}
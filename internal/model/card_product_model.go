@@ -0,0 +1,66 @@
+package model
+
+import "time"
+
+// CreateCardProductRequest adds a card product. DiscountValue is a decimal
+// percentage up to 100 for percentage discounts, an amount in rupiah for
+// fixed ones and must be empty for free products.
+type CreateCardProductRequest struct {
+	Code          string `json:"code" validate:"required,alphanum,lowercase,max=30"`
+	Name          string `json:"name" validate:"required,max=100"`
+	Concession    bool   `json:"concession"`
+	DiscountType  string `json:"discount_type" validate:"required,oneof=percentage fixed free"`
+	DiscountValue string `json:"discount_value" validate:"omitempty,numeric"`
+	ValidFrom     string `json:"valid_from" validate:"required,datetime=2006-01-02"`
+	ValidUntil    string `json:"valid_until" validate:"omitempty,datetime=2006-01-02"`
+}
+
+// UpdateCardProductRequest changes everything but the code of a product.
+type UpdateCardProductRequest struct {
+	ID            int64  `json:"-" validate:"required,gt=0"`
+	Name          string `json:"name" validate:"required,max=100"`
+	Concession    bool   `json:"concession"`
+	DiscountType  string `json:"discount_type" validate:"required,oneof=percentage fixed free"`
+	DiscountValue string `json:"discount_value" validate:"omitempty,numeric"`
+	ValidFrom     string `json:"valid_from" validate:"required,datetime=2006-01-02"`
+	ValidUntil    string `json:"valid_until" validate:"omitempty,datetime=2006-01-02"`
+}
+
+// AssignCardProductRequest links the card to the product ProductID, or back
+// to the regular fare when ProductID is nil.
+type AssignCardProductRequest struct {
+	CardNumber int64  `json:"-" validate:"required,gt=0"`
+	ProductID  *int64 `json:"id_product" validate:"omitempty,gt=0"`
+}
+
+type CardProductResponse struct {
+	ID            int64     `json:"id_product"`
+	Code          string    `json:"code"`
+	Name          string    `json:"name"`
+	Concession    bool      `json:"concession"`
+	DiscountType  string    `json:"discount_type"`
+	DiscountValue string    `json:"discount_value"`
+	ValidFrom     string    `json:"valid_from"`
+	ValidUntil    *string   `json:"valid_until"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// ConcessionUsageRequest covers the local dates From up to and including To.
+type ConcessionUsageRequest struct {
+	From string `json:"from" validate:"required,datetime=2006-01-02"`
+	To   string `json:"to" validate:"required,datetime=2006-01-02"`
+}
+
+// ConcessionUsageResponse carries the amounts as decimal strings. Discount is
+// RegularFare minus FareCharged.
+type ConcessionUsageResponse struct {
+	ProductID   int64  `json:"id_product"`
+	Code        string `json:"code"`
+	Name        string `json:"name"`
+	Concession  bool   `json:"concession"`
+	Journeys    int64  `json:"journeys"`
+	RegularFare string `json:"regular_fare"`
+	FareCharged string `json:"fare_charged"`
+	Discount    string `json:"discount"`
+}
@@ -0,0 +1,30 @@
+package model
+
+import "time"
+
+type CreateChannelPartnerRequest struct {
+	Name string `json:"name" validate:"required,max=100"`
+}
+
+type ChannelPartnerResponse struct {
+	ID         string     `json:"id_partner"`
+	Name       string     `json:"name"`
+	CreatedBy  int64      `json:"created_by"`
+	DisabledAt *time.Time `json:"disabled_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// ChannelPartnerSecretResponse is returned once, when a partner is created.
+// The key goes in the X-Partner-Api-Key header.
+type ChannelPartnerSecretResponse struct {
+	ChannelPartnerResponse
+	APIKey string `json:"api_key"`
+}
+
+// AuthPartner is the authenticated channel partner stored in
+// ctx.Locals("partner").
+type AuthPartner struct {
+	ID   string `json:"id_partner"`
+	Name string `json:"name"`
+}
@@ -6,22 +6,21 @@ import (
 )
 
 func AdminToResponse(admin *entity.Admin) *model.AdminResponse {
-	return &model.AdminResponse{
-		ID:        admin.ID,
-		Name:      admin.Name,
-		Email:     admin.Email,
-		CreatedAt: admin.CreatedAt,
-		UpdatedAt: admin.UpdatedAt,
+	response := &model.AdminResponse{
+		ID:          admin.ID,
+		Name:        admin.Name,
+		Username:    admin.Username,
+		RoleID:      admin.RoleID,
+		IsActive:    admin.IsActive,
+		TOTPEnabled: admin.TOTPEnabled,
+		CreatedAt:   admin.CreatedAt,
+		UpdatedAt:   admin.UpdatedAt,
 	}
-}
 
-func AdminToTokenResponse(admin *entity.Admin) *model.AdminResponse {
-	return &model.AdminResponse{
-		ID:            admin.ID,
-		Name:          admin.Name,
-		Email:         admin.Email,
-		CreatedAt:     admin.CreatedAt,
-		UpdatedAt:     admin.UpdatedAt,
-		RememberToken: admin.RememberToken,
+	if admin.Role != nil {
+		response.Role = admin.Role.Name
+		response.Permissions = admin.Role.PermissionCodes()
 	}
+
+	return response
 }
@@ -0,0 +1,26 @@
+package converter
+
+import (
+	"test-kerja-mkp/internal/entity"
+	"test-kerja-mkp/internal/model"
+)
+
+func AuditLogToResponse(log *entity.AuditLog) *model.AuditLogResponse {
+	return &model.AuditLogResponse{
+		ID:            log.ID,
+		ActorID:       log.ActorID,
+		ActorUsername: log.ActorUsername,
+		Action:        log.Action,
+		EntityType:    log.EntityType,
+		EntityID:      log.EntityID,
+		Before:        log.Before,
+		After:         log.After,
+		Success:       log.Success,
+		StatusCode:    log.StatusCode,
+		Method:        log.Method,
+		Path:          log.Path,
+		IPAddress:     log.IPAddress,
+		UserAgent:     log.UserAgent,
+		CreatedAt:     log.CreatedAt,
+	}
+}
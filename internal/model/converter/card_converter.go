@@ -0,0 +1,29 @@
+package converter
+
+import (
+	"test-kerja-mkp/internal/entity"
+	"test-kerja-mkp/internal/model"
+)
+
+func CardToResponse(card *entity.Card) *model.CardResponse {
+	return &model.CardResponse{
+		CardNumber:  card.CardNumber,
+		Balance:     card.Balance.String(),
+		Status:      card.Status,
+		ProductID:   card.ProductID,
+		BlockReason: card.BlockReason,
+		BlockNote:   card.BlockNote,
+		BlockedAt:   card.BlockedAt,
+		RiderID:     card.RiderID,
+		ReplacedBy:  card.ReplacedBy,
+		CreatedAt:   card.CreatedAt,
+		UpdatedAt:   card.UpdatedAt,
+	}
+}
+
+func CardHotlistToResponse(entry *entity.CardHotlist) *model.HotlistEntryResponse {
+	return &model.HotlistEntryResponse{
+		CardNumber: entry.CardNumber,
+		Reason:     entry.Reason,
+	}
+}
@@ -0,0 +1,39 @@
+package converter
+
+import (
+	"test-kerja-mkp/internal/entity"
+	"test-kerja-mkp/internal/model"
+	"time"
+)
+
+func CardProductToResponse(product *entity.CardProduct) *model.CardProductResponse {
+	response := &model.CardProductResponse{
+		ID:            product.ID,
+		Code:          product.Code,
+		Name:          product.Name,
+		Concession:    product.Concession,
+		DiscountType:  product.DiscountType,
+		DiscountValue: product.DiscountValue.String(),
+		ValidFrom:     product.ValidFrom.Format(time.DateOnly),
+		CreatedAt:     product.CreatedAt,
+		UpdatedAt:     product.UpdatedAt,
+	}
+	if product.ValidUntil != nil {
+		validUntil := product.ValidUntil.Format(time.DateOnly)
+		response.ValidUntil = &validUntil
+	}
+	return response
+}
+
+func ConcessionUsageToResponse(usage *entity.ConcessionUsage) *model.ConcessionUsageResponse {
+	return &model.ConcessionUsageResponse{
+		ProductID:   usage.ProductID,
+		Code:        usage.Code,
+		Name:        usage.Name,
+		Concession:  usage.Concession,
+		Journeys:    usage.Journeys,
+		RegularFare: usage.RegularFare.String(),
+		FareCharged: usage.FareCharged.String(),
+		Discount:    (usage.RegularFare - usage.FareCharged).String(),
+	}
+}
@@ -0,0 +1,17 @@
+package converter
+
+import (
+	"test-kerja-mkp/internal/entity"
+	"test-kerja-mkp/internal/model"
+)
+
+func ChannelPartnerToResponse(partner *entity.ChannelPartner) *model.ChannelPartnerResponse {
+	return &model.ChannelPartnerResponse{
+		ID:         partner.ID,
+		Name:       partner.Name,
+		CreatedBy:  partner.CreatedBy,
+		DisabledAt: partner.DisabledAt,
+		LastUsedAt: partner.LastUsedAt,
+		CreatedAt:  partner.CreatedAt,
+	}
+}
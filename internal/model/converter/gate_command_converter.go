@@ -0,0 +1,22 @@
+package converter
+
+import (
+	"test-kerja-mkp/internal/entity"
+	"test-kerja-mkp/internal/model"
+)
+
+func GateCommandToResponse(command *entity.GateCommand) *model.GateCommandResponse {
+	return &model.GateCommandResponse{
+		ID:          command.ID,
+		GateID:      command.GateID,
+		Command:     command.Command,
+		Payload:     command.Payload,
+		Status:      command.Status,
+		Result:      command.Result,
+		IssuedBy:    command.IssuedBy,
+		ExpiresAt:   command.ExpiresAt,
+		DeliveredAt: command.DeliveredAt,
+		CompletedAt: command.CompletedAt,
+		CreatedAt:   command.CreatedAt,
+	}
+}
@@ -0,0 +1,66 @@
+package converter
+
+import (
+	"test-kerja-mkp/internal/entity"
+	"test-kerja-mkp/internal/model"
+	"time"
+)
+
+func GateToResponse(gate *entity.Gate) *model.GateResponse {
+	return &model.GateResponse{
+		ID:               gate.ID,
+		TerminalID:       gate.TerminalID,
+		GateNumber:       gate.GateNumber,
+		Status:           gate.Status,
+		DecommissionedAt: gate.DecommissionedAt,
+		CreatedAt:        gate.CreatedAt,
+		UpdatedAt:        gate.UpdatedAt,
+	}
+}
+
+func GateToStatusResponse(gate *entity.Gate) *model.GateStatusResponse {
+	return &model.GateStatusResponse{
+		ID:                  gate.ID,
+		GateNumber:          gate.GateNumber,
+		Status:              gate.Status,
+		Down:                gate.IsDown(),
+		Since:               gate.StatusChangedAt,
+		LastHeartbeatAt:     gate.LastHeartbeatAt,
+		FirmwareVersion:     gate.FirmwareVersion,
+		ClockOffsetMs:       gate.ClockOffsetMs,
+		PendingOfflineCount: gate.PendingOfflineCount,
+	}
+}
+
+func GateStatusHistoryToResponse(history *entity.GateStatusHistory) *model.GateStatusHistoryResponse {
+	return &model.GateStatusHistoryResponse{
+		ID:         history.ID,
+		FromStatus: history.FromStatus,
+		ToStatus:   history.ToStatus,
+		Reason:     history.Reason,
+		ChangedAt:  history.ChangedAt,
+	}
+}
+
+func GateSyncErrorToEvent(gate *entity.Gate, reason string, previousCount int, reportedAt time.Time) *model.SyncErrorEvent {
+	return &model.SyncErrorEvent{
+		GateID:              gate.ID,
+		GateNumber:          gate.GateNumber,
+		Reason:              reason,
+		Status:              gate.Status,
+		PendingOfflineCount: gate.PendingOfflineCount,
+		PreviousCount:       previousCount,
+		ReportedAt:          reportedAt,
+	}
+}
+
+func GateStatusToEvent(gate *entity.Gate, history *entity.GateStatusHistory) *model.GateStatusEvent {
+	return &model.GateStatusEvent{
+		GateID:     gate.ID,
+		GateNumber: gate.GateNumber,
+		FromStatus: history.FromStatus,
+		ToStatus:   history.ToStatus,
+		Reason:     history.Reason,
+		ChangedAt:  history.ChangedAt,
+	}
+}
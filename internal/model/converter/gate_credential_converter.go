@@ -0,0 +1,19 @@
+package converter
+
+import (
+	"test-kerja-mkp/internal/entity"
+	"test-kerja-mkp/internal/model"
+)
+
+func GateCredentialToResponse(credential *entity.GateCredential) *model.GateCredentialResponse {
+	return &model.GateCredentialResponse{
+		ID:         credential.ID,
+		GateID:     credential.GateID,
+		AuthType:   credential.AuthType,
+		CreatedBy:  credential.CreatedBy,
+		ExpiresAt:  credential.ExpiresAt,
+		RevokedAt:  credential.RevokedAt,
+		LastUsedAt: credential.LastUsedAt,
+		CreatedAt:  credential.CreatedAt,
+	}
+}
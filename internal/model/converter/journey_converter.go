@@ -0,0 +1,53 @@
+package converter
+
+import (
+	"test-kerja-mkp/internal/entity"
+	"test-kerja-mkp/internal/model"
+)
+
+func JourneyToResponse(journey *entity.Journey) *model.JourneyResponse {
+	response := &model.JourneyResponse{
+		ID:                  journey.ID,
+		CardNumber:          journey.CardNumber,
+		OriginTerminal:      journey.OriginTerminal,
+		DestinationTerminal: journey.DestinationTerminal,
+		CheckInGate:         journey.CheckInGate,
+		CheckOutGate:        journey.CheckOutGate,
+		CheckInTime:         journey.CheckInTime,
+		CheckOutTime:        journey.CheckOutTime,
+		MaxFareHeld:         journey.MaxFareHeld.String(),
+		ProductID:           journey.ProductID,
+		Status:              journey.Status,
+	}
+	if journey.RegularFare != nil {
+		fare := journey.RegularFare.String()
+		response.RegularFare = &fare
+	}
+	if journey.FareCharged != nil {
+		fare := journey.FareCharged.String()
+		response.FareCharged = &fare
+	}
+	return response
+}
+
+func JourneyToCheckInEvent(journey *entity.Journey, gate *model.AuthGate) *model.JourneyEvent {
+	return &model.JourneyEvent{
+		JourneyID:  journey.ID,
+		CardNumber: journey.CardNumber,
+		GateID:     gate.GateID,
+		GateNumber: gate.GateNumber,
+		Time:       journey.CheckInTime,
+	}
+}
+
+func JourneyToCheckOutEvent(journey *entity.Journey, gate *model.AuthGate) *model.JourneyEvent {
+	fare := journey.FareCharged.String()
+	return &model.JourneyEvent{
+		JourneyID:  journey.ID,
+		CardNumber: journey.CardNumber,
+		GateID:     gate.GateID,
+		GateNumber: gate.GateNumber,
+		Time:       *journey.CheckOutTime,
+		Fare:       &fare,
+	}
+}
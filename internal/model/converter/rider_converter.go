@@ -0,0 +1,18 @@
+package converter
+
+import (
+	"test-kerja-mkp/internal/entity"
+	"test-kerja-mkp/internal/model"
+)
+
+func RiderToResponse(rider *entity.Rider) *model.RiderResponse {
+	return &model.RiderResponse{
+		ID:        rider.ID,
+		Name:      rider.Name,
+		IDNumber:  rider.IDNumber,
+		Phone:     rider.Phone,
+		Email:     rider.Email,
+		CreatedAt: rider.CreatedAt,
+		UpdatedAt: rider.UpdatedAt,
+	}
+}
@@ -0,0 +1,142 @@
+package converter
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"test-kerja-mkp/internal/entity"
+	"test-kerja-mkp/internal/model"
+)
+
+func TerminalToResponse(terminal *entity.Terminal) *model.TerminalResponse {
+	response := &model.TerminalResponse{
+		TerminalId: terminal.IDTerminal,
+		Name:       terminal.Name,
+		Location:   terminal.Location,
+		Address:    terminal.Address,
+		RegionCode: terminal.RegionCode,
+		Latitude:   terminal.Latitude,
+		Longitude:  terminal.Longitude,
+		CreatedAt:  terminal.CreatedAt,
+		UpdatedAt:  terminal.UpdatedAt,
+	}
+	if terminal.DeletedAt.Valid {
+		response.DeletedAt = &terminal.DeletedAt.Time
+	}
+	return response
+}
+
+func TerminalDistanceToResponse(terminal *entity.TerminalDistance) *model.NearbyTerminalResponse {
+	return &model.NearbyTerminalResponse{
+		TerminalId: terminal.IDTerminal,
+		Name:       terminal.Name,
+		Location:   terminal.Location,
+		Address:    terminal.Address,
+		RegionCode: terminal.RegionCode,
+		Latitude:   terminal.Latitude,
+		Longitude:  terminal.Longitude,
+		DistanceKm: math.Round(terminal.Distance*1000) / 1000,
+	}
+}
+
+// TerminalCSVHeader lists the columns of the terminal import and export files.
+var TerminalCSVHeader = []string{"name", "location", "address", "region_code", "latitude", "longitude"}
+
+func TerminalToImportRow(terminal *entity.Terminal) *model.CreateTerminalRequest {
+	return &model.CreateTerminalRequest{
+		Name:       terminal.Name,
+		Location:   terminal.Location,
+		Address:    terminal.Address,
+		RegionCode: terminal.RegionCode,
+		Latitude:   terminal.Latitude,
+		Longitude:  terminal.Longitude,
+	}
+}
+
+// TerminalCSVColumns maps the header of an import file to column positions.
+// Columns may come in any order; name and location are mandatory.
+func TerminalCSVColumns(header []string) (map[string]int, error) {
+	columns := make(map[string]int, len(header))
+	for i, column := range header {
+		column = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(column, "\ufeff")))
+		if !slices.Contains(TerminalCSVHeader, column) {
+			return nil, fmt.Errorf("unknown column %q", column)
+		}
+		if _, ok := columns[column]; ok {
+			return nil, fmt.Errorf("duplicate column %q", column)
+		}
+		columns[column] = i
+	}
+	for _, column := range []string{"name", "location"} {
+		if _, ok := columns[column]; !ok {
+			return nil, fmt.Errorf("missing column %q", column)
+		}
+	}
+	return columns, nil
+}
+
+// TerminalRowFromCSV reads one record of an import file. Values that cannot be
+// parsed are reported per column; empty optional values stay nil.
+func TerminalRowFromCSV(columns map[string]int, record []string) (*model.CreateTerminalRequest, map[string]string) {
+	value := func(column string) string {
+		if i, ok := columns[column]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+	optional := func(column string) *string {
+		if v := value(column); v != "" {
+			return &v
+		}
+		return nil
+	}
+
+	row := &model.CreateTerminalRequest{
+		Name:       value("name"),
+		Location:   value("location"),
+		Address:    optional("address"),
+		RegionCode: optional("region_code"),
+	}
+
+	errors := make(map[string]string)
+	for column, target := range map[string]**float64{"latitude": &row.Latitude, "longitude": &row.Longitude} {
+		if v := value(column); v != "" {
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				errors[column] = column + " must be a number"
+				continue
+			}
+			*target = &parsed
+		}
+	}
+	if len(errors) > 0 {
+		return row, errors
+	}
+	return row, nil
+}
+
+func TerminalRowToCSV(row *model.CreateTerminalRequest) []string {
+	optional := func(v *string) string {
+		if v == nil {
+			return ""
+		}
+		return *v
+	}
+	coordinate := func(v *float64) string {
+		if v == nil {
+			return ""
+		}
+		return strconv.FormatFloat(*v, 'f', -1, 64)
+	}
+
+	return []string{
+		row.Name,
+		row.Location,
+		optional(row.Address),
+		optional(row.RegionCode),
+		coordinate(row.Latitude),
+		coordinate(row.Longitude),
+	}
+}
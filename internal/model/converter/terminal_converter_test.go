@@ -0,0 +1,139 @@
+package converter
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"io"
+	"reflect"
+	"test-kerja-mkp/internal/entity"
+	"test-kerja-mkp/internal/model"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+)
+
+func TestTerminalCSVRoundTrip(t *testing.T) {
+	text := func(v string) *string { return &v }
+	number := func(v float64) *float64 { return &v }
+
+	terminals := []*entity.Terminal{
+		{Name: "Blok M", Location: "Jakarta Selatan", Address: text("Jl. Sisingamangaraja"), RegionCode: text("31.74"), Latitude: number(-6.243514), Longitude: number(106.800925)},
+		{Name: "Kalideres", Location: "Jakarta Barat"},
+		{Name: "Terminal, \"Lama\"", Location: "Bogor", Address: text("Jl. Raya\nNo. 1"), Latitude: number(-6.5), Longitude: number(106.8)},
+		{Name: "Pulo Gebang", Location: "Jakarta Timur", RegionCode: text("31.75"), Latitude: number(0), Longitude: number(-180)},
+		{Name: "Terminal Ñ 東", Location: "Ujung", Address: text("100% _done_ \\ path"), Latitude: number(90), Longitude: number(180)},
+	}
+
+	// Written the way Export writes the csv format.
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	if err := writer.Write(TerminalCSVHeader); err != nil {
+		t.Fatalf("write header: %v", err)
+	}
+	for _, terminal := range terminals {
+		if err := writer.Write(TerminalRowToCSV(TerminalToImportRow(terminal))); err != nil {
+			t.Fatalf("write row: %v", err)
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		t.Fatalf("flush: %v", err)
+	}
+
+	// Read back the way the import endpoint reads a csv upload.
+	reader := csv.NewReader(&buf)
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err != nil {
+		t.Fatalf("read header: %v", err)
+	}
+	columns, err := TerminalCSVColumns(header)
+	if err != nil {
+		t.Fatalf("TerminalCSVColumns: %v", err)
+	}
+
+	var rows []*model.CreateTerminalRequest
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("read row %d: %v", len(rows), err)
+		}
+		row, invalid := TerminalRowFromCSV(columns, record)
+		if invalid != nil {
+			t.Fatalf("row %d: %v", len(rows), invalid)
+		}
+		rows = append(rows, row)
+	}
+
+	if len(rows) != len(terminals) {
+		t.Fatalf("read %d rows, want %d", len(rows), len(terminals))
+	}
+	for i, terminal := range terminals {
+		if want := TerminalToImportRow(terminal); !reflect.DeepEqual(rows[i], want) {
+			t.Errorf("row %d = %+v, want %+v", i, rows[i], want)
+		}
+	}
+
+	// A dry-run import validates the rows before anything is written.
+	request := &model.ImportTerminalRequest{DryRun: true, Rows: rows}
+	if err := validator.New().Struct(request); err != nil {
+		t.Fatalf("dry-run import of the export: %v", err)
+	}
+}
+
+func TestTerminalCSVColumns(t *testing.T) {
+	tests := []struct {
+		name   string
+		header []string
+		want   map[string]int
+		err    bool
+	}{
+		{name: "export header", header: TerminalCSVHeader, want: map[string]int{"name": 0, "location": 1, "address": 2, "region_code": 3, "latitude": 4, "longitude": 5}},
+		{name: "any order and case", header: []string{" Location", "NAME "}, want: map[string]int{"location": 0, "name": 1}},
+		{name: "byte order mark", header: []string{"\ufeffname", "location"}, want: map[string]int{"name": 0, "location": 1}},
+		{name: "unknown column", header: []string{"name", "location", "id_terminal"}, err: true},
+		{name: "duplicate column", header: []string{"name", "location", "Name"}, err: true},
+		{name: "missing location", header: []string{"name", "address"}, err: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := TerminalCSVColumns(tt.header)
+			if tt.err {
+				if err == nil {
+					t.Fatalf("TerminalCSVColumns(%q) = %v, want error", tt.header, got)
+				}
+				return
+			}
+			if err != nil || !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("TerminalCSVColumns(%q) = %v, %v; want %v", tt.header, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestTerminalRowFromCSV(t *testing.T) {
+	columns := map[string]int{"name": 0, "location": 1, "address": 2, "latitude": 3, "longitude": 4}
+
+	row, invalid := TerminalRowFromCSV(columns, []string{" Blok M ", "Jakarta", "", "-6.2", "106.8"})
+	if invalid != nil {
+		t.Fatalf("TerminalRowFromCSV: %v", invalid)
+	}
+	if row.Name != "Blok M" || row.Address != nil || row.RegionCode != nil || *row.Latitude != -6.2 || *row.Longitude != 106.8 {
+		t.Fatalf("row = %+v", row)
+	}
+
+	// A short record leaves the missing columns empty.
+	row, invalid = TerminalRowFromCSV(columns, []string{"Blok M", "Jakarta"})
+	if invalid != nil || row.Latitude != nil || row.Longitude != nil {
+		t.Fatalf("short record = %+v, %v", row, invalid)
+	}
+
+	_, invalid = TerminalRowFromCSV(columns, []string{"Blok M", "Jakarta", "", "south", "106,8"})
+	if invalid["latitude"] == "" || invalid["longitude"] == "" {
+		t.Fatalf("invalid coordinates = %v, want latitude and longitude errors", invalid)
+	}
+}
@@ -0,0 +1,31 @@
+package converter
+
+import (
+	"fmt"
+	"test-kerja-mkp/internal/entity"
+	"test-kerja-mkp/internal/model"
+)
+
+func OperatingHourToResponse(hour *entity.TerminalOperatingHour) *model.OperatingHourResponse {
+	return &model.OperatingHourResponse{
+		DayOfWeek: hour.DayOfWeek,
+		OpenTime:  formatClock(hour.OpenMinute),
+		CloseTime: formatClock(hour.CloseMinute),
+	}
+}
+
+func TerminalClosureToResponse(closure *entity.TerminalClosure) *model.TerminalClosureResponse {
+	return &model.TerminalClosureResponse{
+		ID:         closure.ID,
+		TerminalID: closure.TerminalID,
+		StartDate:  closure.StartDate.Format("2006-01-02"),
+		EndDate:    closure.EndDate.Format("2006-01-02"),
+		Reason:     closure.Reason,
+		CreatedBy:  closure.CreatedBy,
+		CreatedAt:  closure.CreatedAt,
+	}
+}
+
+func formatClock(minute int) string {
+	return fmt.Sprintf("%02d:%02d", minute/60, minute%60)
+}
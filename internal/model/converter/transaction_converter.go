@@ -0,0 +1,21 @@
+package converter
+
+import (
+	"test-kerja-mkp/internal/entity"
+	"test-kerja-mkp/internal/model"
+)
+
+func TransactionToTopUpResponse(transaction *entity.Transaction) *model.TopUpResponse {
+	response := &model.TopUpResponse{
+		TransactionID: transaction.ID,
+		CardNumber:    transaction.CardNumber,
+		Amount:        transaction.Amount.String(),
+		BalanceBefore: transaction.BalanceBefore.String(),
+		BalanceAfter:  transaction.BalanceAfter.String(),
+		Timestamp:     transaction.Timestamp,
+	}
+	if transaction.ReferenceNumber != nil {
+		response.ReferenceNumber = *transaction.ReferenceNumber
+	}
+	return response
+}
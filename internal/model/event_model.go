@@ -0,0 +1,47 @@
+package model
+
+import "time"
+
+// Event is a real-time event published to the control room. TerminalID is the
+// terminal the event happened at and is used for filtering.
+type Event struct {
+	ID         uint64    `json:"id"`
+	Type       string    `json:"type"`
+	TerminalID int64     `json:"id_terminal"`
+	Time       time.Time `json:"time"`
+	Data       any       `json:"data"`
+}
+
+// EventStreamRequest filters the event stream. Empty lists match everything.
+type EventStreamRequest struct {
+	TerminalIDs []int64  `json:"id_terminal" validate:"max=50,dive,gt=0"`
+	Types       []string `json:"type" validate:"max=10,dive,oneof=gate.status journey.checkin journey.checkout sync.error"`
+}
+
+// EventsDroppedResponse tells a client that events were dropped because it did
+// not keep up with the stream.
+type EventsDroppedResponse struct {
+	Count uint64 `json:"count"`
+}
+
+type GateStatusEvent struct {
+	GateID     int64     `json:"id_gates"`
+	GateNumber string    `json:"gate_number"`
+	FromStatus string    `json:"from_status"`
+	ToStatus   string    `json:"to_status"`
+	Reason     string    `json:"reason"`
+	ChangedAt  time.Time `json:"changed_at"`
+}
+
+// SyncErrorEvent warns that a gate cannot sync its offline transactions,
+// either because it reports itself in error or because its backlog of
+// offline transactions keeps growing.
+type SyncErrorEvent struct {
+	GateID              int64     `json:"id_gates"`
+	GateNumber          string    `json:"gate_number"`
+	Reason              string    `json:"reason"`
+	Status              string    `json:"status"`
+	PendingOfflineCount int       `json:"pending_offline_count"`
+	PreviousCount       int       `json:"previous_pending_offline_count"`
+	ReportedAt          time.Time `json:"reported_at"`
+}
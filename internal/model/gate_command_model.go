@@ -0,0 +1,48 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// IssueGateCommandRequest queues a command for a gate. Enabled is required by
+// emergency_mode and switches free-exit mode on or off. TTL is how long the
+// command may wait for the gate, in seconds.
+type IssueGateCommandRequest struct {
+	GateID  int64  `json:"-" validate:"required,gt=0"`
+	Command string `json:"command" validate:"required,oneof=open emergency_mode reload_config flush_offline_queue"`
+	Enabled *bool  `json:"enabled" validate:"required_if=Command emergency_mode"`
+	TTL     int    `json:"ttl" validate:"min=5,max=86400"`
+}
+
+type CancelGateCommandRequest struct {
+	GateID int64 `json:"-" validate:"required,gt=0"`
+	ID     int64 `json:"-" validate:"required,gt=0"`
+}
+
+// PullGateCommandRequest asks for the open commands of the authenticated gate,
+// waiting up to Wait seconds for one to be issued.
+type PullGateCommandRequest struct {
+	Wait int `json:"wait" validate:"min=0,max=30"`
+}
+
+// AckGateCommandRequest reports the outcome of a command executed by the gate.
+type AckGateCommandRequest struct {
+	ID     int64  `json:"-" validate:"required,gt=0"`
+	Status string `json:"status" validate:"required,oneof=acknowledged failed"`
+	Result string `json:"result" validate:"max=255"`
+}
+
+type GateCommandResponse struct {
+	ID          int64           `json:"id_command"`
+	GateID      int64           `json:"id_gates"`
+	Command     string          `json:"command"`
+	Payload     json.RawMessage `json:"payload"`
+	Status      string          `json:"status"`
+	Result      *string         `json:"result"`
+	IssuedBy    int64           `json:"issued_by"`
+	ExpiresAt   time.Time       `json:"expires_at"`
+	DeliveredAt *time.Time      `json:"delivered_at"`
+	CompletedAt *time.Time      `json:"completed_at"`
+	CreatedAt   time.Time       `json:"created_at"`
+}
@@ -0,0 +1,53 @@
+package model
+
+import "time"
+
+type GateCredentialRequest struct {
+	GateID   int64  `json:"-"`
+	AuthType string `json:"auth_type" validate:"required,oneof=api_key hmac"`
+}
+
+type RevokeGateCredentialRequest struct {
+	GateID       int64  `json:"-"`
+	CredentialID string `json:"-" validate:"required,uuid"`
+}
+
+type GateCredentialResponse struct {
+	ID         string     `json:"id_credential"`
+	GateID     int64      `json:"id_gates"`
+	AuthType   string     `json:"auth_type"`
+	CreatedBy  int64      `json:"created_by"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// GateCredentialSecretResponse is returned once, when a credential is issued.
+// For api_key the secret is the full key sent in X-Gate-Api-Key; for hmac it
+// is the signing secret and id_credential goes in X-Gate-Key-Id.
+type GateCredentialSecretResponse struct {
+	GateCredentialResponse
+	Secret string `json:"secret"`
+}
+
+// VerifyGateRequest carries what the gate middleware read from the request.
+// APIKey is set for api_key credentials; the other fields for hmac.
+type VerifyGateRequest struct {
+	APIKey    string
+	KeyID     string
+	Timestamp string
+	Nonce     string
+	Signature string
+	Method    string
+	Path      string
+	Body      []byte
+}
+
+// AuthGate is the authenticated gate stored in ctx.Locals("gate").
+type AuthGate struct {
+	GateID       int64  `json:"id_gates"`
+	TerminalID   int64  `json:"id_terminal"`
+	GateNumber   string `json:"gate_number"`
+	CredentialID string `json:"id_credential"`
+}
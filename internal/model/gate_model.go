@@ -0,0 +1,32 @@
+package model
+
+import "time"
+
+type CreateGateRequest struct {
+	TerminalID int64  `json:"-" validate:"required,gt=0"`
+	GateNumber string `json:"gate_number" validate:"required,max=50"`
+}
+
+type RenameGateRequest struct {
+	TerminalID int64  `json:"-" validate:"required,gt=0"`
+	ID         int64  `json:"-" validate:"required,gt=0"`
+	GateNumber string `json:"gate_number" validate:"required,max=50"`
+}
+
+// MoveGateRequest moves a gate to the terminal TargetTerminalID, keeping its
+// gate number and credentials.
+type MoveGateRequest struct {
+	TerminalID       int64 `json:"-" validate:"required,gt=0"`
+	ID               int64 `json:"-" validate:"required,gt=0"`
+	TargetTerminalID int64 `json:"id_terminal" validate:"required,gt=0"`
+}
+
+type GateResponse struct {
+	ID               int64      `json:"id_gates"`
+	TerminalID       int64      `json:"id_terminal"`
+	GateNumber       string     `json:"gate_number"`
+	Status           string     `json:"status"`
+	DecommissionedAt *time.Time `json:"decommissioned_at"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
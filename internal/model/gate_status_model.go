@@ -0,0 +1,51 @@
+package model
+
+import "time"
+
+// GateHeartbeatRequest is sent periodically by a gate device. LocalTime is the
+// device clock when the heartbeat was sent and Status is the state the device
+// reports itself in, online when omitted.
+type GateHeartbeatRequest struct {
+	FirmwareVersion     string     `json:"firmware_version" validate:"required,max=50"`
+	LocalTime           *time.Time `json:"local_time" validate:"required"`
+	PendingOfflineCount int        `json:"pending_offline_count" validate:"min=0"`
+	Status              string     `json:"status" validate:"omitempty,oneof=online error maintenance"`
+}
+
+// GateHeartbeatResponse gives the device the server time so it can correct
+// its clock, and the offset the server measured.
+type GateHeartbeatResponse struct {
+	ServerTime    time.Time `json:"server_time"`
+	Status        string    `json:"status"`
+	ClockOffsetMs int64     `json:"clock_offset_ms"`
+}
+
+type GateStatusResponse struct {
+	ID                  int64      `json:"id_gates"`
+	GateNumber          string     `json:"gate_number"`
+	Status              string     `json:"status"`
+	Down                bool       `json:"down"`
+	Since               *time.Time `json:"since"`
+	LastHeartbeatAt     *time.Time `json:"last_heartbeat_at"`
+	FirmwareVersion     *string    `json:"firmware_version"`
+	ClockOffsetMs       *int64     `json:"clock_offset_ms"`
+	PendingOfflineCount int        `json:"pending_offline_count"`
+}
+
+// TerminalGateStatusResponse is the status dashboard of the gates in service
+// of a terminal, the gates that are down first.
+type TerminalGateStatusResponse struct {
+	TerminalID int64                 `json:"id_terminal"`
+	Total      int                   `json:"total"`
+	Online     int                   `json:"online"`
+	Down       int                   `json:"down"`
+	Gates      []*GateStatusResponse `json:"gates"`
+}
+
+type GateStatusHistoryResponse struct {
+	ID         int64     `json:"id_status_history"`
+	FromStatus string    `json:"from_status"`
+	ToStatus   string    `json:"to_status"`
+	Reason     string    `json:"reason"`
+	ChangedAt  time.Time `json:"changed_at"`
+}
@@ -0,0 +1,46 @@
+package model
+
+import "time"
+
+type CheckInRequest struct {
+	CardNumber int64 `json:"card_number" validate:"required,gt=0"`
+}
+
+type CheckOutRequest struct {
+	CardNumber int64 `json:"card_number" validate:"required,gt=0"`
+}
+
+// JourneyResponse carries the fares as decimal strings such as "1500.50".
+type JourneyResponse struct {
+	ID                  string     `json:"id_journey"`
+	CardNumber          int64      `json:"card_number"`
+	OriginTerminal      int64      `json:"origin_terminal"`
+	DestinationTerminal *int64     `json:"destination_terminal"`
+	CheckInGate         int64      `json:"checkin_gate"`
+	CheckOutGate        *int64     `json:"checkout_gate"`
+	CheckInTime         time.Time  `json:"checkin_time"`
+	CheckOutTime        *time.Time `json:"checkout_time"`
+	ProductID           *int64     `json:"id_product"`
+	RegularFare         *string    `json:"regular_fare"`
+	FareCharged         *string    `json:"fare_charged"`
+	MaxFareHeld         string     `json:"max_fare_held"`
+	Status              string     `json:"journey_status"`
+}
+
+// JourneyGateResponse is shown by the gate. Balance is the card balance after
+// the check-in or check-out.
+type JourneyGateResponse struct {
+	Journey *JourneyResponse `json:"journey"`
+	Balance string           `json:"balance"`
+}
+
+// JourneyEvent is published on check-in and check-out. Fare is only set on
+// check-out.
+type JourneyEvent struct {
+	JourneyID  string    `json:"id_journey"`
+	CardNumber int64     `json:"card_number"`
+	GateID     int64     `json:"id_gates"`
+	GateNumber string    `json:"gate_number"`
+	Time       time.Time `json:"time"`
+	Fare       *string   `json:"fare,omitempty"`
+}
@@ -0,0 +1,19 @@
+package model
+
+import "time"
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" validate:"required,max=72"`
+	NewPassword     string `json:"new_password" validate:"required,max=72"`
+}
+
+type ResetPasswordRequest struct {
+	Token       string `json:"token" validate:"required,max=100"`
+	NewPassword string `json:"new_password" validate:"required,max=72"`
+}
+
+type PasswordResetResponse struct {
+	AdminID    int64     `json:"id_admin"`
+	ResetToken string    `json:"reset_token"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
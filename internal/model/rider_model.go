@@ -0,0 +1,27 @@
+package model
+
+import "time"
+
+type CreateRiderRequest struct {
+	Name     string `json:"name" validate:"required,max=100"`
+	IDNumber string `json:"id_number" validate:"required,alphanum,max=30"`
+	Phone    string `json:"phone" validate:"omitempty,e164"`
+	Email    string `json:"email" validate:"omitempty,email,max=100"`
+}
+
+// RegisterCardRequest registers the card CardNumber to the rider RiderID.
+type RegisterCardRequest struct {
+	RiderID    int64 `json:"-" validate:"required,gt=0"`
+	CardNumber int64 `json:"card_number" validate:"required,gt=0"`
+}
+
+type RiderResponse struct {
+	ID        int64           `json:"id_rider"`
+	Name      string          `json:"name"`
+	IDNumber  string          `json:"id_number"`
+	Phone     *string         `json:"phone"`
+	Email     *string         `json:"email"`
+	Cards     []*CardResponse `json:"cards,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
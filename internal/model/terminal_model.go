@@ -1,18 +1,97 @@
 package model
 
+import "time"
+
 type Terminal struct {
-	ID          int64     `json:"id_terminal"`
-	Name       string    `json:"name"`
-	Location   string    `json:"location"`
+	ID       int64  `json:"id_terminal"`
+	Name     string `json:"name"`
+	Location string `json:"location"`
 }
 
+// CreateTerminalRequest creates a terminal. Latitude and longitude are
+// optional but must be given together.
 type CreateTerminalRequest struct {
-    Name       string                `form:"name" validate:"required"`
-    Location   string                `form:"location" validate:"required"`
+	Name       string   `json:"name" form:"name" validate:"required,max=100"`
+	Location   string   `json:"location" form:"location" validate:"required,max=100"`
+	Address    *string  `json:"address" form:"address" validate:"omitempty,max=255"`
+	RegionCode *string  `json:"region_code" form:"region_code" validate:"omitempty,max=20"`
+	Latitude   *float64 `json:"latitude" form:"latitude" validate:"required_with=Longitude,omitempty,gte=-90,lte=90"`
+	Longitude  *float64 `json:"longitude" form:"longitude" validate:"required_with=Latitude,omitempty,gte=-180,lte=180"`
+}
+
+type UpdateTerminalRequest struct {
+	ID         int64    `json:"-" form:"-" validate:"required,gt=0"`
+	Name       string   `json:"name" form:"name" validate:"required,max=100"`
+	Location   string   `json:"location" form:"location" validate:"required,max=100"`
+	Address    *string  `json:"address" form:"address" validate:"omitempty,max=255"`
+	RegionCode *string  `json:"region_code" form:"region_code" validate:"omitempty,max=20"`
+	Latitude   *float64 `json:"latitude" form:"latitude" validate:"required_with=Longitude,omitempty,gte=-90,lte=90"`
+	Longitude  *float64 `json:"longitude" form:"longitude" validate:"required_with=Latitude,omitempty,gte=-180,lte=180"`
+}
+
+// SearchTerminalRequest lists terminals. Search matches name and location.
+// Deleted selects how soft deleted terminals are treated: left empty they are
+// hidden, "include" lists them alongside the others and "only" lists nothing
+// but them. HasOnlineGates keeps terminals with, or without, an online gate.
+type SearchTerminalRequest struct {
+	Search         string `json:"search" validate:"max=100"`
+	HasOnlineGates *bool  `json:"has_online_gates"`
+	Deleted        string `json:"deleted" validate:"omitempty,oneof=include only"`
+	Sort           string `json:"sort" validate:"omitempty,oneof=name location created_at updated_at"`
+	Order          string `json:"order" validate:"omitempty,oneof=asc desc"`
+	Page           int    `json:"page" validate:"min=1"`
+	Size           int    `json:"size" validate:"min=1,max=100"`
+}
+
+// NearbyTerminalRequest looks up the terminals within Radius kilometres of a
+// point, nearest first.
+type NearbyTerminalRequest struct {
+	Latitude  float64 `json:"lat" validate:"gte=-90,lte=90"`
+	Longitude float64 `json:"lng" validate:"gte=-180,lte=180"`
+	Radius    float64 `json:"radius" validate:"gt=0,lte=100"`
+	Limit     int     `json:"limit" validate:"min=1,max=100"`
 }
 
 type TerminalResponse struct {
-	TerminalId       int64     `json:"id_terminal"`
-	Name       string    `json:"name"`
-	Location   string    `json:"location"`
+	TerminalId int64      `json:"id_terminal"`
+	Name       string     `json:"name"`
+	Location   string     `json:"location"`
+	Address    *string    `json:"address"`
+	RegionCode *string    `json:"region_code"`
+	Latitude   *float64   `json:"latitude"`
+	Longitude  *float64   `json:"longitude"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+	DeletedAt  *time.Time `json:"deleted_at,omitempty"`
+}
+
+type NearbyTerminalResponse struct {
+	TerminalId int64    `json:"id_terminal"`
+	Name       string   `json:"name"`
+	Location   string   `json:"location"`
+	Address    *string  `json:"address"`
+	RegionCode *string  `json:"region_code"`
+	Latitude   *float64 `json:"latitude"`
+	Longitude  *float64 `json:"longitude"`
+	DistanceKm float64  `json:"distance_km"`
+}
+
+// ImportTerminalRequest creates all rows in one transaction. A dry run checks
+// and inserts them the same way but rolls back at the end.
+type ImportTerminalRequest struct {
+	DryRun bool                     `json:"dry_run"`
+	Rows   []*CreateTerminalRequest `json:"rows" validate:"required,min=1,max=1000,dive,required"`
+}
+
+type ImportTerminalResponse struct {
+	Total   int  `json:"total"`
+	Created int  `json:"created"`
+	DryRun  bool `json:"dry_run"`
+}
+
+// ImportRowError reports the invalid fields of one import row. Row counts
+// data rows from 1, excluding the CSV header.
+type ImportRowError struct {
+	Row    int               `json:"row"`
+	Errors map[string]string `json:"errors"`
 }
@@ -0,0 +1,58 @@
+package model
+
+import "time"
+
+const (
+	TerminalClosedByClosure      = "closure"
+	TerminalClosedOutsideOfHours = "outside_hours"
+)
+
+// OperatingHourRequest is one opening interval in "15:04" local time.
+// CloseTime may be "24:00" and must be after OpenTime.
+type OperatingHourRequest struct {
+	DayOfWeek int    `json:"day_of_week" validate:"min=0,max=6"`
+	OpenTime  string `json:"open_time" validate:"required,len=5"`
+	CloseTime string `json:"close_time" validate:"required,len=5"`
+}
+
+// UpdateOperatingHoursRequest replaces the weekly schedule of a terminal. An
+// empty list leaves the terminal open around the clock.
+type UpdateOperatingHoursRequest struct {
+	TerminalID int64                   `json:"-" validate:"required,gt=0"`
+	Hours      []*OperatingHourRequest `json:"hours" validate:"max=100,dive,required"`
+}
+
+type OperatingHourResponse struct {
+	DayOfWeek int    `json:"day_of_week"`
+	OpenTime  string `json:"open_time"`
+	CloseTime string `json:"close_time"`
+}
+
+type CreateTerminalClosureRequest struct {
+	TerminalID int64  `json:"-" validate:"required,gt=0"`
+	StartDate  string `json:"start_date" validate:"required,datetime=2006-01-02"`
+	EndDate    string `json:"end_date" validate:"required,datetime=2006-01-02"`
+	Reason     string `json:"reason" validate:"required,max=255"`
+}
+
+type TerminalClosureResponse struct {
+	ID         int64     `json:"id_closure"`
+	TerminalID int64     `json:"id_terminal"`
+	StartDate  string    `json:"start_date"`
+	EndDate    string    `json:"end_date"`
+	Reason     string    `json:"reason"`
+	CreatedBy  int64     `json:"created_by"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// TerminalStatusResponse tells whether a terminal is open at a moment. Hours
+// are the intervals of that local day; Reason and Closure explain why it is
+// closed.
+type TerminalStatusResponse struct {
+	TerminalID int64                    `json:"id_terminal"`
+	At         time.Time                `json:"at"`
+	Open       bool                     `json:"open"`
+	Reason     string                   `json:"reason,omitempty"`
+	Closure    *TerminalClosureResponse `json:"closure,omitempty"`
+	Hours      []*OperatingHourResponse `json:"hours"`
+}
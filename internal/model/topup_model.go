@@ -0,0 +1,25 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// TopUpRequest adds Amount rupiah to a card. ReferenceNumber is the
+// idempotency key of the caller: retrying with the same reference returns
+// the original top-up instead of charging twice.
+type TopUpRequest struct {
+	CardNumber      int64       `json:"-" validate:"required,gt=0"`
+	Amount          json.Number `json:"amount" validate:"required,numeric"`
+	ReferenceNumber string      `json:"reference_number" validate:"required,max=50"`
+}
+
+type TopUpResponse struct {
+	TransactionID   int64     `json:"id_transaction"`
+	CardNumber      int64     `json:"card_number"`
+	Amount          string    `json:"amount"`
+	BalanceBefore   string    `json:"balance_before"`
+	BalanceAfter    string    `json:"balance_after"`
+	ReferenceNumber string    `json:"reference_number"`
+	Timestamp       time.Time `json:"timestamp"`
+}
@@ -0,0 +1,60 @@
+package model
+
+import "time"
+
+type TwoFactorChallengeResponse struct {
+	ChallengeToken string `json:"challenge_token"`
+	// Type is "2fa" when a TOTP or recovery code is expected and "2fa_enroll"
+	// when the role requires 2FA and the admin still has to enroll.
+	Type      string `json:"type"`
+	ExpiresIn int64  `json:"expires_in"`
+}
+
+type VerifyTwoFactorLoginRequest struct {
+	ChallengeToken string `json:"challenge_token" validate:"required"`
+	Code           string `json:"code" validate:"required_without=RecoveryCode,omitempty,len=6,numeric"`
+	RecoveryCode   string `json:"recovery_code" validate:"required_without=Code,omitempty,max=20"`
+	IPAddress      string `json:"-"`
+	UserAgent      string `json:"-"`
+}
+
+// EnrollTwoFactorChallengeRequest needs both the challenge from LoginAdmin and
+// the enrollment token issued by another admin, so the password alone is not
+// enough to bind an authenticator to the account.
+type EnrollTwoFactorChallengeRequest struct {
+	ChallengeToken  string `json:"challenge_token" validate:"required"`
+	EnrollmentToken string `json:"enrollment_token" validate:"required,max=100"`
+	IPAddress       string `json:"-"`
+}
+
+type ConfirmTwoFactorChallengeRequest struct {
+	ChallengeToken  string `json:"challenge_token" validate:"required"`
+	EnrollmentToken string `json:"enrollment_token" validate:"required,max=100"`
+	Code            string `json:"code" validate:"required,len=6,numeric"`
+	IPAddress       string `json:"-"`
+	UserAgent       string `json:"-"`
+}
+
+type ConfirmTwoFactorRequest struct {
+	Code string `json:"code" validate:"required,len=6,numeric"`
+}
+
+type DisableTwoFactorRequest struct {
+	Password string `json:"password" validate:"required,max=72"`
+	Code     string `json:"code" validate:"required,len=6,numeric"`
+}
+
+type TwoFactorSetupResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+type TwoFactorEnrollmentResponse struct {
+	AdminID         int64     `json:"id_admin"`
+	EnrollmentToken string    `json:"enrollment_token"`
+	ExpiresAt       time.Time `json:"expires_at"`
+}
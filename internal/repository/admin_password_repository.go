@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"test-kerja-mkp/internal/entity"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type AdminPasswordHistoryRepository struct {
+	Repository[entity.AdminPasswordHistory]
+	Log *logrus.Logger
+}
+
+func NewAdminPasswordHistoryRepository(log *logrus.Logger) *AdminPasswordHistoryRepository {
+	return &AdminPasswordHistoryRepository{
+		Log: log,
+	}
+}
+
+func (r *AdminPasswordHistoryRepository) FindLatestByAdminId(db *gorm.DB, adminID int64, limit int) ([]*entity.AdminPasswordHistory, error) {
+	var histories []*entity.AdminPasswordHistory
+	err := db.Where("id_admin = ?", adminID).
+		Order("created_at desc, id desc").
+		Limit(limit).
+		Find(&histories).Error
+	return histories, err
+}
+
+type AdminPasswordResetRepository struct {
+	Repository[entity.AdminPasswordReset]
+	Log *logrus.Logger
+}
+
+func NewAdminPasswordResetRepository(log *logrus.Logger) *AdminPasswordResetRepository {
+	return &AdminPasswordResetRepository{
+		Log: log,
+	}
+}
+
+func (r *AdminPasswordResetRepository) FindByTokenHashForUpdate(db *gorm.DB, reset *entity.AdminPasswordReset, tokenHash string) error {
+	return db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("token_hash = ?", tokenHash).
+		Take(reset).Error
+}
+
+// InvalidatePending marks every unused reset token of the admin as used, so
+// only the most recently issued token stays valid.
+func (r *AdminPasswordResetRepository) InvalidatePending(db *gorm.DB, adminID int64) error {
+	return db.Model(&entity.AdminPasswordReset{}).
+		Where("id_admin = ? AND used_at IS NULL", adminID).
+		Update("used_at", time.Now()).Error
+}
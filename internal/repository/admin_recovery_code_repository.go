@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"test-kerja-mkp/internal/entity"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type AdminRecoveryCodeRepository struct {
+	Repository[entity.AdminRecoveryCode]
+	Log *logrus.Logger
+}
+
+func NewAdminRecoveryCodeRepository(log *logrus.Logger) *AdminRecoveryCodeRepository {
+	return &AdminRecoveryCodeRepository{
+		Log: log,
+	}
+}
+
+func (r *AdminRecoveryCodeRepository) FindUnusedForUpdate(db *gorm.DB, code *entity.AdminRecoveryCode, adminID int64, codeHash string) error {
+	return db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id_admin = ? AND code_hash = ? AND used_at IS NULL", adminID, codeHash).
+		Take(code).Error
+}
+
+func (r *AdminRecoveryCodeRepository) DeleteByAdminId(db *gorm.DB, adminID int64) error {
+	return db.Where("id_admin = ?", adminID).Delete(&entity.AdminRecoveryCode{}).Error
+}
+
+type AdminTwoFactorEnrollmentRepository struct {
+	Repository[entity.AdminTwoFactorEnrollment]
+	Log *logrus.Logger
+}
+
+func NewAdminTwoFactorEnrollmentRepository(log *logrus.Logger) *AdminTwoFactorEnrollmentRepository {
+	return &AdminTwoFactorEnrollmentRepository{
+		Log: log,
+	}
+}
+
+func (r *AdminTwoFactorEnrollmentRepository) FindByTokenHash(db *gorm.DB, enrollment *entity.AdminTwoFactorEnrollment, tokenHash string) error {
+	return db.Where("token_hash = ?", tokenHash).Take(enrollment).Error
+}
+
+// InvalidatePending marks every unused enrollment token of the admin as used,
+// so only the most recently issued token stays valid.
+func (r *AdminTwoFactorEnrollmentRepository) InvalidatePending(db *gorm.DB, adminID int64) error {
+	return db.Model(&entity.AdminTwoFactorEnrollment{}).
+		Where("id_admin = ? AND used_at IS NULL", adminID).
+		Update("used_at", time.Now()).Error
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"test-kerja-mkp/internal/entity"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type AdminSessionRepository struct {
+	Repository[entity.AdminSession]
+	Log *logrus.Logger
+}
+
+func NewAdminSessionRepository(log *logrus.Logger) *AdminSessionRepository {
+	return &AdminSessionRepository{
+		Log: log,
+	}
+}
+
+func (r *AdminSessionRepository) FindByIdForUpdate(db *gorm.DB, session *entity.AdminSession, id string) error {
+	return db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id_session = ?", id).
+		Take(session).Error
+}
+
+func (r *AdminSessionRepository) FindActiveIdsByAdminId(db *gorm.DB, adminID int64) ([]string, error) {
+	var ids []string
+	err := db.Model(&entity.AdminSession{}).
+		Where("id_admin = ? AND revoked_at IS NULL AND expires_at > ?", adminID, time.Now()).
+		Pluck("id_session", &ids).Error
+	return ids, err
+}
+
+func (r *AdminSessionRepository) Revoke(db *gorm.DB, id string, reason string) error {
+	return db.Model(&entity.AdminSession{}).
+		Where("id_session = ? AND revoked_at IS NULL", id).
+		Updates(map[string]any{
+			"revoked_at":     time.Now(),
+			"revoked_reason": reason,
+		}).Error
+}
+
+func (r *AdminSessionRepository) RevokeAllByAdminId(db *gorm.DB, adminID int64, reason string) error {
+	return db.Model(&entity.AdminSession{}).
+		Where("id_admin = ? AND revoked_at IS NULL", adminID).
+		Updates(map[string]any{
+			"revoked_at":     time.Now(),
+			"revoked_reason": reason,
+		}).Error
+}
+
+type AdminRefreshTokenRepository struct {
+	Repository[entity.AdminRefreshToken]
+	Log *logrus.Logger
+}
+
+func NewAdminRefreshTokenRepository(log *logrus.Logger) *AdminRefreshTokenRepository {
+	return &AdminRefreshTokenRepository{
+		Log: log,
+	}
+}
+
+func (r *AdminRefreshTokenRepository) FindByTokenHashForUpdate(db *gorm.DB, token *entity.AdminRefreshToken, tokenHash string) error {
+	return db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("token_hash = ?", tokenHash).
+		Take(token).Error
+}
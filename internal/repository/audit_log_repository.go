@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"test-kerja-mkp/internal/entity"
+	"test-kerja-mkp/internal/model"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type AuditLogRepository struct {
+	Repository[entity.AuditLog]
+	Log *logrus.Logger
+}
+
+func NewAuditLogRepository(log *logrus.Logger) *AuditLogRepository {
+	return &AuditLogRepository{
+		Log: log,
+	}
+}
+
+func (r *AuditLogRepository) Search(db *gorm.DB, request *model.SearchAuditLogRequest) ([]*entity.AuditLog, int64, error) {
+	var logs []*entity.AuditLog
+	var total int64
+
+	query := db.Model(&entity.AuditLog{}).Scopes(r.filter(request))
+	if err := query.Count(&total).Error; err != nil {
+		r.Log.Errorf("Failed to count audit logs: %v", err)
+		return nil, 0, err
+	}
+
+	offset := (request.Page - 1) * request.Size
+	err := db.Scopes(r.filter(request)).
+		Order("created_at desc, id_audit_log desc").
+		Offset(offset).
+		Limit(request.Size).
+		Find(&logs).Error
+
+	if err != nil {
+		r.Log.Errorf("Failed to find audit logs: %v", err)
+		return nil, 0, err
+	}
+	return logs, total, nil
+}
+
+func (r *AuditLogRepository) filter(request *model.SearchAuditLogRequest) func(tx *gorm.DB) *gorm.DB {
+	return func(tx *gorm.DB) *gorm.DB {
+		if request.ActorID != 0 {
+			tx = tx.Where("id_admin = ?", request.ActorID)
+		}
+		if request.Action != "" {
+			tx = tx.Where("action = ?", request.Action)
+		}
+		if request.EntityType != "" {
+			tx = tx.Where("entity_type = ?", request.EntityType)
+		}
+		if request.EntityID != "" {
+			tx = tx.Where("entity_id = ?", request.EntityID)
+		}
+		if request.Success != nil {
+			tx = tx.Where("success = ?", *request.Success)
+		}
+		if request.From != nil {
+			tx = tx.Where("created_at >= ?", *request.From)
+		}
+		if request.To != nil {
+			tx = tx.Where("created_at < ?", *request.To)
+		}
+		return tx
+	}
+}
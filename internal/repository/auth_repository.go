@@ -23,6 +23,40 @@ func (r *AuthRepository) FindByToken(db *gorm.DB, admin *entity.Admin, token str
 }
 
 func (r *AuthRepository) FindByUsername(db *gorm.DB, admin *entity.Admin, username string) error {
-	return db.Where("username = ?", username).First(admin).Error
+	return db.Preload("Role.Permissions").Where("username = ?", username).First(admin).Error
 }
 
+func (r *AuthRepository) FindByIdWithRole(db *gorm.DB, admin *entity.Admin, id int64) error {
+	return db.Preload("Role.Permissions").Where("id_admin = ?", id).Take(admin).Error
+}
+
+func (r *AuthRepository) CountByUsername(db *gorm.DB, username string, excludeID int64) (int64, error) {
+	var total int64
+	err := db.Model(&entity.Admin{}).
+		Where("LOWER(username) = LOWER(?) AND id_admin <> ?", username, excludeID).
+		Count(&total).Error
+	return total, err
+}
+
+func (r *AuthRepository) FindAll(db *gorm.DB, page int, size int) ([]*entity.Admin, int64, error) {
+	var admins []*entity.Admin
+	var total int64
+
+	if err := db.Model(&entity.Admin{}).Count(&total).Error; err != nil {
+		r.Log.Errorf("Failed to count admins: %v", err)
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * size
+	err := db.Preload("Role.Permissions").
+		Order("created_at desc").
+		Offset(offset).
+		Limit(size).
+		Find(&admins).Error
+
+	if err != nil {
+		r.Log.Errorf("Failed to find admins: %v", err)
+		return nil, 0, err
+	}
+	return admins, total, nil
+}
@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"test-kerja-mkp/internal/entity"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type CardHotlistRepository struct {
+	Repository[entity.CardHotlist]
+	Log *logrus.Logger
+}
+
+func NewCardHotlistRepository(log *logrus.Logger) *CardHotlistRepository {
+	return &CardHotlistRepository{
+		Log: log,
+	}
+}
+
+// LockWriter serializes the writers of the hotlist until the end of the
+// transaction. Versions are then committed in order, so a reader that sees
+// version N has also seen every version below it.
+func (r *CardHotlistRepository) LockWriter(db *gorm.DB) error {
+	return db.Exec("SELECT pg_advisory_xact_lock(hashtext('card_hotlist'))").Error
+}
+
+// CurrentVersion returns the latest hotlist version, 0 when it is empty.
+func (r *CardHotlistRepository) CurrentVersion(db *gorm.DB) (int64, error) {
+	var version int64
+	err := db.Model(&entity.CardHotlist{}).
+		Select("COALESCE(MAX(id_version), 0)").
+		Scan(&version).Error
+	return version, err
+}
+
+// FindLatestBetween returns the latest entry of every card changed after
+// version from up to and including version to.
+func (r *CardHotlistRepository) FindLatestBetween(db *gorm.DB, from int64, to int64) ([]*entity.CardHotlist, error) {
+	var entries []*entity.CardHotlist
+	err := db.Select("DISTINCT ON (card_number) *").
+		Where("id_version > ? AND id_version <= ?", from, to).
+		Order("card_number, id_version DESC").
+		Find(&entries).Error
+	return entries, err
+}
@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"test-kerja-mkp/internal/entity"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type CardProductRepository struct {
+	Repository[entity.CardProduct]
+	Log *logrus.Logger
+}
+
+func NewCardProductRepository(log *logrus.Logger) *CardProductRepository {
+	return &CardProductRepository{
+		Log: log,
+	}
+}
+
+func (r *CardProductRepository) FindAll(db *gorm.DB) ([]*entity.CardProduct, error) {
+	var products []*entity.CardProduct
+	err := db.Order("code").Find(&products).Error
+	return products, err
+}
+
+// FindUsage sums, per card product, the completed journeys checked out from
+// from up to but excluding to.
+func (r *CardProductRepository) FindUsage(db *gorm.DB, from time.Time, to time.Time) ([]*entity.ConcessionUsage, error) {
+	var usages []*entity.ConcessionUsage
+	err := db.Table("journeys").
+		Select(`card_product.id_product, card_product.code, card_product.name, card_product.concession,
+			COUNT(*) AS journeys,
+			COALESCE(SUM(journeys.regular_fare), 0) AS regular_fare,
+			COALESCE(SUM(journeys.fare_charged), 0) AS fare_charged`).
+		Joins("JOIN card_product ON card_product.id_product = journeys.id_product").
+		Where("journeys.journey_status = ? AND journeys.checkout_time >= ? AND journeys.checkout_time < ?",
+			entity.JourneyStatusCompleted, from, to).
+		Group("card_product.id_product, card_product.code, card_product.name, card_product.concession").
+		Order("card_product.code").
+		Scan(&usages).Error
+	return usages, err
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"test-kerja-mkp/internal/entity"
+	"test-kerja-mkp/internal/model"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CardRepository struct {
+	Repository[entity.Card]
+	Log *logrus.Logger
+}
+
+func NewCardRepository(log *logrus.Logger) *CardRepository {
+	return &CardRepository{
+		Log: log,
+	}
+}
+
+var cardSortFields = map[string]string{
+	"card_number": "card_number",
+	"balance":     "balance",
+	"created_at":  "created_at",
+	"updated_at":  "updated_at",
+}
+
+// FindAll lists the cards matching the request. The balance bounds must have
+// been checked with entity.ParseMoney.
+func (r *CardRepository) FindAll(db *gorm.DB, request *model.SearchCardRequest) ([]*entity.Card, int64, error) {
+	spec := &QuerySpec{
+		Sort:        request.Sort,
+		Order:       request.Order,
+		SortFields:  cardSortFields,
+		DefaultSort: "created_at",
+		TieBreaker:  "card_number",
+		Page:        request.Page,
+		Size:        request.Size,
+	}
+
+	if request.Status != "" {
+		spec.Where(func(tx *gorm.DB) *gorm.DB {
+			return tx.Where("status = ?", request.Status)
+		})
+	}
+	if request.MinBalance != "" {
+		spec.Where(func(tx *gorm.DB) *gorm.DB {
+			return tx.Where("balance >= CAST(? AS DECIMAL(12,2))", request.MinBalance)
+		})
+	}
+	if request.MaxBalance != "" {
+		spec.Where(func(tx *gorm.DB) *gorm.DB {
+			return tx.Where("balance <= CAST(? AS DECIMAL(12,2))", request.MaxBalance)
+		})
+	}
+
+	cards, total, err := FindPage[entity.Card](db, spec)
+	if err != nil {
+		r.Log.Errorf("Failed to find cards: %v", err)
+		return nil, 0, err
+	}
+	return cards, total, nil
+}
+
+func (r *CardRepository) FindByIdForUpdate(db *gorm.DB, card *entity.Card, cardNumber int64) error {
+	return db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("card_number = ?", cardNumber).
+		Take(card).Error
+}
+
+func (r *CardRepository) FindAllByRiderId(db *gorm.DB, riderID int64) ([]*entity.Card, error) {
+	var cards []*entity.Card
+	err := db.Where("id_rider = ?", riderID).
+		Order("card_number").
+		Find(&cards).Error
+	return cards, err
+}
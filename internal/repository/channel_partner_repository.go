@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"test-kerja-mkp/internal/entity"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type ChannelPartnerRepository struct {
+	Repository[entity.ChannelPartner]
+	Log *logrus.Logger
+}
+
+func NewChannelPartnerRepository(log *logrus.Logger) *ChannelPartnerRepository {
+	return &ChannelPartnerRepository{
+		Log: log,
+	}
+}
+
+func (r *ChannelPartnerRepository) FindAll(db *gorm.DB) ([]*entity.ChannelPartner, error) {
+	var partners []*entity.ChannelPartner
+	err := db.Order("created_at desc").
+		Find(&partners).Error
+	return partners, err
+}
+
+func (r *ChannelPartnerRepository) UpdateLastUsed(db *gorm.DB, id string, now time.Time) error {
+	return db.Model(&entity.ChannelPartner{}).
+		Where("id_partner = ?", id).
+		Update("last_used_at", now).Error
+}
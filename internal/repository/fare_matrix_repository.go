@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"test-kerja-mkp/internal/entity"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type FareMatrixRepository struct {
+	Repository[entity.FareMatrix]
+	Log *logrus.Logger
+}
+
+func NewFareMatrixRepository(log *logrus.Logger) *FareMatrixRepository {
+	return &FareMatrixRepository{
+		Log: log,
+	}
+}
+
+// FindMaxFareFrom returns the highest regular fare from the terminal on the
+// date (YYYY-MM-DD), considering only the latest effective fare of every
+// route to a terminal that is not deleted. It is 0 when no fare applies.
+func (r *FareMatrixRepository) FindMaxFareFrom(db *gorm.DB, terminalID int64, date string) (entity.Money, error) {
+	var fare entity.Money
+	err := db.Raw(`SELECT COALESCE(MAX(regular_fare), 0) FROM (
+		SELECT DISTINCT ON (to_terminal) regular_fare FROM fare_matrix
+		WHERE from_terminal = ? AND effective_date <= ? AND (end_date IS NULL OR end_date >= ?)
+			AND EXISTS (SELECT 1 FROM terminal t WHERE t.id_terminal = fare_matrix.to_terminal AND t.deleted_at IS NULL)
+		ORDER BY to_terminal, effective_date DESC
+	) fares`, terminalID, date, date).Scan(&fare).Error
+	return fare, err
+}
+
+// FindFare finds the fare of the route effective on the date (YYYY-MM-DD).
+// Routes to a deleted terminal have no fare.
+func (r *FareMatrixRepository) FindFare(db *gorm.DB, fare *entity.FareMatrix, from int64, to int64, date string) error {
+	return db.Where("from_terminal = ? AND to_terminal = ? AND effective_date <= ? AND (end_date IS NULL OR end_date >= ?)", from, to, date, date).
+		Where("EXISTS (SELECT 1 FROM terminal t WHERE t.id_terminal = fare_matrix.to_terminal AND t.deleted_at IS NULL)").
+		Order("effective_date DESC").
+		Take(fare).Error
+}
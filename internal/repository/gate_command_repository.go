@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"test-kerja-mkp/internal/entity"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type GateCommandRepository struct {
+	Repository[entity.GateCommand]
+	Log *logrus.Logger
+}
+
+func NewGateCommandRepository(log *logrus.Logger) *GateCommandRepository {
+	return &GateCommandRepository{
+		Log: log,
+	}
+}
+
+func (r *GateCommandRepository) FindAllByGateId(db *gorm.DB, gateID int64, limit int) ([]*entity.GateCommand, error) {
+	var commands []*entity.GateCommand
+	err := db.Where("id_gates = ?", gateID).
+		Order("id_command desc").
+		Limit(limit).
+		Find(&commands).Error
+	return commands, err
+}
+
+func (r *GateCommandRepository) FindByIdAndGateIdForUpdate(db *gorm.DB, command *entity.GateCommand, id int64, gateID int64) error {
+	return db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id_command = ? AND id_gates = ?", id, gateID).
+		Take(command).Error
+}
+
+// FindOpenByGateIdForUpdate locks the pending and delivered commands of the
+// gate that have not expired, oldest first.
+func (r *GateCommandRepository) FindOpenByGateIdForUpdate(db *gorm.DB, gateID int64, now time.Time, limit int) ([]*entity.GateCommand, error) {
+	var commands []*entity.GateCommand
+	err := db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id_gates = ? AND status IN ? AND expires_at > ?", gateID,
+			[]string{entity.GateCommandStatusPending, entity.GateCommandStatusDelivered}, now).
+		Order("id_command").
+		Limit(limit).
+		Find(&commands).Error
+	return commands, err
+}
+
+// ExpireByGateId fails the open commands of the gate that expired.
+func (r *GateCommandRepository) ExpireByGateId(db *gorm.DB, gateID int64, now time.Time) error {
+	return db.Model(&entity.GateCommand{}).
+		Where("id_gates = ? AND status IN ? AND expires_at <= ?", gateID,
+			[]string{entity.GateCommandStatusPending, entity.GateCommandStatusDelivered}, now).
+		Updates(map[string]any{
+			"status":       entity.GateCommandStatusFailed,
+			"result":       entity.GateCommandResultExpired,
+			"completed_at": now,
+		}).Error
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"test-kerja-mkp/internal/entity"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type GateCredentialRepository struct {
+	Repository[entity.GateCredential]
+	Log *logrus.Logger
+}
+
+func NewGateCredentialRepository(log *logrus.Logger) *GateCredentialRepository {
+	return &GateCredentialRepository{
+		Log: log,
+	}
+}
+
+// FindByIdWithGate loads the credential with its gate and the gate's terminal.
+// The terminal stays nil when it is soft deleted.
+func (r *GateCredentialRepository) FindByIdWithGate(db *gorm.DB, credential *entity.GateCredential, id string) error {
+	return db.Preload("Gate.Terminal").
+		Where("id_credential = ?", id).
+		Take(credential).Error
+}
+
+func (r *GateCredentialRepository) FindByIdAndGateId(db *gorm.DB, credential *entity.GateCredential, id string, gateID int64) error {
+	return db.Where("id_credential = ? AND id_gates = ?", id, gateID).
+		Take(credential).Error
+}
+
+func (r *GateCredentialRepository) FindAllByGateId(db *gorm.DB, gateID int64) ([]*entity.GateCredential, error) {
+	var credentials []*entity.GateCredential
+	err := db.Where("id_gates = ?", gateID).
+		Order("created_at desc").
+		Find(&credentials).Error
+	return credentials, err
+}
+
+// FindActiveByGateIdForUpdate locks the credentials of the gate that are
+// neither revoked nor expired.
+func (r *GateCredentialRepository) FindActiveByGateIdForUpdate(db *gorm.DB, gateID int64, now time.Time) ([]*entity.GateCredential, error) {
+	var credentials []*entity.GateCredential
+	err := db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id_gates = ? AND revoked_at IS NULL AND (expires_at IS NULL OR expires_at > ?)", gateID, now).
+		Find(&credentials).Error
+	return credentials, err
+}
+
+func (r *GateCredentialRepository) UpdateLastUsed(db *gorm.DB, id string, now time.Time) error {
+	return db.Model(&entity.GateCredential{}).
+		Where("id_credential = ?", id).
+		Update("last_used_at", now).Error
+}
+
+// RevokeAllByGateId revokes every credential of the gate that is not revoked
+// yet.
+func (r *GateCredentialRepository) RevokeAllByGateId(db *gorm.DB, gateID int64, now time.Time) error {
+	return db.Model(&entity.GateCredential{}).
+		Where("id_gates = ? AND revoked_at IS NULL", gateID).
+		Update("revoked_at", now).Error
+}
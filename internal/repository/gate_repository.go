@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"test-kerja-mkp/internal/entity"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type GateRepository struct {
+	Repository[entity.Gate]
+	Log *logrus.Logger
+}
+
+func NewGateRepository(log *logrus.Logger) *GateRepository {
+	return &GateRepository{
+		Log: log,
+	}
+}
+
+// ActiveTerminal limits the query to gates whose terminal is not soft deleted.
+func (r *GateRepository) ActiveTerminal(db *gorm.DB) *gorm.DB {
+	return db.Where("EXISTS (SELECT 1 FROM terminal WHERE terminal.id_terminal = gates.id_terminal AND terminal.deleted_at IS NULL)")
+}
+
+// CountActiveById counts the gate when it is in service and its terminal is
+// not soft deleted.
+func (r *GateRepository) CountActiveById(db *gorm.DB, id int64) (int64, error) {
+	var total int64
+	err := db.Model(&entity.Gate{}).
+		Scopes(r.ActiveTerminal).
+		Where("id_gates = ? AND decommissioned_at IS NULL", id).
+		Count(&total).Error
+	return total, err
+}
+
+func (r *GateRepository) FindAllByTerminalId(db *gorm.DB, terminalID int64, includeDecommissioned bool) ([]*entity.Gate, error) {
+	var gates []*entity.Gate
+	query := db.Where("id_terminal = ?", terminalID)
+	if !includeDecommissioned {
+		query = query.Where("decommissioned_at IS NULL")
+	}
+	err := query.Order("gate_number, id_gates").
+		Find(&gates).Error
+	return gates, err
+}
+
+func (r *GateRepository) FindByIdAndTerminalId(db *gorm.DB, gate *entity.Gate, id int64, terminalID int64) error {
+	return db.Where("id_gates = ? AND id_terminal = ?", id, terminalID).
+		Take(gate).Error
+}
+
+func (r *GateRepository) FindByIdAndTerminalIdForUpdate(db *gorm.DB, gate *entity.Gate, id int64, terminalID int64) error {
+	return db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id_gates = ? AND id_terminal = ?", id, terminalID).
+		Take(gate).Error
+}
+
+// CountByGateNumber counts the gates in service in the terminal that use the
+// gate number, ignoring the gate excludeID.
+func (r *GateRepository) CountByGateNumber(db *gorm.DB, terminalID int64, gateNumber string, excludeID int64) (int64, error) {
+	var total int64
+	err := db.Model(&entity.Gate{}).
+		Where("id_terminal = ? AND gate_number = ? AND decommissioned_at IS NULL AND id_gates <> ?", terminalID, gateNumber, excludeID).
+		Count(&total).Error
+	return total, err
+}
+
+func (r *GateRepository) FindByIdForUpdate(db *gorm.DB, gate *entity.Gate, id int64) error {
+	return db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id_gates = ?", id).
+		Take(gate).Error
+}
+
+// FindSilentForUpdate locks the gates in service that are not offline yet and
+// have not sent a heartbeat since the cutoff. Rows locked by a heartbeat or by
+// another monitor are skipped.
+func (r *GateRepository) FindSilentForUpdate(db *gorm.DB, cutoff time.Time, limit int) ([]*entity.Gate, error) {
+	var gates []*entity.Gate
+	err := db.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("decommissioned_at IS NULL AND status <> ?", entity.GateStatusOffline).
+		Where("last_heartbeat_at IS NULL OR last_heartbeat_at < ?", cutoff).
+		Order("id_gates").
+		Limit(limit).
+		Find(&gates).Error
+	return gates, err
+}
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"test-kerja-mkp/internal/entity"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type GateStatusHistoryRepository struct {
+	Repository[entity.GateStatusHistory]
+	Log *logrus.Logger
+}
+
+func NewGateStatusHistoryRepository(log *logrus.Logger) *GateStatusHistoryRepository {
+	return &GateStatusHistoryRepository{
+		Log: log,
+	}
+}
+
+func (r *GateStatusHistoryRepository) FindAllByGateId(db *gorm.DB, gateID int64, limit int) ([]*entity.GateStatusHistory, error) {
+	var histories []*entity.GateStatusHistory
+	err := db.Where("id_gates = ?", gateID).
+		Order("changed_at desc, id_status_history desc").
+		Limit(limit).
+		Find(&histories).Error
+	return histories, err
+}
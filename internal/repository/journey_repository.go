@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"test-kerja-mkp/internal/entity"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type JourneyRepository struct {
+	Repository[entity.Journey]
+	Log *logrus.Logger
+}
+
+func NewJourneyRepository(log *logrus.Logger) *JourneyRepository {
+	return &JourneyRepository{
+		Log: log,
+	}
+}
+
+func (r *JourneyRepository) CountActiveByCardNumber(db *gorm.DB, cardNumber int64) (int64, error) {
+	var total int64
+	err := db.Model(&entity.Journey{}).
+		Where("card_number = ? AND journey_status = ?", cardNumber, entity.JourneyStatusActive).
+		Count(&total).Error
+	return total, err
+}
+
+func (r *JourneyRepository) FindActiveByCardNumberForUpdate(db *gorm.DB, journey *entity.Journey, cardNumber int64) error {
+	return db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("card_number = ? AND journey_status = ?", cardNumber, entity.JourneyStatusActive).
+		Take(journey).Error
+}
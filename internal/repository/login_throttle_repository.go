@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"test-kerja-mkp/internal/entity"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type LoginThrottleRepository struct {
+	Repository[entity.LoginThrottle]
+	Log *logrus.Logger
+}
+
+func NewLoginThrottleRepository(log *logrus.Logger) *LoginThrottleRepository {
+	return &LoginThrottleRepository{
+		Log: log,
+	}
+}
+
+func (r *LoginThrottleRepository) FindByKeys(db *gorm.DB, keys []string) ([]*entity.LoginThrottle, error) {
+	var throttles []*entity.LoginThrottle
+	err := db.Where("throttle_key IN ?", keys).Find(&throttles).Error
+	return throttles, err
+}
+
+// RecordFailure counts a failed attempt for the key. The counter restarts when
+// the previous failure is older than windowStart or a previous lockout has
+// ended, and the key is locked until lockUntil once threshold is reached.
+func (r *LoginThrottleRepository) RecordFailure(db *gorm.DB, key string, now time.Time, windowStart time.Time, threshold int, lockUntil time.Time) error {
+	return db.Exec(`
+		INSERT INTO login_throttle (throttle_key, failed_count, last_failed_at, locked_until, updated_at)
+		VALUES (@key, 1, @now, CASE WHEN 1 >= @threshold THEN @lock_until::timestamp ELSE NULL END, @now)
+		ON CONFLICT (throttle_key) DO UPDATE SET
+			failed_count = CASE
+				WHEN login_throttle.last_failed_at < @window_start OR login_throttle.locked_until < @now THEN 1
+				ELSE login_throttle.failed_count + 1
+			END,
+			locked_until = CASE
+				WHEN login_throttle.last_failed_at < @window_start OR login_throttle.locked_until < @now THEN
+					CASE WHEN 1 >= @threshold THEN @lock_until::timestamp ELSE NULL END
+				WHEN login_throttle.failed_count + 1 >= @threshold THEN @lock_until::timestamp
+				ELSE login_throttle.locked_until
+			END,
+			last_failed_at = @now,
+			updated_at = @now`,
+		map[string]any{
+			"key":          key,
+			"now":          now,
+			"window_start": windowStart,
+			"threshold":    threshold,
+			"lock_until":   lockUntil,
+		}).Error
+}
+
+func (r *LoginThrottleRepository) DeleteByKey(db *gorm.DB, key string) error {
+	return db.Where("throttle_key = ?", key).Delete(&entity.LoginThrottle{}).Error
+}
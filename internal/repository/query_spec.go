@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+const (
+	SortAsc  = "asc"
+	SortDesc = "desc"
+)
+
+// QuerySpec describes a paged list query: a free-text search over
+// SearchFields, extra filter scopes, and a sort key that is only honoured when
+// it is listed in SortFields. Sort keys are the names exposed to clients and
+// map to the column they order by, so request input never reaches ORDER BY.
+type QuerySpec struct {
+	Search       string
+	SearchFields []string
+	Filters      []func(tx *gorm.DB) *gorm.DB
+
+	Sort        string
+	Order       string
+	SortFields  map[string]string
+	DefaultSort string
+	// TieBreaker is appended to the ordering to keep pages stable when the
+	// sort column has duplicates, usually the primary key.
+	TieBreaker string
+
+	Page int
+	Size int
+}
+
+// Where adds a filter scope to the spec.
+func (s *QuerySpec) Where(filter func(tx *gorm.DB) *gorm.DB) *QuerySpec {
+	s.Filters = append(s.Filters, filter)
+	return s
+}
+
+// Filter applies the search and the filters, for counting and for fetching.
+func (s *QuerySpec) Filter(tx *gorm.DB) *gorm.DB {
+	if search := strings.TrimSpace(s.Search); search != "" && len(s.SearchFields) > 0 {
+		pattern := "%" + escapeLike(search) + "%"
+		conditions := make([]string, 0, len(s.SearchFields))
+		args := make([]any, 0, len(s.SearchFields))
+		for _, field := range s.SearchFields {
+			conditions = append(conditions, field+" ILIKE ?")
+			args = append(args, pattern)
+		}
+		tx = tx.Where("("+strings.Join(conditions, " OR ")+")", args...)
+	}
+	for _, filter := range s.Filters {
+		tx = filter(tx)
+	}
+	return tx
+}
+
+// Paginate applies the ordering, offset and limit.
+func (s *QuerySpec) Paginate(tx *gorm.DB) *gorm.DB {
+	return tx.Order(s.OrderBy()).
+		Offset((s.Page - 1) * s.Size).
+		Limit(s.Size)
+}
+
+// OrderBy resolves the ORDER BY clause. An unknown sort key falls back to
+// DefaultSort and anything but "asc" sorts descending.
+func (s *QuerySpec) OrderBy() string {
+	column, ok := s.SortFields[s.Sort]
+	if !ok {
+		column = s.DefaultSort
+	}
+
+	direction := SortDesc
+	if strings.EqualFold(s.Order, SortAsc) {
+		direction = SortAsc
+	}
+
+	orderBy := column + " " + direction
+	if s.TieBreaker != "" && s.TieBreaker != column {
+		orderBy += ", " + s.TieBreaker + " " + direction
+	}
+	return orderBy
+}
+
+// FindPage counts the rows matching the spec and fetches the requested page.
+func FindPage[T any](db *gorm.DB, spec *QuerySpec) ([]*T, int64, error) {
+	var items []*T
+	var total int64
+
+	if err := db.Model(new(T)).Scopes(spec.Filter).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := db.Scopes(spec.Filter, spec.Paginate).Find(&items).Error; err != nil {
+		return nil, 0, err
+	}
+	return items, total, nil
+}
+
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
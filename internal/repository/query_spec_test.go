@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"testing"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestQuerySpecOrderBy(t *testing.T) {
+	sortFields := map[string]string{"name": "name", "created_at": "created_at"}
+	tests := []struct {
+		name       string
+		sort       string
+		order      string
+		tieBreaker string
+		want       string
+	}{
+		{name: "listed field ascending", sort: "name", order: "asc", want: "name asc"},
+		{name: "listed field descending", sort: "name", order: "desc", want: "name desc"},
+		{name: "direction is case insensitive", sort: "name", order: "ASC", want: "name asc"},
+		{name: "unlisted field", sort: "password", order: "asc", want: "created_at asc"},
+		{name: "injected field", sort: "name; DROP TABLE admin", order: "asc", want: "created_at asc"},
+		{name: "column name instead of key", sort: "NAME", order: "asc", want: "created_at asc"},
+		{name: "empty field", order: "asc", want: "created_at asc"},
+		{name: "unlisted direction", sort: "name", order: "sideways", want: "name desc"},
+		{name: "injected direction", sort: "name", order: "asc, (SELECT 1)", want: "name desc"},
+		{name: "empty direction", sort: "name", want: "name desc"},
+		{name: "tie breaker", sort: "name", order: "asc", tieBreaker: "id", want: "name asc, id asc"},
+		{name: "tie breaker on the sort column", sort: "created_at", order: "asc", tieBreaker: "created_at", want: "created_at asc"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := &QuerySpec{
+				Sort:        tt.sort,
+				Order:       tt.order,
+				SortFields:  sortFields,
+				DefaultSort: "created_at",
+				TieBreaker:  tt.tieBreaker,
+			}
+			if got := spec.OrderBy(); got != tt.want {
+				t.Fatalf("OrderBy() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEscapeLike(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{value: "jakarta", want: "jakarta"},
+		{value: "100%", want: `100\%`},
+		{value: "gate_1", want: `gate\_1`},
+		{value: `c:\path`, want: `c:\\path`},
+		{value: `\%`, want: `\\\%`},
+		{value: `%_\`, want: `\%\_\\`},
+	}
+	for _, tt := range tests {
+		if got := escapeLike(tt.value); got != tt.want {
+			t.Errorf("escapeLike(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestQuerySpecFilterEscapesSearch(t *testing.T) {
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	if err != nil {
+		t.Fatalf("open dry run db: %v", err)
+	}
+
+	spec := &QuerySpec{Search: " 50%_off ", SearchFields: []string{"code", "name"}}
+	stmt := db.Table("terminal").Scopes(spec.Filter).Find(&[]map[string]any{}).Statement
+
+	want := `SELECT * FROM "terminal" WHERE (code ILIKE $1 OR name ILIKE $2)`
+	if sql := stmt.SQL.String(); sql != want {
+		t.Fatalf("SQL = %q, want %q", sql, want)
+	}
+	for _, arg := range stmt.Vars {
+		if arg != `%50\%\_off%` {
+			t.Fatalf("search argument = %q, want %q", arg, `%50\%\_off%`)
+		}
+	}
+	if len(stmt.Vars) != 2 {
+		t.Fatalf("got %d arguments, want 2", len(stmt.Vars))
+	}
+}
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"test-kerja-mkp/internal/entity"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RevokedTokenRepository struct {
+	Repository[entity.RevokedToken]
+	Log *logrus.Logger
+}
+
+func NewRevokedTokenRepository(log *logrus.Logger) *RevokedTokenRepository {
+	return &RevokedTokenRepository{
+		Log: log,
+	}
+}
+
+// Revoke adds the token to the revocation list, ignoring tokens that are
+// already revoked.
+func (r *RevokedTokenRepository) Revoke(db *gorm.DB, token *entity.RevokedToken) error {
+	return db.Clauses(clause.OnConflict{DoNothing: true}).Create(token).Error
+}
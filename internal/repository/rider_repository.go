@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"test-kerja-mkp/internal/entity"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RiderRepository struct {
+	Repository[entity.Rider]
+	Log *logrus.Logger
+}
+
+func NewRiderRepository(log *logrus.Logger) *RiderRepository {
+	return &RiderRepository{
+		Log: log,
+	}
+}
+
+func (r *RiderRepository) FindByIdForShare(db *gorm.DB, rider *entity.Rider, id int64) error {
+	return db.Clauses(clause.Locking{Strength: "SHARE"}).
+		Where("id_rider = ?", id).
+		Take(rider).Error
+}
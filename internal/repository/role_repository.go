@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"test-kerja-mkp/internal/entity"
+
+	"github.com/sirupsen/logrus"
+)
+
+type RoleRepository struct {
+	Repository[entity.Role]
+	Log *logrus.Logger
+}
+
+func NewRoleRepository(log *logrus.Logger) *RoleRepository {
+	return &RoleRepository{
+		Log: log,
+	}
+}
@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"test-kerja-mkp/internal/entity"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type TerminalClosureRepository struct {
+	Repository[entity.TerminalClosure]
+	Log *logrus.Logger
+}
+
+func NewTerminalClosureRepository(log *logrus.Logger) *TerminalClosureRepository {
+	return &TerminalClosureRepository{
+		Log: log,
+	}
+}
+
+func (r *TerminalClosureRepository) FindAllByTerminalId(db *gorm.DB, terminalID int64) ([]*entity.TerminalClosure, error) {
+	var closures []*entity.TerminalClosure
+	err := db.Where("id_terminal = ?", terminalID).
+		Order("start_date desc, id_closure desc").
+		Find(&closures).Error
+	return closures, err
+}
+
+func (r *TerminalClosureRepository) FindByIdAndTerminalId(db *gorm.DB, closure *entity.TerminalClosure, id int64, terminalID int64) error {
+	return db.Where("id_closure = ? AND id_terminal = ?", id, terminalID).
+		Take(closure).Error
+}
+
+// FindCovering finds a closure of the terminal that includes the local date,
+// formatted as 2006-01-02.
+func (r *TerminalClosureRepository) FindCovering(db *gorm.DB, closure *entity.TerminalClosure, terminalID int64, date string) error {
+	return db.Where("id_terminal = ? AND start_date <= ? AND end_date >= ?", terminalID, date, date).
+		Order("start_date, id_closure").
+		Take(closure).Error
+}
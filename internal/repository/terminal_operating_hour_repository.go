@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"test-kerja-mkp/internal/entity"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type TerminalOperatingHourRepository struct {
+	Repository[entity.TerminalOperatingHour]
+	Log *logrus.Logger
+}
+
+func NewTerminalOperatingHourRepository(log *logrus.Logger) *TerminalOperatingHourRepository {
+	return &TerminalOperatingHourRepository{
+		Log: log,
+	}
+}
+
+func (r *TerminalOperatingHourRepository) FindAllByTerminalId(db *gorm.DB, terminalID int64) ([]*entity.TerminalOperatingHour, error) {
+	var hours []*entity.TerminalOperatingHour
+	err := db.Where("id_terminal = ?", terminalID).
+		Order("day_of_week, open_minute").
+		Find(&hours).Error
+	return hours, err
+}
+
+// ReplaceAll swaps the weekly schedule of the terminal for hours.
+func (r *TerminalOperatingHourRepository) ReplaceAll(db *gorm.DB, terminalID int64, hours []*entity.TerminalOperatingHour) error {
+	if err := db.Where("id_terminal = ?", terminalID).Delete(&entity.TerminalOperatingHour{}).Error; err != nil {
+		return err
+	}
+	if len(hours) == 0 {
+		return nil
+	}
+	return db.Create(&hours).Error
+}
@@ -1,7 +1,9 @@
 package repository
 
 import (
+	"math"
 	"test-kerja-mkp/internal/entity"
+	"test-kerja-mkp/internal/model"
 
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
@@ -20,26 +22,127 @@ func NewTerminalRepository(log *logrus.Logger, db *gorm.DB) *TerminalRepository
 	}
 }
 
-func (r *TerminalRepository) FindAll(page int, size int) ([]*entity.Terminal, int64, error) {
-	var terminals []*entity.Terminal
-	var total int64
+var terminalSortFields = map[string]string{
+	"name":       "name",
+	"location":   "location",
+	"created_at": "created_at",
+	"updated_at": "updated_at",
+}
 
-	if err := r.DB.Model(&entity.Terminal{}).Where("deleted_at IS NULL").Count(&total).Error; err != nil {
-		r.Log.Errorf("Failed to count terminals: %v", err)
-		return nil, 0, err
+func (r *TerminalRepository) FindAll(db *gorm.DB, request *model.SearchTerminalRequest) ([]*entity.Terminal, int64, error) {
+	spec := &QuerySpec{
+		Search:       request.Search,
+		SearchFields: []string{"name", "location"},
+		Sort:         request.Sort,
+		Order:        request.Order,
+		SortFields:   terminalSortFields,
+		DefaultSort:  "created_at",
+		TieBreaker:   "id_terminal",
+		Page:         request.Page,
+		Size:         request.Size,
 	}
 
-	offset := (page - 1) * size
-	err := r.DB.Preload("Attachment").
-		Where("deleted_at IS NULL").
-		Order("created_at desc").
-		Offset(offset).
-		Limit(size).
-		Find(&terminals).Error
+	switch request.Deleted {
+	case "include":
+		spec.Where(func(tx *gorm.DB) *gorm.DB {
+			return tx.Unscoped()
+		})
+	case "only":
+		spec.Where(func(tx *gorm.DB) *gorm.DB {
+			return tx.Unscoped().Where("deleted_at IS NOT NULL")
+		})
+	}
+
+	if request.HasOnlineGates != nil {
+		condition := "EXISTS (SELECT 1 FROM gates WHERE gates.id_terminal = terminal.id_terminal AND gates.status = 'online')"
+		if !*request.HasOnlineGates {
+			condition = "NOT " + condition
+		}
+		spec.Where(func(tx *gorm.DB) *gorm.DB {
+			return tx.Where(condition)
+		})
+	}
 
+	terminals, total, err := FindPage[entity.Terminal](db, spec)
 	if err != nil {
 		r.Log.Errorf("Failed to find terminals: %v", err)
 		return nil, 0, err
 	}
 	return terminals, total, nil
 }
+
+func (r *TerminalRepository) CreateAll(db *gorm.DB, terminals []*entity.Terminal) error {
+	return db.CreateInBatches(terminals, 100).Error
+}
+
+// FindAllInBatches walks the terminals in primary key order, handing each
+// batch to fn.
+func (r *TerminalRepository) FindAllInBatches(db *gorm.DB, size int, fn func(terminals []*entity.Terminal) error) error {
+	var terminals []*entity.Terminal
+	return db.FindInBatches(&terminals, size, func(tx *gorm.DB, batch int) error {
+		return fn(terminals)
+	}).Error
+}
+
+// FindByIdWithDeleted finds the terminal whether it is soft deleted or not.
+func (r *TerminalRepository) FindByIdWithDeleted(db *gorm.DB, terminal *entity.Terminal, id int64) error {
+	return db.Unscoped().
+		Where("id_terminal = ?", id).
+		Take(terminal).Error
+}
+
+func (r *TerminalRepository) Restore(db *gorm.DB, terminal *entity.Terminal) error {
+	terminal.DeletedAt = gorm.DeletedAt{}
+	return db.Unscoped().Model(terminal).
+		Update("deleted_at", nil).Error
+}
+
+// CountActiveReferences counts what still needs the terminal: gates in
+// service, fares that are effective now or later and journeys that are not
+// finished. Decommissioned gates, expired fares and finished journeys keep
+// pointing at a soft deleted terminal and are ignored by the lookups instead.
+func (r *TerminalRepository) CountActiveReferences(db *gorm.DB, id int64) (int64, error) {
+	var total int64
+	err := db.Raw(`
+		SELECT
+			(SELECT COUNT(*) FROM gates WHERE id_terminal = @id AND decommissioned_at IS NULL) +
+			(SELECT COUNT(*) FROM fare_matrix WHERE (from_terminal = @id OR to_terminal = @id)
+				AND (end_date IS NULL OR end_date >= CURRENT_DATE)) +
+			(SELECT COUNT(*) FROM journeys WHERE origin_terminal = @id AND journey_status = 'active')`,
+		map[string]any{"id": id}).
+		Scan(&total).Error
+	return total, err
+}
+
+// earthRadiusKm is the mean earth radius used by the haversine distance.
+const earthRadiusKm = 6371.0
+
+// FindNearby returns the terminals within radiusKm of the point ordered by
+// haversine distance. A bounding box on the coordinates narrows the rows
+// before the distance is computed.
+func (r *TerminalRepository) FindNearby(db *gorm.DB, lat float64, lng float64, radiusKm float64, limit int) ([]*entity.TerminalDistance, error) {
+	distance := `(? * 2 * ASIN(LEAST(1, SQRT(
+		POWER(SIN(RADIANS(latitude - ?) / 2), 2) +
+		COS(RADIANS(?)) * COS(RADIANS(latitude)) * POWER(SIN(RADIANS(longitude - ?) / 2), 2)))))`
+	args := []any{earthRadiusKm, lat, lat, lng}
+
+	query := db.Model(&entity.Terminal{}).
+		Select("terminal.*, "+distance+" AS distance", args...).
+		Where("latitude IS NOT NULL AND longitude IS NOT NULL")
+
+	latDelta := radiusKm / 111.045
+	query = query.Where("latitude BETWEEN ? AND ?", lat-latDelta, lat+latDelta)
+	if cos := math.Cos(lat * math.Pi / 180); cos > 0.01 {
+		lngDelta := radiusKm / (111.045 * cos)
+		if lng-lngDelta >= -180 && lng+lngDelta <= 180 {
+			query = query.Where("longitude BETWEEN ? AND ?", lng-lngDelta, lng+lngDelta)
+		}
+	}
+
+	var terminals []*entity.TerminalDistance
+	err := query.Where(distance+" <= ?", append(args, radiusKm)...).
+		Order("distance, id_terminal").
+		Limit(limit).
+		Find(&terminals).Error
+	return terminals, err
+}
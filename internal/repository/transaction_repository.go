@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"test-kerja-mkp/internal/entity"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type TransactionRepository struct {
+	Repository[entity.Transaction]
+	Log *logrus.Logger
+}
+
+func NewTransactionRepository(log *logrus.Logger) *TransactionRepository {
+	return &TransactionRepository{
+		Log: log,
+	}
+}
+
+// LockReference takes a transaction scoped advisory lock on a reference
+// number, so concurrent requests with the same idempotency key run one after
+// the other.
+func (r *TransactionRepository) LockReference(db *gorm.DB, scope string, referenceNumber string) error {
+	return db.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", scope+":"+referenceNumber).Error
+}
+
+// FindTopUpByReference finds the top-up recorded under the reference number
+// by the partner, or by an admin when partnerID is nil.
+func (r *TransactionRepository) FindTopUpByReference(db *gorm.DB, transaction *entity.Transaction, partnerID *string, referenceNumber string) error {
+	query := db.Where("transaction_type = ? AND reference_number = ?", entity.TransactionTypeTopUp, referenceNumber)
+	if partnerID == nil {
+		query = query.Where("id_partner IS NULL")
+	} else {
+		query = query.Where("id_partner = ?", *partnerID)
+	}
+	return query.Take(transaction).Error
+}
@@ -0,0 +1,355 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"math"
+	"test-kerja-mkp/internal/constants"
+	"test-kerja-mkp/internal/entity"
+	"test-kerja-mkp/internal/helper"
+	"test-kerja-mkp/internal/model"
+	"test-kerja-mkp/internal/model/converter"
+	"test-kerja-mkp/internal/repository"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	sessionRevokedReasonDisabled   = "account_disabled"
+	sessionRevokedReasonRoleChange = "role_change"
+)
+
+type AdminUseCase struct {
+	DB              *gorm.DB
+	Log             *logrus.Logger
+	Validate        *validator.Validate
+	AuthRepository  *repository.AuthRepository
+	RoleRepository  *repository.RoleRepository
+	AuthUseCase     *AuthUseCase
+	PasswordUseCase *PasswordUseCase
+	// LoginThrottleUseCase is used to lift login lockouts.
+	LoginThrottleUseCase *LoginThrottleUseCase
+}
+
+func NewAdminUseCase(db *gorm.DB, log *logrus.Logger, validate *validator.Validate, authRepository *repository.AuthRepository,
+	roleRepository *repository.RoleRepository, authUseCase *AuthUseCase, passwordUseCase *PasswordUseCase,
+	loginThrottleUseCase *LoginThrottleUseCase) *AdminUseCase {
+	return &AdminUseCase{
+		DB:                   db,
+		Log:                  log,
+		Validate:             validate,
+		AuthRepository:       authRepository,
+		RoleRepository:       roleRepository,
+		AuthUseCase:          authUseCase,
+		PasswordUseCase:      passwordUseCase,
+		LoginThrottleUseCase: loginThrottleUseCase,
+	}
+}
+
+func (c *AdminUseCase) Create(ctx context.Context, request *model.CreateAdminRequest) (*model.AdminResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	audit := helper.Audit(ctx)
+	audit.Action = constants.AuditActionAdminCreate
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	if err := c.ensureUniqueUsername(tx, request.Username, 0); err != nil {
+		return nil, err
+	}
+	if err := c.ensureRoleExists(tx, request.RoleID); err != nil {
+		return nil, err
+	}
+
+	password, err := c.PasswordUseCase.hashNewPassword(request.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	admin := &entity.Admin{
+		Name:     request.Name,
+		Username: request.Username,
+		Password: password,
+		RoleID:   request.RoleID,
+		IsActive: true,
+	}
+	if err := c.AuthRepository.Create(tx, admin); err != nil {
+		c.Log.Warnf("Failed create admin : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := c.PasswordUseCase.recordHistory(tx, admin.ID, password); err != nil {
+		return nil, err
+	}
+
+	if err := c.AuthRepository.FindByIdWithRole(tx, admin, admin.ID); err != nil {
+		c.Log.Warnf("Failed find admin : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed commit transaction : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	response := converter.AdminToResponse(admin)
+	audit.SetEntity(constants.AuditEntityAdmin, admin.ID)
+	audit.SetChange(nil, response)
+
+	return response, nil
+}
+
+func (c *AdminUseCase) FindAll(ctx context.Context, page int, size int) ([]*model.AdminResponse, *model.PageMetadata, error) {
+	if page < 1 {
+		page = 1
+	}
+	if size < 1 {
+		size = 10
+	}
+
+	admins, total, err := c.AuthRepository.FindAll(c.DB.WithContext(ctx), page, size)
+	if err != nil {
+		return nil, nil, fiber.ErrInternalServerError
+	}
+
+	responses := make([]*model.AdminResponse, 0, len(admins))
+	for _, admin := range admins {
+		responses = append(responses, converter.AdminToResponse(admin))
+	}
+
+	return responses, &model.PageMetadata{
+		Page:      page,
+		Size:      size,
+		TotalItem: total,
+		TotalPage: int64(math.Ceil(float64(total) / float64(size))),
+	}, nil
+}
+
+func (c *AdminUseCase) FindById(ctx context.Context, id int64) (*model.AdminResponse, error) {
+	admin := new(entity.Admin)
+	if err := c.findAdmin(c.DB.WithContext(ctx), admin, id); err != nil {
+		return nil, err
+	}
+
+	return converter.AdminToResponse(admin), nil
+}
+
+func (c *AdminUseCase) Update(ctx context.Context, request *model.UpdateAdminRequest) (*model.AdminResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	audit := helper.Audit(ctx)
+	audit.Action = constants.AuditActionAdminUpdate
+	audit.SetEntity(constants.AuditEntityAdmin, request.ID)
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	admin := new(entity.Admin)
+	if err := c.findAdminForUpdate(tx, admin, request.ID); err != nil {
+		return nil, err
+	}
+	before := converter.AdminToResponse(admin)
+
+	if err := c.ensureUniqueUsername(tx, request.Username, admin.ID); err != nil {
+		return nil, err
+	}
+	if err := c.ensureRoleExists(tx, request.RoleID); err != nil {
+		return nil, err
+	}
+
+	// Issued access tokens carry the permissions of the old role, so a role
+	// change logs the admin out everywhere.
+	roleChanged := admin.RoleID != request.RoleID
+	if err := tx.Model(admin).Updates(map[string]any{
+		"name":     request.Name,
+		"username": request.Username,
+		"id_role":  request.RoleID,
+	}).Error; err != nil {
+		c.Log.Warnf("Failed update admin : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	var sessionIDs []string
+	if roleChanged {
+		revoked, err := c.AuthUseCase.revokeAllSessions(tx, admin.ID, sessionRevokedReasonRoleChange)
+		if err != nil {
+			return nil, err
+		}
+		sessionIDs = revoked
+	}
+
+	if err := c.AuthRepository.FindByIdWithRole(tx, admin, admin.ID); err != nil {
+		c.Log.Warnf("Failed find admin : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed commit transaction : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	c.AuthUseCase.markSessionsRevoked(sessionIDs)
+
+	response := converter.AdminToResponse(admin)
+	audit.SetChange(before, response)
+
+	return response, nil
+}
+
+// UpdateStatus enables or disables an admin account. Disabling an account
+// revokes all of its sessions so it is logged out immediately.
+func (c *AdminUseCase) UpdateStatus(ctx context.Context, auth *model.AuthAdmin, request *model.UpdateAdminStatusRequest) (*model.AdminResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	audit := helper.Audit(ctx)
+	audit.Action = constants.AuditActionAdminUpdateStatus
+	audit.SetEntity(constants.AuditEntityAdmin, request.ID)
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	if request.ID == auth.ID && !*request.IsActive {
+		return nil, fiber.NewError(fiber.StatusUnprocessableEntity, constants.CannotDisableSelfMessage)
+	}
+
+	admin := new(entity.Admin)
+	if err := c.findAdminForUpdate(tx, admin, request.ID); err != nil {
+		return nil, err
+	}
+	before := converter.AdminToResponse(admin)
+
+	admin.IsActive = *request.IsActive
+	if err := tx.Model(admin).Update("is_active", admin.IsActive).Error; err != nil {
+		c.Log.Warnf("Failed update admin status : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	var sessionIDs []string
+	if !admin.IsActive {
+		revoked, err := c.AuthUseCase.revokeAllSessions(tx, admin.ID, sessionRevokedReasonDisabled)
+		if err != nil {
+			return nil, err
+		}
+		sessionIDs = revoked
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed commit transaction : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	c.AuthUseCase.markSessionsRevoked(sessionIDs)
+
+	response := converter.AdminToResponse(admin)
+	audit.SetChange(before, response)
+
+	return response, nil
+}
+
+func (c *AdminUseCase) Delete(ctx context.Context, auth *model.AuthAdmin, id int64) error {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	audit := helper.Audit(ctx)
+	audit.Action = constants.AuditActionAdminDelete
+	audit.SetEntity(constants.AuditEntityAdmin, id)
+
+	if id == auth.ID {
+		return fiber.NewError(fiber.StatusUnprocessableEntity, constants.CannotDeleteSelfMessage)
+	}
+
+	admin := new(entity.Admin)
+	if err := c.findAdminForUpdate(tx, admin, id); err != nil {
+		return err
+	}
+	audit.SetChange(converter.AdminToResponse(admin), nil)
+
+	sessionIDs, err := c.AuthUseCase.revokeAllSessions(tx, admin.ID, sessionRevokedReasonDisabled)
+	if err != nil {
+		return err
+	}
+
+	// The admin stays referenced by closures, hotlist entries, gate commands
+	// and partners it created, so it is disabled and soft deleted instead.
+	admin.IsActive = false
+	if err := tx.Model(admin).Update("is_active", false).Error; err != nil {
+		c.Log.Warnf("Failed update admin status : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	if err := c.AuthRepository.Delete(tx, admin); err != nil {
+		c.Log.Warnf("Failed delete admin : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed commit transaction : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+	c.AuthUseCase.markSessionsRevoked(sessionIDs)
+
+	return nil
+}
+
+// Unlock lifts a login lockout caused by too many failed attempts.
+func (c *AdminUseCase) Unlock(ctx context.Context, id int64) error {
+	audit := helper.Audit(ctx)
+	audit.Action = constants.AuditActionAdminUnlock
+	audit.SetEntity(constants.AuditEntityAdmin, id)
+
+	return c.LoginThrottleUseCase.Unlock(ctx, id)
+}
+
+func (c *AdminUseCase) findAdmin(db *gorm.DB, admin *entity.Admin, id int64) error {
+	if err := c.AuthRepository.FindByIdWithRole(db, admin, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.Log.Warnf("Admin %d not found", id)
+			return fiber.ErrNotFound
+		}
+		c.Log.Warnf("Failed find admin : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+	return nil
+}
+
+// findAdminForUpdate finds the admin and locks its row, so a password, 2FA or
+// status change running at the same time waits instead of being overwritten.
+func (c *AdminUseCase) findAdminForUpdate(tx *gorm.DB, admin *entity.Admin, id int64) error {
+	return c.findAdmin(tx.Clauses(clause.Locking{Strength: "UPDATE", Table: clause.Table{Name: clause.CurrentTable}}), admin, id)
+}
+
+func (c *AdminUseCase) ensureUniqueUsername(db *gorm.DB, username string, excludeID int64) error {
+	total, err := c.AuthRepository.CountByUsername(db, username, excludeID)
+	if err != nil {
+		c.Log.Warnf("Failed count admin by username : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+	if total > 0 {
+		return fiber.NewError(fiber.StatusConflict, constants.UsernameAlreadyExistsMessage)
+	}
+	return nil
+}
+
+func (c *AdminUseCase) ensureRoleExists(db *gorm.DB, roleID int64) error {
+	total, err := c.RoleRepository.CountById(db, "id_role", roleID)
+	if err != nil {
+		c.Log.Warnf("Failed count role : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+	if total == 0 {
+		return fiber.NewError(fiber.StatusUnprocessableEntity, constants.RoleNotFoundMessage)
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"context"
+	"math"
+	"test-kerja-mkp/internal/entity"
+	"test-kerja-mkp/internal/helper"
+	"test-kerja-mkp/internal/model"
+	"test-kerja-mkp/internal/model/converter"
+	"test-kerja-mkp/internal/repository"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type AuditUseCase struct {
+	DB                 *gorm.DB
+	Log                *logrus.Logger
+	Validate           *validator.Validate
+	AuditLogRepository *repository.AuditLogRepository
+}
+
+func NewAuditUseCase(db *gorm.DB, log *logrus.Logger, validate *validator.Validate, auditLogRepository *repository.AuditLogRepository) *AuditUseCase {
+	return &AuditUseCase{
+		DB:                 db,
+		Log:                log,
+		Validate:           validate,
+		AuditLogRepository: auditLogRepository,
+	}
+}
+
+// Record persists the audit entry of a finished request. It runs outside of
+// the request transaction so failed and rolled back actions are recorded too.
+// Errors are logged only; auditing never fails the request itself.
+func (c *AuditUseCase) Record(ctx context.Context, entry *model.AuditEntry) {
+	before, after, err := helper.AuditDiff(entry.Before, entry.After)
+	if err != nil {
+		c.Log.Warnf("Failed marshal audit change : %+v", err)
+	}
+
+	log := &entity.AuditLog{
+		ActorID:       entry.ActorID,
+		ActorUsername: entry.ActorUsername,
+		Action:        entry.Action,
+		EntityType:    entry.EntityType,
+		EntityID:      entry.EntityID,
+		Before:        before,
+		After:         after,
+		Success:       entry.StatusCode < fiber.StatusBadRequest,
+		StatusCode:    entry.StatusCode,
+		Method:        entry.Method,
+		Path:          entry.Path,
+		IPAddress:     entry.IPAddress,
+		UserAgent:     entry.UserAgent,
+	}
+	if err := c.AuditLogRepository.Create(c.DB.WithContext(ctx), log); err != nil {
+		c.Log.Errorf("Failed create audit log %s : %+v", entry.Action, err)
+	}
+}
+
+func (c *AuditUseCase) Search(ctx context.Context, request *model.SearchAuditLogRequest) ([]*model.AuditLogResponse, *model.PageMetadata, error) {
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %v", err)
+		return nil, nil, fiber.ErrBadRequest
+	}
+
+	logs, total, err := c.AuditLogRepository.Search(c.DB.WithContext(ctx), request)
+	if err != nil {
+		return nil, nil, fiber.ErrInternalServerError
+	}
+
+	responses := make([]*model.AuditLogResponse, 0, len(logs))
+	for _, log := range logs {
+		responses = append(responses, converter.AuditLogToResponse(log))
+	}
+
+	return responses, &model.PageMetadata{
+		Page:      request.Page,
+		Size:      request.Size,
+		TotalItem: total,
+		TotalPage: int64(math.Ceil(float64(total) / float64(request.Size))),
+	}, nil
+}
@@ -2,10 +2,16 @@ package usecase
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"test-kerja-mkp/internal/constants"
 	"test-kerja-mkp/internal/entity"
+	"test-kerja-mkp/internal/helper"
 	"test-kerja-mkp/internal/model"
+	"test-kerja-mkp/internal/model/converter"
 	"test-kerja-mkp/internal/repository"
-	"strings"
 	"time"
 
 	"github.com/go-playground/validator/v10"
@@ -17,21 +23,63 @@ import (
 	"gorm.io/gorm"
 )
 
+const (
+	sessionRevokedReasonReuse     = "refresh_token_reuse"
+	sessionRevokedReasonLogout    = "logout"
+	sessionRevokedReasonLogoutAll = "logout_all"
+
+	// dummyPasswordHash is compared against when the username does not exist,
+	// so a failed login takes the same time whether or not the admin exists.
+	dummyPasswordHash = "$2a$10$92IXUNpkjO0rOQ5byMi.Ye4oKoEa3Ro9llC/.og/at2.uheWG/igi"
+
+	challengeTypeVerify = "2fa"
+	challengeTypeEnroll = "2fa_enroll"
+	challengeTTL        = 5 * time.Minute
+)
+
 type AuthUseCase struct {
-	DB                            *gorm.DB
-	Log                           *logrus.Logger
-	Validate                      *validator.Validate
-	AuthRepository                 *repository.AuthRepository
-	JwtSecret                     []byte
+	DB                          *gorm.DB
+	Log                         *logrus.Logger
+	Validate                    *validator.Validate
+	AuthRepository              *repository.AuthRepository
+	AdminSessionRepository      *repository.AdminSessionRepository
+	AdminRefreshTokenRepository *repository.AdminRefreshTokenRepository
+	RevokedTokenRepository      *repository.RevokedTokenRepository
+	LoginThrottleUseCase        *LoginThrottleUseCase
+	// KeyRing signs access and challenge tokens with the active key and
+	// verifies tokens signed with any key that is not retired.
+	KeyRing            *helper.KeyRing
+	AccessTokenTTL     time.Duration
+	RefreshTokenTTL    time.Duration
+	RevocationCacheTTL time.Duration
+	// SessionMaxLifetime caps how long a session can be kept alive by
+	// refreshing, counted from the login that created it.
+	SessionMaxLifetime time.Duration
+	// VerifyCache remembers the outcome of Verify per token ("jti:<id>") and
+	// revoked sessions ("sid:<id>"). A nil value means revoked.
+	VerifyCache *helper.TTLCache[string, *model.AuthAdmin]
 }
 
-func NewAuthUseCase(db *gorm.DB, logger *logrus.Logger, validate *validator.Validate, AuthRepository *repository.AuthRepository, jwtSecret []byte) *AuthUseCase {
+func NewAuthUseCase(db *gorm.DB, logger *logrus.Logger, validate *validator.Validate, AuthRepository *repository.AuthRepository,
+	adminSessionRepository *repository.AdminSessionRepository, adminRefreshTokenRepository *repository.AdminRefreshTokenRepository,
+	revokedTokenRepository *repository.RevokedTokenRepository, loginThrottleUseCase *LoginThrottleUseCase,
+	keyRing *helper.KeyRing, accessTokenTTL time.Duration, refreshTokenTTL time.Duration, revocationCacheTTL time.Duration,
+	sessionMaxLifetime time.Duration) *AuthUseCase {
 	return &AuthUseCase{
-		DB:                            db,
-		Log:                           logger,
-		Validate:                      validate,
-		AuthRepository:           AuthRepository,
-		JwtSecret:                     jwtSecret,
+		DB:                          db,
+		Log:                         logger,
+		Validate:                    validate,
+		AuthRepository:              AuthRepository,
+		AdminSessionRepository:      adminSessionRepository,
+		AdminRefreshTokenRepository: adminRefreshTokenRepository,
+		RevokedTokenRepository:      revokedTokenRepository,
+		LoginThrottleUseCase:        loginThrottleUseCase,
+		KeyRing:                     keyRing,
+		AccessTokenTTL:              accessTokenTTL,
+		RefreshTokenTTL:             refreshTokenTTL,
+		RevocationCacheTTL:          revocationCacheTTL,
+		SessionMaxLifetime:          sessionMaxLifetime,
+		VerifyCache:                 helper.NewTTLCache[string, *model.AuthAdmin](time.Minute),
 	}
 }
 
@@ -39,57 +87,172 @@ func (c *AuthUseCase) LoginAdmin(ctx context.Context, request *model.LoginAdminR
 	tx := c.DB.WithContext(ctx).Begin()
 	defer tx.Rollback()
 
+	audit := helper.Audit(ctx)
+	audit.Action = constants.AuditActionLogin
+	audit.ActorUsername = request.Username
+
 	if err := c.Validate.Struct(request); err != nil {
 		c.Log.Warnf("Invalid request body: %v", err)
 		return nil, fiber.ErrBadRequest
 	}
 
+	if err := c.LoginThrottleUseCase.Check(ctx, request.Username, request.IPAddress); err != nil {
+		return nil, err
+	}
+
 	admin := new(entity.Admin)
 	if err := c.AuthRepository.FindByUsername(tx, admin, request.Username); err != nil {
 		c.Log.Warnf("Failed find user by username: %v", err)
+		_ = bcrypt.CompareHashAndPassword([]byte(dummyPasswordHash), []byte(request.Password))
+		c.LoginThrottleUseCase.RecordFailure(ctx, request.Username, request.IPAddress)
 		return nil, &fiber.Error{
-			Code:    fiber.StatusUnauthorized,
+			Code: fiber.StatusUnauthorized,
 		}
 	}
+	audit.SetEntity(constants.AuditEntityAdmin, admin.ID)
 
 	if err := bcrypt.CompareHashAndPassword([]byte(admin.Password), []byte(request.Password)); err != nil {
 		c.Log.Warnf("Failed to compare user password with bcrypt hash: %v", err)
+		c.LoginThrottleUseCase.RecordFailure(ctx, request.Username, request.IPAddress)
 		return nil, &fiber.Error{
-			Code:    fiber.StatusUnauthorized,
+			Code: fiber.StatusUnauthorized,
 		}
 	}
 
-	accessToken := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"uid":  admin.ID,
-		"name": admin.Name,
-		"type": "access",
-		"exp":  time.Now().Add(time.Minute * 10).Unix(),
-	})
+	if !admin.IsActive {
+		c.Log.Warnf("Admin %d is disabled", admin.ID)
+		c.LoginThrottleUseCase.RecordFailure(ctx, request.Username, request.IPAddress)
+		return nil, &fiber.Error{
+			Code: fiber.StatusUnauthorized,
+		}
+	}
 
-	refreshToken := uuid.New().String()
+	if admin.TOTPEnabled || (admin.Role != nil && admin.Role.Require2FA) {
+		challenge, err := c.issueChallenge(admin)
+		if err != nil {
+			return nil, err
+		}
+		return &model.LoginAdminResponse{TwoFactor: challenge}, nil
+	}
 
-	accessTokenStr, err := accessToken.SignedString(c.JwtSecret)
+	token, err := c.startSession(tx, admin, request.IPAddress, request.UserAgent)
 	if err != nil {
 		return nil, err
 	}
 
-	expiresAt := time.Now().Add(time.Hour * 24 * 7)
-
 	if err := tx.Commit().Error; err != nil {
 		c.Log.Warnf("Failed commit transaction : %+v", err)
 		return nil, fiber.ErrInternalServerError
 	}
 
+	c.LoginThrottleUseCase.RecordSuccess(ctx, request.Username)
+	audit.SetActor(admin.ID, admin.Username)
+
 	return &model.LoginAdminResponse{
-		Token: &model.TokenResponse{
-			AccessToken:  accessTokenStr,
-			RefreshToken: refreshToken,
-			ExpiresIn:    600,
-		},
+		Token: token,
 		Admin: converter.AdminToResponse(admin),
 	}, nil
 }
 
+// Refresh exchanges a refresh token for a new access token and a new refresh
+// token. Every refresh token can be used once; presenting an already used token
+// is treated as theft and revokes the whole session.
+func (c *AuthUseCase) Refresh(ctx context.Context, request *model.RefreshTokenRequest) (*model.TokenResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	audit := helper.Audit(ctx)
+	audit.Action = constants.AuditActionRefresh
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	refreshToken := new(entity.AdminRefreshToken)
+	if err := c.AdminRefreshTokenRepository.FindByTokenHashForUpdate(tx, refreshToken, hashToken(request.RefreshToken)); err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			c.Log.Warnf("Failed find refresh token : %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+		c.Log.Warnf("Refresh token not found")
+		return nil, fiber.ErrUnauthorized
+	}
+
+	session := new(entity.AdminSession)
+	if err := c.AdminSessionRepository.FindByIdForUpdate(tx, session, refreshToken.SessionID); err != nil {
+		c.Log.Warnf("Failed find admin session : %+v", err)
+		return nil, fiber.ErrUnauthorized
+	}
+	audit.SetEntity(constants.AuditEntityAdmin, session.AdminID)
+
+	now := time.Now()
+	if refreshToken.UsedAt != nil {
+		c.Log.Warnf("Refresh token reuse detected, revoking session %s", session.ID)
+		if err := c.AdminSessionRepository.Revoke(tx, session.ID, sessionRevokedReasonReuse); err != nil {
+			c.Log.Warnf("Failed revoke admin session : %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+		if err := tx.Commit().Error; err != nil {
+			c.Log.Warnf("Failed commit transaction : %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+		c.markSessionRevoked(session.ID)
+		return nil, fiber.ErrUnauthorized
+	}
+
+	if !session.IsActive(now) || refreshToken.ExpiresAt.Before(now) {
+		c.Log.Warnf("Refresh token or session %s expired or revoked", session.ID)
+		return nil, fiber.ErrUnauthorized
+	}
+
+	deadline := session.CreatedAt.Add(c.SessionMaxLifetime)
+	if !deadline.After(now) {
+		c.Log.Warnf("Session %s reached its maximum lifetime", session.ID)
+		return nil, fiber.ErrUnauthorized
+	}
+
+	refreshToken.UsedAt = &now
+	if err := c.AdminRefreshTokenRepository.Update(tx, refreshToken); err != nil {
+		c.Log.Warnf("Failed update refresh token : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	admin := new(entity.Admin)
+	if err := c.AuthRepository.FindByIdWithRole(tx, admin, session.AdminID); err != nil {
+		c.Log.Warnf("Failed to find admin by ID: %+v ", err)
+		return nil, fiber.ErrUnauthorized
+	}
+	audit.SetActor(admin.ID, admin.Username)
+	if !admin.IsActive {
+		c.Log.Warnf("Admin %d is disabled", admin.ID)
+		return nil, fiber.ErrUnauthorized
+	}
+
+	session.IPAddress = request.IPAddress
+	session.UserAgent = request.UserAgent
+	session.ExpiresAt = now.Add(c.RefreshTokenTTL)
+	if session.ExpiresAt.After(deadline) {
+		session.ExpiresAt = deadline
+	}
+	if err := c.AdminSessionRepository.Update(tx, session); err != nil {
+		c.Log.Warnf("Failed update admin session : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	token, err := c.issueTokens(tx, admin, session)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed commit transaction : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return token, nil
+}
+
 func (c *AuthUseCase) Verify(ctx context.Context, request *model.VerifyAdminRequest) (*model.AuthAdmin, error) {
 	if err := c.Validate.Struct(request); err != nil {
 		c.Log.Warnf("Invalid request body: %+v", err)
@@ -97,12 +260,7 @@ func (c *AuthUseCase) Verify(ctx context.Context, request *model.VerifyAdminRequ
 	}
 
 	tokenStr := strings.TrimSpace(strings.TrimPrefix(request.Token, "Bearer "))
-	token, err := jwt.Parse(tokenStr, func(t *jwt.Token) (interface{}, error) {
-		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fiber.ErrUnauthorized
-		}
-		return c.JwtSecret, nil
-	})
+	token, err := c.KeyRing.Parse(tokenStr)
 	if err != nil || !token.Valid {
 		c.Log.Warnf("Invalid JWT token: %+v", err)
 		return nil, fiber.ErrUnauthorized
@@ -111,27 +269,340 @@ func (c *AuthUseCase) Verify(ctx context.Context, request *model.VerifyAdminRequ
 	claims, ok := token.Claims.(jwt.MapClaims)
 	if !ok {
 		c.Log.Warnf("Invalid JWT claims")
-		return nil, err
+		return nil, fiber.ErrUnauthorized
 	}
 
-	if exp, ok := claims["exp"].(float64); ok {
-		if time.Unix(int64(exp), 0).Before(time.Now()) {
-			c.Log.Warn("Token expired")
-			return nil, err
-		}
+	if tokenType, _ := claims["type"].(string); tokenType != "access" {
+		c.Log.Warnf("Invalid JWT token type: %v", claims["type"])
+		return nil, fiber.ErrUnauthorized
 	}
 
 	adminID, ok := claims["uid"].(float64)
 	if !ok {
-		c.Log.Warn("Token claims: %+v", claims)
+		c.Log.Warnf("Token claims: %+v", claims)
+		return nil, fiber.ErrUnauthorized
+	}
+	tokenID, _ := claims["jti"].(string)
+	sessionID, _ := claims["sid"].(string)
+	expiresAt, err := claims.GetExpirationTime()
+	if tokenID == "" || sessionID == "" || err != nil || expiresAt == nil {
+		c.Log.Warnf("Token claims: %+v", claims)
+		return nil, fiber.ErrUnauthorized
+	}
+
+	cached, err := c.checkSession(ctx, int64(adminID), sessionID, tokenID, expiresAt.Time)
+	if err != nil {
 		return nil, err
 	}
+	if cached != nil {
+		return cached, nil
+	}
+
+	role, _ := claims["role"].(string)
+	auth := &model.AuthAdmin{
+		ID:          int64(adminID),
+		SessionID:   sessionID,
+		TokenID:     tokenID,
+		ExpiresAt:   expiresAt.Time,
+		Role:        role,
+		Permissions: claimStrings(claims["perms"]),
+	}
+	// A revocation cached while the session was being checked wins over this
+	// result, so it is only stored when nothing is cached for the token yet.
+	c.VerifyCache.SetIfAbsent("jti:"+tokenID, auth, min(c.RevocationCacheTTL, time.Until(expiresAt.Time)))
+	return auth, nil
+}
+
+// CheckSession checks again that an access token verified earlier is still
+// usable. Long-lived connections such as the event stream call it
+// periodically, since they outlive the check done by the middleware.
+func (c *AuthUseCase) CheckSession(ctx context.Context, auth *model.AuthAdmin) error {
+	if !auth.ExpiresAt.After(time.Now()) {
+		c.Log.Warnf("Token %s has expired", auth.TokenID)
+		return fiber.ErrUnauthorized
+	}
+	_, err := c.checkSession(ctx, auth.ID, auth.SessionID, auth.TokenID, auth.ExpiresAt)
+	return err
+}
+
+// checkSession checks that neither the token nor its session was revoked and
+// that the admin is still enabled. When an earlier check is still cached it
+// returns that result, otherwise nil.
+func (c *AuthUseCase) checkSession(ctx context.Context, adminID int64, sessionID string, tokenID string, expiresAt time.Time) (*model.AuthAdmin, error) {
+	if auth, ok := c.VerifyCache.Get("sid:" + sessionID); ok && auth == nil {
+		c.Log.Warnf("Session %s has been revoked", sessionID)
+		return nil, fiber.ErrUnauthorized
+	}
+	if auth, ok := c.VerifyCache.Get("jti:" + tokenID); ok {
+		if auth == nil {
+			c.Log.Warnf("Token %s has been revoked", tokenID)
+			return nil, fiber.ErrUnauthorized
+		}
+		return auth, nil
+	}
+
+	db := c.DB.WithContext(ctx)
+
+	revoked, err := c.RevokedTokenRepository.CountById(db, "jti", tokenID)
+	if err != nil {
+		c.Log.Warnf("Failed to check token revocation : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if revoked > 0 {
+		c.Log.Warnf("Token %s has been revoked", tokenID)
+		c.VerifyCache.Set("jti:"+tokenID, nil, time.Until(expiresAt))
+		return nil, fiber.ErrUnauthorized
+	}
+
+	session := new(entity.AdminSession)
+	if err := c.AdminSessionRepository.FindById(db, session, "id_session", sessionID); err != nil {
+		c.Log.Warnf("Failed to find admin session : %+v", err)
+		return nil, fiber.ErrUnauthorized
+	}
+	if session.RevokedAt != nil {
+		c.Log.Warnf("Session %s has been revoked", sessionID)
+		c.markSessionRevoked(sessionID)
+		return nil, fiber.ErrUnauthorized
+	}
 
 	admin := new(entity.Admin)
-	if err := c.AuthRepository.FindById(c.DB.WithContext(ctx), admin, "id", uint64(adminID)); err != nil {
-		print(admin)
+	if err := c.AuthRepository.FindById(db, admin, "id_admin", adminID); err != nil {
 		c.Log.Warnf("Failed to find admin by ID: %+v ", err)
-		return nil, err
+		return nil, fiber.ErrUnauthorized
+	}
+	if !admin.IsActive {
+		c.Log.Warnf("Admin %d is disabled", admin.ID)
+		return nil, fiber.ErrUnauthorized
+	}
+
+	return nil, nil
+}
+
+// Logout revokes the access token used for the request and the session it
+// belongs to, so its refresh token can no longer be exchanged either.
+func (c *AuthUseCase) Logout(ctx context.Context, auth *model.AuthAdmin) error {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	helper.Audit(ctx).Action = constants.AuditActionLogout
+
+	if err := c.RevokedTokenRepository.Revoke(tx, &entity.RevokedToken{
+		TokenID:   auth.TokenID,
+		AdminID:   auth.ID,
+		ExpiresAt: auth.ExpiresAt,
+	}); err != nil {
+		c.Log.Warnf("Failed revoke access token : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	if err := c.AdminSessionRepository.Revoke(tx, auth.SessionID, sessionRevokedReasonLogout); err != nil {
+		c.Log.Warnf("Failed revoke admin session : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed commit transaction : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	c.VerifyCache.Set("jti:"+auth.TokenID, nil, time.Until(auth.ExpiresAt))
+	c.markSessionRevoked(auth.SessionID)
+	return nil
+}
+
+// LogoutAll revokes every session of the admin, logging them out of all
+// devices. Access tokens of other sessions are rejected through their session.
+func (c *AuthUseCase) LogoutAll(ctx context.Context, auth *model.AuthAdmin) error {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	helper.Audit(ctx).Action = constants.AuditActionLogoutAll
+
+	if err := c.RevokedTokenRepository.Revoke(tx, &entity.RevokedToken{
+		TokenID:   auth.TokenID,
+		AdminID:   auth.ID,
+		ExpiresAt: auth.ExpiresAt,
+	}); err != nil {
+		c.Log.Warnf("Failed revoke access token : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	sessionIDs, err := c.revokeAllSessions(tx, auth.ID, sessionRevokedReasonLogoutAll)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed commit transaction : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	c.markSessionsRevoked(sessionIDs)
+	c.VerifyCache.Set("jti:"+auth.TokenID, nil, time.Until(auth.ExpiresAt))
+	return nil
+}
+
+// JWKS returns the public keys other services use to verify access tokens
+// offline.
+func (c *AuthUseCase) JWKS() *model.JWKSResponse {
+	return c.KeyRing.JWKS()
+}
+
+// revokeAllSessions revokes every active session of the admin inside tx and
+// returns their IDs. Callers pass them to markSessionsRevoked once tx is
+// committed, so a rollback never leaves a live session marked as revoked.
+func (c *AuthUseCase) revokeAllSessions(tx *gorm.DB, adminID int64, reason string) ([]string, error) {
+	sessionIDs, err := c.AdminSessionRepository.FindActiveIdsByAdminId(tx, adminID)
+	if err != nil {
+		c.Log.Warnf("Failed find admin sessions : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := c.AdminSessionRepository.RevokeAllByAdminId(tx, adminID, reason); err != nil {
+		c.Log.Warnf("Failed revoke admin sessions : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return sessionIDs, nil
+}
+
+// markSessionsRevoked marks every given session as revoked in the verify cache.
+func (c *AuthUseCase) markSessionsRevoked(sessionIDs []string) {
+	for _, sessionID := range sessionIDs {
+		c.markSessionRevoked(sessionID)
+	}
+}
+
+// markSessionRevoked remembers a revoked session for as long as any access
+// token issued for it can still be valid.
+func (c *AuthUseCase) markSessionRevoked(sessionID string) {
+	c.VerifyCache.Set("sid:"+sessionID, nil, c.AccessTokenTTL)
+}
+
+// startSession creates a new session for the admin and issues its first
+// access and refresh token.
+func (c *AuthUseCase) startSession(tx *gorm.DB, admin *entity.Admin, ipAddress string, userAgent string) (*model.TokenResponse, error) {
+	now := time.Now()
+	session := &entity.AdminSession{
+		ID:        uuid.New().String(),
+		AdminID:   admin.ID,
+		IPAddress: ipAddress,
+		UserAgent: userAgent,
+		ExpiresAt: now.Add(min(c.RefreshTokenTTL, c.SessionMaxLifetime)),
+		CreatedAt: now,
+	}
+	if err := c.AdminSessionRepository.Create(tx, session); err != nil {
+		c.Log.Warnf("Failed to create admin session: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return c.issueTokens(tx, admin, session)
+}
+
+// issueChallenge signs a short-lived token proving that the admin passed the
+// password step. It is exchanged for a session once the second factor is
+// verified, or used to enroll when the role requires 2FA and none is set up.
+func (c *AuthUseCase) issueChallenge(admin *entity.Admin) (*model.TwoFactorChallengeResponse, error) {
+	challengeType := challengeTypeVerify
+	if !admin.TOTPEnabled {
+		challengeType = challengeTypeEnroll
+	}
+
+	challengeStr, err := c.KeyRing.Sign(jwt.MapClaims{
+		"jti":  uuid.New().String(),
+		"uid":  admin.ID,
+		"type": challengeType,
+		"exp":  time.Now().Add(challengeTTL).Unix(),
+	})
+	if err != nil {
+		c.Log.Warnf("Failed to sign challenge token : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return &model.TwoFactorChallengeResponse{
+		ChallengeToken: challengeStr,
+		Type:           challengeType,
+		ExpiresIn:      int64(challengeTTL.Seconds()),
+	}, nil
+}
+
+// parseChallenge validates a challenge token of the expected type and returns
+// the admin ID it was issued for.
+func (c *AuthUseCase) parseChallenge(tokenStr string, expectedType string) (int64, error) {
+	token, err := c.KeyRing.Parse(tokenStr)
+	if err != nil || !token.Valid {
+		c.Log.Warnf("Invalid challenge token: %+v", err)
+		return 0, fiber.NewError(fiber.StatusUnauthorized, constants.InvalidChallengeTokenMessage)
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return 0, fiber.ErrUnauthorized
+	}
+	if tokenType, _ := claims["type"].(string); tokenType != expectedType {
+		c.Log.Warnf("Invalid challenge token type: %v", claims["type"])
+		return 0, fiber.NewError(fiber.StatusUnauthorized, constants.InvalidChallengeTokenMessage)
 	}
-	return &model.AuthAdmin{ID: int64(admin.ID)}, nil
-}
\ No newline at end of file
+
+	adminID, ok := claims["uid"].(float64)
+	if !ok {
+		return 0, fiber.ErrUnauthorized
+	}
+	return int64(adminID), nil
+}
+
+// issueTokens signs a new access token and persists a new refresh token for
+// the given session.
+func (c *AuthUseCase) issueTokens(tx *gorm.DB, admin *entity.Admin, session *entity.AdminSession) (*model.TokenResponse, error) {
+	claims := jwt.MapClaims{
+		"jti":  uuid.New().String(),
+		"uid":  admin.ID,
+		"sid":  session.ID,
+		"name": admin.Name,
+		"type": "access",
+		"exp":  time.Now().Add(c.AccessTokenTTL).Unix(),
+	}
+	if admin.Role != nil {
+		claims["role"] = admin.Role.Name
+		claims["perms"] = admin.Role.PermissionCodes()
+	}
+	accessTokenStr, err := c.KeyRing.Sign(claims)
+	if err != nil {
+		c.Log.Warnf("Failed to sign access token : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	refreshToken := uuid.New().String()
+	if err := c.AdminRefreshTokenRepository.Create(tx, &entity.AdminRefreshToken{
+		SessionID: session.ID,
+		TokenHash: hashToken(refreshToken),
+		ExpiresAt: session.ExpiresAt,
+	}); err != nil {
+		c.Log.Warnf("Failed to create refresh token : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return &model.TokenResponse{
+		AccessToken:           accessTokenStr,
+		RefreshToken:          refreshToken,
+		ExpiresIn:             int64(c.AccessTokenTTL.Seconds()),
+		RefreshTokenExpiresIn: int64(time.Until(session.ExpiresAt).Seconds()),
+	}, nil
+}
+
+// claimStrings converts a JSON array claim into a string slice.
+func claimStrings(claim any) []string {
+	values, _ := claim.([]any)
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		if str, ok := value.(string); ok {
+			result = append(result, str)
+		}
+	}
+	return result
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"test-kerja-mkp/internal/helper"
+	"test-kerja-mkp/internal/model"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// The cached revocations must be honoured before the cached valid result,
+// without reaching the database.
+func TestCheckSessionHonoursCachedRevocation(t *testing.T) {
+	expiresAt := time.Now().Add(time.Minute)
+	valid := &model.AuthAdmin{ID: 1, SessionID: "session", TokenID: "token", ExpiresAt: expiresAt}
+
+	tests := []struct {
+		name    string
+		prepare func(c *AuthUseCase)
+		want    *model.AuthAdmin
+	}{
+		{
+			name: "cached valid token",
+			prepare: func(c *AuthUseCase) {
+				c.VerifyCache.Set("jti:token", valid, time.Minute)
+			},
+			want: valid,
+		},
+		{
+			name: "revoked token",
+			prepare: func(c *AuthUseCase) {
+				c.VerifyCache.Set("jti:token", nil, time.Minute)
+			},
+		},
+		{
+			name: "revoked session with a cached valid token",
+			prepare: func(c *AuthUseCase) {
+				c.VerifyCache.Set("jti:token", valid, time.Minute)
+				c.markSessionRevoked("session")
+			},
+		},
+		{
+			name: "revoked token not replaced by a later valid result",
+			prepare: func(c *AuthUseCase) {
+				c.VerifyCache.Set("jti:token", nil, time.Minute)
+				c.VerifyCache.SetIfAbsent("jti:token", valid, time.Minute)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &AuthUseCase{
+				Log:            logrus.New(),
+				AccessTokenTTL: time.Minute,
+				VerifyCache:    helper.NewTTLCache[string, *model.AuthAdmin](0),
+			}
+			tt.prepare(c)
+
+			auth, err := c.checkSession(context.Background(), 1, "session", "token", expiresAt)
+			if tt.want != nil {
+				if err != nil || auth != tt.want {
+					t.Fatalf("checkSession = %v, %v; want the cached result", auth, err)
+				}
+				return
+			}
+			if auth != nil || !errors.Is(err, fiber.ErrUnauthorized) {
+				t.Fatalf("checkSession = %v, %v; want %v", auth, err, fiber.ErrUnauthorized)
+			}
+		})
+	}
+}
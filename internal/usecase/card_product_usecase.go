@@ -0,0 +1,277 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"test-kerja-mkp/internal/constants"
+	"test-kerja-mkp/internal/entity"
+	"test-kerja-mkp/internal/helper"
+	"test-kerja-mkp/internal/model"
+	"test-kerja-mkp/internal/model/converter"
+	"test-kerja-mkp/internal/repository"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// CardProductUseCase manages the card product catalog, the product of each
+// card and the report of their usage. Dates are local dates in Location.
+type CardProductUseCase struct {
+	DB                    *gorm.DB
+	Log                   *logrus.Logger
+	Validate              *validator.Validate
+	CardProductRepository *repository.CardProductRepository
+	CardRepository        *repository.CardRepository
+	Location              *time.Location
+}
+
+func NewCardProductUseCase(db *gorm.DB, log *logrus.Logger, validate *validator.Validate, cardProductRepository *repository.CardProductRepository,
+	cardRepository *repository.CardRepository, location *time.Location) *CardProductUseCase {
+	return &CardProductUseCase{
+		DB:                    db,
+		Log:                   log,
+		Validate:              validate,
+		CardProductRepository: cardProductRepository,
+		CardRepository:        cardRepository,
+		Location:              location,
+	}
+}
+
+func (c *CardProductUseCase) FindAll(ctx context.Context) ([]*model.CardProductResponse, error) {
+	products, err := c.CardProductRepository.FindAll(c.DB.WithContext(ctx))
+	if err != nil {
+		c.Log.Warnf("Failed find card products : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	responses := make([]*model.CardProductResponse, 0, len(products))
+	for _, product := range products {
+		responses = append(responses, converter.CardProductToResponse(product))
+	}
+	return responses, nil
+}
+
+func (c *CardProductUseCase) Create(ctx context.Context, request *model.CreateCardProductRequest) (*model.CardProductResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	audit := helper.Audit(ctx)
+	audit.Action = constants.AuditActionCardProductCreate
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	product := &entity.CardProduct{
+		Code:       request.Code,
+		Name:       request.Name,
+		Concession: request.Concession,
+	}
+	if err := setCardProductRule(product, request.DiscountType, request.DiscountValue, request.ValidFrom, request.ValidUntil); err != nil {
+		return nil, err
+	}
+
+	total, err := c.CardProductRepository.CountById(tx, "code", request.Code)
+	if err != nil {
+		c.Log.Warnf("Failed count card product : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if total > 0 {
+		return nil, fiber.NewError(fiber.StatusConflict, constants.CardProductCodeExistsMessage)
+	}
+
+	if err := c.CardProductRepository.Create(tx, product); err != nil {
+		c.Log.Warnf("Failed create card product : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed commit transaction : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	response := converter.CardProductToResponse(product)
+	audit.SetEntity(constants.AuditEntityCardProduct, product.ID)
+	audit.SetChange(nil, response)
+
+	return response, nil
+}
+
+// Update changes the product. Active journeys keep the product they checked
+// in with, but are charged with its updated discount.
+func (c *CardProductUseCase) Update(ctx context.Context, request *model.UpdateCardProductRequest) (*model.CardProductResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	audit := helper.Audit(ctx)
+	audit.Action = constants.AuditActionCardProductUpdate
+	audit.SetEntity(constants.AuditEntityCardProduct, request.ID)
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	product := new(entity.CardProduct)
+	if err := c.findProduct(tx, product, request.ID); err != nil {
+		return nil, err
+	}
+	before := converter.CardProductToResponse(product)
+
+	product.Name = request.Name
+	product.Concession = request.Concession
+	if err := setCardProductRule(product, request.DiscountType, request.DiscountValue, request.ValidFrom, request.ValidUntil); err != nil {
+		return nil, err
+	}
+	if err := c.CardProductRepository.Update(tx, product); err != nil {
+		c.Log.Warnf("Failed update card product : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed commit transaction : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	response := converter.CardProductToResponse(product)
+	audit.SetChange(before, response)
+
+	return response, nil
+}
+
+// AssignToCard links the card to a product valid today, or back to the
+// regular fare. The journey in progress, if any, keeps its product.
+func (c *CardProductUseCase) AssignToCard(ctx context.Context, request *model.AssignCardProductRequest) (*model.CardResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	audit := helper.Audit(ctx)
+	audit.Action = constants.AuditActionCardAssignProduct
+	audit.SetEntity(constants.AuditEntityCard, request.CardNumber)
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	if request.ProductID != nil {
+		product := new(entity.CardProduct)
+		if err := c.findProduct(tx, product, *request.ProductID); err != nil {
+			return nil, err
+		}
+		if !product.IsValidOn(time.Now().In(c.Location).Format(time.DateOnly)) {
+			return nil, fiber.NewError(fiber.StatusConflict, constants.CardProductNotValidMessage)
+		}
+	}
+
+	card := new(entity.Card)
+	if err := c.CardRepository.FindByIdForUpdate(tx, card, request.CardNumber); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fiber.NewError(fiber.StatusNotFound, constants.CardNotFoundMessage)
+		}
+		c.Log.Warnf("Failed find card : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	before := converter.CardToResponse(card)
+
+	card.ProductID = request.ProductID
+	if err := c.CardRepository.Update(tx, card); err != nil {
+		c.Log.Warnf("Failed update card product : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed commit transaction : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	response := converter.CardToResponse(card)
+	audit.SetChange(before, response)
+
+	return response, nil
+}
+
+// ConcessionUsage reports, per product, the completed journeys checked out
+// between the local dates and the discount they were given.
+func (c *CardProductUseCase) ConcessionUsage(ctx context.Context, request *model.ConcessionUsageRequest) ([]*model.ConcessionUsageResponse, error) {
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	from, _ := time.ParseInLocation(time.DateOnly, request.From, c.Location)
+	to, _ := time.ParseInLocation(time.DateOnly, request.To, c.Location)
+	if to.Before(from) {
+		return nil, fiber.NewError(fiber.StatusBadRequest, constants.InvalidReportRangeMessage)
+	}
+
+	usages, err := c.CardProductRepository.FindUsage(c.DB.WithContext(ctx), from, to.AddDate(0, 0, 1))
+	if err != nil {
+		c.Log.Warnf("Failed find concession usage : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	responses := make([]*model.ConcessionUsageResponse, 0, len(usages))
+	for _, usage := range usages {
+		responses = append(responses, converter.ConcessionUsageToResponse(usage))
+	}
+	return responses, nil
+}
+
+func (c *CardProductUseCase) findProduct(db *gorm.DB, product *entity.CardProduct, id int64) error {
+	if err := c.CardProductRepository.FindById(db, product, "id_product", id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, constants.CardProductNotFoundMessage)
+		}
+		c.Log.Warnf("Failed find card product : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+	return nil
+}
+
+// setCardProductRule checks the discount and the validity of a product
+// request and sets them on the product.
+func setCardProductRule(product *entity.CardProduct, discountType string, discountValue string, validFrom string, validUntil string) error {
+	var value entity.Money
+	if discountValue != "" {
+		parsed, err := entity.ParseMoney(discountValue)
+		if err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, constants.InvalidDiscountMessage)
+		}
+		value = parsed
+	}
+
+	switch discountType {
+	case entity.CardProductDiscountPercentage:
+		if value <= 0 || value > 100*entity.MoneyScale {
+			return fiber.NewError(fiber.StatusBadRequest, constants.InvalidDiscountMessage)
+		}
+	case entity.CardProductDiscountFixed:
+		if value <= 0 || value > entity.CardProductMaxDiscountValue {
+			return fiber.NewError(fiber.StatusBadRequest, constants.InvalidDiscountMessage)
+		}
+	case entity.CardProductDiscountFree:
+		if value != 0 {
+			return fiber.NewError(fiber.StatusBadRequest, constants.InvalidDiscountMessage)
+		}
+	}
+
+	from, _ := time.Parse(time.DateOnly, validFrom)
+	product.ValidFrom = from
+	product.ValidUntil = nil
+	if validUntil != "" {
+		until, _ := time.Parse(time.DateOnly, validUntil)
+		if until.Before(from) {
+			return fiber.NewError(fiber.StatusBadRequest, constants.InvalidProductValidityMessage)
+		}
+		product.ValidUntil = &until
+	}
+
+	product.DiscountType = discountType
+	product.DiscountValue = value
+	return nil
+}
@@ -0,0 +1,55 @@
+package usecase
+
+import (
+	"errors"
+	"test-kerja-mkp/internal/entity"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestSetCardProductRule(t *testing.T) {
+	tests := []struct {
+		name      string
+		typ       string
+		value     string
+		from      string
+		until     string
+		wantValue entity.Money
+		wantErr   bool
+	}{
+		{name: "percentage", typ: entity.CardProductDiscountPercentage, value: "50", wantValue: 5000},
+		{name: "full percentage", typ: entity.CardProductDiscountPercentage, value: "100", wantValue: 10000},
+		{name: "percentage above 100", typ: entity.CardProductDiscountPercentage, value: "100.01", wantErr: true},
+		{name: "zero percentage", typ: entity.CardProductDiscountPercentage, value: "0", wantErr: true},
+		{name: "fixed", typ: entity.CardProductDiscountFixed, value: "1500.50", wantValue: 150050},
+		{name: "fixed column maximum", typ: entity.CardProductDiscountFixed, value: "999999.99", wantValue: entity.CardProductMaxDiscountValue},
+		{name: "fixed above the column", typ: entity.CardProductDiscountFixed, value: "1000000", wantErr: true},
+		{name: "fixed far above the column", typ: entity.CardProductDiscountFixed, value: "999999999999999", wantErr: true},
+		{name: "negative fixed", typ: entity.CardProductDiscountFixed, value: "-1", wantErr: true},
+		{name: "free", typ: entity.CardProductDiscountFree, wantValue: 0},
+		{name: "free with a value", typ: entity.CardProductDiscountFree, value: "1", wantErr: true},
+		{name: "invalid value", typ: entity.CardProductDiscountFixed, value: "1e3", wantErr: true},
+		{name: "until before from", typ: entity.CardProductDiscountFree, until: "2025-12-31", wantErr: true},
+		{name: "until on from", typ: entity.CardProductDiscountFree, until: "2026-01-01"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			product := new(entity.CardProduct)
+			err := setCardProductRule(product, tt.typ, tt.value, "2026-01-01", tt.until)
+			if tt.wantErr {
+				var fiberErr *fiber.Error
+				if !errors.As(err, &fiberErr) || fiberErr.Code != fiber.StatusBadRequest {
+					t.Fatalf("setCardProductRule error = %v, want 400", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("setCardProductRule: %v", err)
+			}
+			if product.DiscountType != tt.typ || product.DiscountValue != tt.wantValue {
+				t.Fatalf("product = %s %s, want %s %s", product.DiscountType, product.DiscountValue, tt.typ, tt.wantValue)
+			}
+		})
+	}
+}
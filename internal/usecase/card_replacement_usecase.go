@@ -0,0 +1,176 @@
+package usecase
+
+import (
+	"context"
+	"strconv"
+	"test-kerja-mkp/internal/constants"
+	"test-kerja-mkp/internal/entity"
+	"test-kerja-mkp/internal/helper"
+	"test-kerja-mkp/internal/model"
+	"test-kerja-mkp/internal/model/converter"
+	"test-kerja-mkp/internal/repository"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// CardReplacementUseCase replaces lost or damaged registered cards. The old
+// card is blocked and its whole balance moves to the new card through a
+// transfer_out and a transfer_in transaction sharing one reference number.
+type CardReplacementUseCase struct {
+	DB                    *gorm.DB
+	Log                   *logrus.Logger
+	Validate              *validator.Validate
+	CardRepository        *repository.CardRepository
+	JourneyRepository     *repository.JourneyRepository
+	TransactionRepository *repository.TransactionRepository
+	CardUseCase           *CardUseCase
+}
+
+func NewCardReplacementUseCase(db *gorm.DB, log *logrus.Logger, validate *validator.Validate, cardRepository *repository.CardRepository,
+	journeyRepository *repository.JourneyRepository, transactionRepository *repository.TransactionRepository,
+	cardUseCase *CardUseCase) *CardReplacementUseCase {
+	return &CardReplacementUseCase{
+		DB:                    db,
+		Log:                   log,
+		Validate:              validate,
+		CardRepository:        cardRepository,
+		JourneyRepository:     journeyRepository,
+		TransactionRepository: transactionRepository,
+		CardUseCase:           cardUseCase,
+	}
+}
+
+// Replace moves the registered card to the new card in a single transaction.
+// It is refused while the old card has an active journey, since the fare of
+// that journey is still to be charged to it. The new card must be active and
+// either unregistered or registered to the same rider; it ends up registered
+// to the rider of the old card.
+func (c *CardReplacementUseCase) Replace(ctx context.Context, auth *model.AuthAdmin, request *model.ReplaceCardRequest) (*model.CardReplacementResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	audit := helper.Audit(ctx)
+	audit.Action = constants.AuditActionCardReplace
+	audit.SetEntity(constants.AuditEntityCard, request.CardNumber)
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	oldCard, newCard := new(entity.Card), new(entity.Card)
+	if err := c.lockCards(tx, oldCard, request.CardNumber, newCard, request.NewCardNumber); err != nil {
+		return nil, err
+	}
+
+	if oldCard.RiderID == nil {
+		return nil, fiber.NewError(fiber.StatusConflict, constants.CardNotRegisteredMessage)
+	}
+	if oldCard.ReplacedBy != nil {
+		return nil, fiber.NewError(fiber.StatusConflict, constants.CardAlreadyReplacedMessage)
+	}
+	active, err := c.JourneyRepository.CountActiveByCardNumber(tx, oldCard.CardNumber)
+	if err != nil {
+		c.Log.Warnf("Failed count active journeys : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if active > 0 {
+		return nil, fiber.NewError(fiber.StatusConflict, constants.CardHasActiveJourneyMessage)
+	}
+
+	if newCard.Status != entity.CardStatusActive || newCard.ReplacedBy != nil {
+		return nil, fiber.NewError(fiber.StatusConflict, constants.InvalidReplacementCardMessage)
+	}
+	if newCard.RiderID != nil && *newCard.RiderID != *oldCard.RiderID {
+		return nil, fiber.NewError(fiber.StatusConflict, constants.CardAlreadyRegisteredMessage)
+	}
+
+	before := &model.CardReplacementResponse{
+		OldCard: converter.CardToResponse(oldCard),
+		NewCard: converter.CardToResponse(newCard),
+		Amount:  entity.Money(0).String(),
+	}
+
+	now := time.Now()
+	if !oldCard.IsHotlisted() {
+		oldCard.Block(request.Reason, request.Note, now)
+		if err := c.CardUseCase.appendHotlist(tx, auth, oldCard, entity.CardHotlistAdd); err != nil {
+			return nil, err
+		}
+	}
+
+	amount := oldCard.Balance
+	reference := "REPLACE-" + strconv.FormatInt(oldCard.CardNumber, 10)
+	transactions := []*entity.Transaction{
+		{
+			CardNumber:      oldCard.CardNumber,
+			Type:            entity.TransactionTypeTransferOut,
+			Amount:          -amount,
+			BalanceBefore:   oldCard.Balance,
+			BalanceAfter:    0,
+			ReferenceNumber: &reference,
+			Timestamp:       now,
+			SyncStatus:      entity.SyncStatusSynced,
+		},
+		{
+			CardNumber:      newCard.CardNumber,
+			Type:            entity.TransactionTypeTransferIn,
+			Amount:          amount,
+			BalanceBefore:   newCard.Balance,
+			BalanceAfter:    newCard.Balance + amount,
+			ReferenceNumber: &reference,
+			Timestamp:       now,
+			SyncStatus:      entity.SyncStatusSynced,
+		},
+	}
+	for _, transaction := range transactions {
+		if err := c.TransactionRepository.Create(tx, transaction); err != nil {
+			c.Log.Warnf("Failed create transfer transaction : %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+	}
+
+	oldCard.Balance = 0
+	oldCard.ReplacedBy = &newCard.CardNumber
+	newCard.Balance += amount
+	newCard.RiderID = oldCard.RiderID
+	for _, card := range []*entity.Card{oldCard, newCard} {
+		if err := c.CardRepository.Update(tx, card); err != nil {
+			c.Log.Warnf("Failed update card : %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed commit transaction : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	response := &model.CardReplacementResponse{
+		OldCard: converter.CardToResponse(oldCard),
+		NewCard: converter.CardToResponse(newCard),
+		Amount:  amount.String(),
+	}
+	audit.SetChange(before, response)
+
+	return response, nil
+}
+
+// lockCards locks both cards in card number order, so two replacements
+// involving the same cards cannot deadlock.
+func (c *CardReplacementUseCase) lockCards(db *gorm.DB, oldCard *entity.Card, oldNumber int64, newCard *entity.Card, newNumber int64) error {
+	if oldNumber < newNumber {
+		if err := c.CardUseCase.lockCard(db, oldCard, oldNumber); err != nil {
+			return err
+		}
+		return c.CardUseCase.lockCard(db, newCard, newNumber)
+	}
+	if err := c.CardUseCase.lockCard(db, newCard, newNumber); err != nil {
+		return err
+	}
+	return c.CardUseCase.lockCard(db, oldCard, oldNumber)
+}
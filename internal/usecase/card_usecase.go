@@ -0,0 +1,317 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"math"
+	"test-kerja-mkp/internal/constants"
+	"test-kerja-mkp/internal/entity"
+	"test-kerja-mkp/internal/helper"
+	"test-kerja-mkp/internal/model"
+	"test-kerja-mkp/internal/model/converter"
+	"test-kerja-mkp/internal/repository"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// CardUseCase manages e-ticketing cards. Balances are entity.Money and only
+// change through ledger transactions, never directly through this use case.
+// Blocking and unblocking a card also records the change in the hotlist that
+// gates download.
+type CardUseCase struct {
+	DB                    *gorm.DB
+	Log                   *logrus.Logger
+	Validate              *validator.Validate
+	CardRepository        *repository.CardRepository
+	CardHotlistRepository *repository.CardHotlistRepository
+}
+
+func NewCardUseCase(db *gorm.DB, log *logrus.Logger, validate *validator.Validate, cardRepository *repository.CardRepository,
+	cardHotlistRepository *repository.CardHotlistRepository) *CardUseCase {
+	return &CardUseCase{
+		DB:                    db,
+		Log:                   log,
+		Validate:              validate,
+		CardRepository:        cardRepository,
+		CardHotlistRepository: cardHotlistRepository,
+	}
+}
+
+// Issue creates an active card with an empty balance.
+func (c *CardUseCase) Issue(ctx context.Context) (*model.CardResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	audit := helper.Audit(ctx)
+	audit.Action = constants.AuditActionCardIssue
+
+	card := &entity.Card{
+		Status: entity.CardStatusActive,
+	}
+	if err := c.CardRepository.Create(tx, card); err != nil {
+		c.Log.Warnf("Failed create card : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed commit transaction : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	response := converter.CardToResponse(card)
+	audit.SetEntity(constants.AuditEntityCard, card.CardNumber)
+	audit.SetChange(nil, response)
+
+	return response, nil
+}
+
+func (c *CardUseCase) FindAll(ctx context.Context, request *model.SearchCardRequest) ([]*model.CardResponse, *model.PageMetadata, error) {
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %v", err)
+		return nil, nil, fiber.ErrBadRequest
+	}
+	for _, amount := range []string{request.MinBalance, request.MaxBalance} {
+		if amount == "" {
+			continue
+		}
+		if _, err := entity.ParseMoney(amount); err != nil {
+			return nil, nil, fiber.NewError(fiber.StatusBadRequest, constants.InvalidAmountMessage)
+		}
+	}
+
+	cards, total, err := c.CardRepository.FindAll(c.DB.WithContext(ctx), request)
+	if err != nil {
+		return nil, nil, fiber.ErrInternalServerError
+	}
+
+	responses := make([]*model.CardResponse, 0, len(cards))
+	for _, card := range cards {
+		responses = append(responses, converter.CardToResponse(card))
+	}
+
+	return responses, &model.PageMetadata{
+		Page:      request.Page,
+		Size:      request.Size,
+		TotalItem: total,
+		TotalPage: int64(math.Ceil(float64(total) / float64(request.Size))),
+	}, nil
+}
+
+func (c *CardUseCase) FindById(ctx context.Context, cardNumber int64) (*model.CardResponse, error) {
+	card := new(entity.Card)
+	if err := c.CardRepository.FindById(c.DB.WithContext(ctx), card, "card_number", cardNumber); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fiber.NewError(fiber.StatusNotFound, constants.CardNotFoundMessage)
+		}
+		c.Log.Warnf("Failed find card : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.CardToResponse(card), nil
+}
+
+func (c *CardUseCase) UpdateStatus(ctx context.Context, request *model.UpdateCardStatusRequest) (*model.CardResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	audit := helper.Audit(ctx)
+	audit.Action = constants.AuditActionCardUpdateStatus
+	audit.SetEntity(constants.AuditEntityCard, request.CardNumber)
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	card := new(entity.Card)
+	if err := c.lockCard(tx, card, request.CardNumber); err != nil {
+		return nil, err
+	}
+	if card.IsHotlisted() {
+		return nil, fiber.NewError(fiber.StatusConflict, constants.CardBlockedMessage)
+	}
+	before := converter.CardToResponse(card)
+
+	card.Status = request.Status
+	if err := c.CardRepository.Update(tx, card); err != nil {
+		c.Log.Warnf("Failed update card : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed commit transaction : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	response := converter.CardToResponse(card)
+	audit.SetChange(before, response)
+
+	return response, nil
+}
+
+// Block blocks an active card and adds it to the hotlist.
+func (c *CardUseCase) Block(ctx context.Context, auth *model.AuthAdmin, request *model.BlockCardRequest) (*model.CardResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	audit := helper.Audit(ctx)
+	audit.Action = constants.AuditActionCardBlock
+	audit.SetEntity(constants.AuditEntityCard, request.CardNumber)
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	card := new(entity.Card)
+	if err := c.lockCard(tx, card, request.CardNumber); err != nil {
+		return nil, err
+	}
+	if card.IsHotlisted() {
+		return nil, fiber.NewError(fiber.StatusConflict, constants.CardAlreadyBlockedMessage)
+	}
+	if card.Status != entity.CardStatusActive {
+		return nil, fiber.NewError(fiber.StatusConflict, constants.CardNotActiveMessage)
+	}
+	before := converter.CardToResponse(card)
+
+	card.Block(request.Reason, request.Note, time.Now())
+	if err := c.CardRepository.Update(tx, card); err != nil {
+		c.Log.Warnf("Failed block card : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if err := c.appendHotlist(tx, auth, card, entity.CardHotlistAdd); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed commit transaction : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	response := converter.CardToResponse(card)
+	audit.SetChange(before, response)
+
+	return response, nil
+}
+
+// Unblock activates a blocked card and removes it from the hotlist.
+func (c *CardUseCase) Unblock(ctx context.Context, auth *model.AuthAdmin, request *model.UnblockCardRequest) (*model.CardResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	audit := helper.Audit(ctx)
+	audit.Action = constants.AuditActionCardUnblock
+	audit.SetEntity(constants.AuditEntityCard, request.CardNumber)
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	card := new(entity.Card)
+	if err := c.lockCard(tx, card, request.CardNumber); err != nil {
+		return nil, err
+	}
+	if !card.IsHotlisted() {
+		return nil, fiber.NewError(fiber.StatusConflict, constants.CardNotBlockedMessage)
+	}
+	before := converter.CardToResponse(card)
+
+	card.Unblock()
+	if err := c.CardRepository.Update(tx, card); err != nil {
+		c.Log.Warnf("Failed unblock card : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if err := c.appendHotlist(tx, auth, card, entity.CardHotlistRemove); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed commit transaction : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	response := converter.CardToResponse(card)
+	audit.SetChange(before, response)
+
+	return response, nil
+}
+
+// Hotlist returns the hotlist changes after request.Since, or the full
+// hotlist when Since is nil or ahead of the server, for instance after a
+// database restore.
+func (c *CardUseCase) Hotlist(ctx context.Context, request *model.HotlistRequest) (*model.HotlistResponse, error) {
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	db := c.DB.WithContext(ctx)
+	version, err := c.CardHotlistRepository.CurrentVersion(db)
+	if err != nil {
+		c.Log.Warnf("Failed find hotlist version : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	response := &model.HotlistResponse{
+		Version: version,
+		Full:    request.Since == nil || *request.Since > version,
+		Added:   []*model.HotlistEntryResponse{},
+		Removed: []int64{},
+	}
+	var since int64
+	if !response.Full {
+		since = *request.Since
+	}
+
+	entries, err := c.CardHotlistRepository.FindLatestBetween(db, since, version)
+	if err != nil {
+		c.Log.Warnf("Failed find hotlist : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	for _, entry := range entries {
+		if entry.Action == entity.CardHotlistAdd {
+			response.Added = append(response.Added, converter.CardHotlistToResponse(entry))
+		} else if !response.Full {
+			response.Removed = append(response.Removed, entry.CardNumber)
+		}
+	}
+
+	return response, nil
+}
+
+// appendHotlist records the new hotlist version of the card.
+func (c *CardUseCase) appendHotlist(db *gorm.DB, auth *model.AuthAdmin, card *entity.Card, action string) error {
+	if err := c.CardHotlistRepository.LockWriter(db); err != nil {
+		c.Log.Warnf("Failed lock hotlist : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	entry := &entity.CardHotlist{
+		CardNumber: card.CardNumber,
+		Action:     action,
+		Reason:     card.BlockReason,
+		CreatedBy:  auth.ID,
+	}
+	if err := c.CardHotlistRepository.Create(db, entry); err != nil {
+		c.Log.Warnf("Failed create hotlist entry : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+	return nil
+}
+
+func (c *CardUseCase) lockCard(db *gorm.DB, card *entity.Card, cardNumber int64) error {
+	if err := c.CardRepository.FindByIdForUpdate(db, card, cardNumber); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, constants.CardNotFoundMessage)
+		}
+		c.Log.Warnf("Failed find card : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+	return nil
+}
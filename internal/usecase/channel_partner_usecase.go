@@ -0,0 +1,180 @@
+package usecase
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"strings"
+	"test-kerja-mkp/internal/constants"
+	"test-kerja-mkp/internal/entity"
+	"test-kerja-mkp/internal/helper"
+	"test-kerja-mkp/internal/model"
+	"test-kerja-mkp/internal/model/converter"
+	"test-kerja-mkp/internal/repository"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// ChannelPartnerUseCase manages the channel partners and authenticates their
+// API keys. A key has the form "<id_partner>.<secret>".
+type ChannelPartnerUseCase struct {
+	DB                       *gorm.DB
+	Log                      *logrus.Logger
+	Validate                 *validator.Validate
+	ChannelPartnerRepository *repository.ChannelPartnerRepository
+}
+
+func NewChannelPartnerUseCase(db *gorm.DB, log *logrus.Logger, validate *validator.Validate,
+	channelPartnerRepository *repository.ChannelPartnerRepository) *ChannelPartnerUseCase {
+	return &ChannelPartnerUseCase{
+		DB:                       db,
+		Log:                      log,
+		Validate:                 validate,
+		ChannelPartnerRepository: channelPartnerRepository,
+	}
+}
+
+// Create registers a partner. The API key is only returned here.
+func (c *ChannelPartnerUseCase) Create(ctx context.Context, auth *model.AuthAdmin, request *model.CreateChannelPartnerRequest) (*model.ChannelPartnerSecretResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	audit := helper.Audit(ctx)
+	audit.Action = constants.AuditActionChannelPartnerCreate
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	secret, err := randomToken(32)
+	if err != nil {
+		c.Log.Warnf("Failed generate partner secret : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	partner := &entity.ChannelPartner{
+		ID:        uuid.New().String(),
+		Name:      request.Name,
+		KeyHash:   hashToken(secret),
+		CreatedBy: auth.ID,
+	}
+	if err := c.ChannelPartnerRepository.Create(tx, partner); err != nil {
+		c.Log.Warnf("Failed create channel partner : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed commit transaction : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	response := converter.ChannelPartnerToResponse(partner)
+	audit.SetEntity(constants.AuditEntityChannelPartner, partner.ID)
+	audit.SetChange(nil, response)
+
+	return &model.ChannelPartnerSecretResponse{
+		ChannelPartnerResponse: *response,
+		APIKey:                 partner.ID + "." + secret,
+	}, nil
+}
+
+func (c *ChannelPartnerUseCase) FindAll(ctx context.Context) ([]*model.ChannelPartnerResponse, error) {
+	partners, err := c.ChannelPartnerRepository.FindAll(c.DB.WithContext(ctx))
+	if err != nil {
+		c.Log.Warnf("Failed find channel partners : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	responses := make([]*model.ChannelPartnerResponse, 0, len(partners))
+	for _, partner := range partners {
+		responses = append(responses, converter.ChannelPartnerToResponse(partner))
+	}
+	return responses, nil
+}
+
+// Disable revokes the API key of the partner for good.
+func (c *ChannelPartnerUseCase) Disable(ctx context.Context, id string) error {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	audit := helper.Audit(ctx)
+	audit.Action = constants.AuditActionChannelPartnerDisable
+	audit.SetEntity(constants.AuditEntityChannelPartner, id)
+
+	if _, err := uuid.Parse(id); err != nil {
+		return fiber.ErrBadRequest
+	}
+
+	partner := new(entity.ChannelPartner)
+	if err := c.ChannelPartnerRepository.FindById(tx, partner, "id_partner", id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, constants.ChannelPartnerNotFoundMessage)
+		}
+		c.Log.Warnf("Failed find channel partner : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	before := converter.ChannelPartnerToResponse(partner)
+	if partner.DisabledAt == nil {
+		now := time.Now()
+		partner.DisabledAt = &now
+		if err := c.ChannelPartnerRepository.Update(tx, partner); err != nil {
+			c.Log.Warnf("Failed disable channel partner : %+v", err)
+			return fiber.ErrInternalServerError
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed commit transaction : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	audit.SetChange(before, converter.ChannelPartnerToResponse(partner))
+
+	return nil
+}
+
+// Verify authenticates a partner API key.
+func (c *ChannelPartnerUseCase) Verify(ctx context.Context, apiKey string) (*model.AuthPartner, error) {
+	id, secret, ok := strings.Cut(apiKey, ".")
+	if !ok {
+		return nil, fiber.ErrUnauthorized
+	}
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, fiber.ErrUnauthorized
+	}
+
+	db := c.DB.WithContext(ctx)
+	partner := new(entity.ChannelPartner)
+	if err := c.ChannelPartnerRepository.FindById(db, partner, "id_partner", id); err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			c.Log.Warnf("Failed find channel partner : %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+		c.Log.Warnf("Unknown channel partner %s", id)
+		return nil, fiber.ErrUnauthorized
+	}
+
+	if partner.DisabledAt != nil || subtle.ConstantTimeCompare([]byte(hashToken(secret)), []byte(partner.KeyHash)) != 1 {
+		c.Log.Warnf("Invalid api key for channel partner %s", partner.ID)
+		return nil, fiber.ErrUnauthorized
+	}
+
+	now := time.Now()
+	if partner.LastUsedAt == nil || now.Sub(*partner.LastUsedAt) > lastUsedInterval {
+		if err := c.ChannelPartnerRepository.UpdateLastUsed(db, partner.ID, now); err != nil {
+			c.Log.Warnf("Failed update channel partner last used : %+v", err)
+		}
+	}
+
+	return &model.AuthPartner{
+		ID:   partner.ID,
+		Name: partner.Name,
+	}, nil
+}
@@ -0,0 +1,275 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"test-kerja-mkp/internal/constants"
+	"test-kerja-mkp/internal/entity"
+	"test-kerja-mkp/internal/helper"
+	"test-kerja-mkp/internal/model"
+	"test-kerja-mkp/internal/model/converter"
+	"test-kerja-mkp/internal/repository"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const (
+	gateCommandPullLimit    = 20
+	gateCommandHistoryLimit = 100
+)
+
+// GateCommandUseCase manages the command queue of gates. Admins issue
+// commands, gates pull them, optionally waiting for one to arrive, and report
+// the outcome. A pulled command is delivered again on every pull until it is
+// acknowledged, failed or expired.
+type GateCommandUseCase struct {
+	DB                    *gorm.DB
+	Log                   *logrus.Logger
+	Validate              *validator.Validate
+	GateRepository        *repository.GateRepository
+	GateCommandRepository *repository.GateCommandRepository
+	// PollInterval is how often a waiting pull looks for new commands.
+	PollInterval time.Duration
+}
+
+func NewGateCommandUseCase(db *gorm.DB, log *logrus.Logger, validate *validator.Validate, gateRepository *repository.GateRepository,
+	gateCommandRepository *repository.GateCommandRepository, pollInterval time.Duration) *GateCommandUseCase {
+	return &GateCommandUseCase{
+		DB:                    db,
+		Log:                   log,
+		Validate:              validate,
+		GateRepository:        gateRepository,
+		GateCommandRepository: gateCommandRepository,
+		PollInterval:          pollInterval,
+	}
+}
+
+func (c *GateCommandUseCase) Issue(ctx context.Context, auth *model.AuthAdmin, request *model.IssueGateCommandRequest) (*model.GateCommandResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	audit := helper.Audit(ctx)
+	audit.Action = constants.AuditActionGateCommandIssue
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	gate := new(entity.Gate)
+	if err := c.GateRepository.FindById(tx, gate, "id_gates", request.GateID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fiber.NewError(fiber.StatusNotFound, constants.GateNotFoundMessage)
+		}
+		c.Log.Warnf("Failed find gate : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if gate.IsDecommissioned() {
+		return nil, fiber.NewError(fiber.StatusConflict, constants.GateDecommissionedMessage)
+	}
+
+	command := &entity.GateCommand{
+		GateID:    gate.ID,
+		Command:   request.Command,
+		Status:    entity.GateCommandStatusPending,
+		IssuedBy:  auth.ID,
+		ExpiresAt: time.Now().Add(time.Duration(request.TTL) * time.Second),
+	}
+	if request.Command == entity.GateCommandEmergencyMode {
+		payload, err := json.Marshal(map[string]bool{"enabled": *request.Enabled})
+		if err != nil {
+			c.Log.Warnf("Failed marshal gate command payload : %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+		command.Payload = payload
+	}
+	if err := c.GateCommandRepository.Create(tx, command); err != nil {
+		c.Log.Warnf("Failed create gate command : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed commit transaction : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	response := converter.GateCommandToResponse(command)
+	audit.SetEntity(constants.AuditEntityGateCommand, command.ID)
+	audit.SetChange(nil, response)
+
+	return response, nil
+}
+
+// Cancel fails an open command so it is no longer delivered.
+func (c *GateCommandUseCase) Cancel(ctx context.Context, request *model.CancelGateCommandRequest) error {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	audit := helper.Audit(ctx)
+	audit.Action = constants.AuditActionGateCommandCancel
+	audit.SetEntity(constants.AuditEntityGateCommand, request.ID)
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	command := new(entity.GateCommand)
+	if err := c.findOpenCommand(tx, command, request.ID, request.GateID); err != nil {
+		return err
+	}
+	before := converter.GateCommandToResponse(command)
+
+	command.Complete(entity.GateCommandStatusFailed, entity.GateCommandResultCancelled, time.Now())
+	if err := c.GateCommandRepository.Update(tx, command); err != nil {
+		c.Log.Warnf("Failed cancel gate command : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed commit transaction : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	audit.SetChange(before, converter.GateCommandToResponse(command))
+
+	return nil
+}
+
+// FindAllByGateId returns the latest commands of the gate.
+func (c *GateCommandUseCase) FindAllByGateId(ctx context.Context, gateID int64) ([]*model.GateCommandResponse, error) {
+	db := c.DB.WithContext(ctx)
+
+	total, err := c.GateRepository.CountById(db, "id_gates", gateID)
+	if err != nil {
+		c.Log.Warnf("Failed count gate : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if total == 0 {
+		return nil, fiber.NewError(fiber.StatusNotFound, constants.GateNotFoundMessage)
+	}
+
+	commands, err := c.GateCommandRepository.FindAllByGateId(db, gateID, gateCommandHistoryLimit)
+	if err != nil {
+		c.Log.Warnf("Failed find gate commands : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	responses := make([]*model.GateCommandResponse, 0, len(commands))
+	for _, command := range commands {
+		responses = append(responses, converter.GateCommandToResponse(command))
+	}
+	return responses, nil
+}
+
+// Pull returns the open commands of the authenticated gate. When there are
+// none it checks again every PollInterval for up to request.Wait seconds.
+func (c *GateCommandUseCase) Pull(ctx context.Context, auth *model.AuthGate, request *model.PullGateCommandRequest) ([]*model.GateCommandResponse, error) {
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	deadline := time.Now().Add(time.Duration(request.Wait) * time.Second)
+	for {
+		responses, err := c.deliver(ctx, auth.GateID)
+		if err != nil || len(responses) > 0 || !time.Now().Add(c.PollInterval).Before(deadline) {
+			return responses, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return responses, nil
+		case <-time.After(c.PollInterval):
+		}
+	}
+}
+
+// Ack records the outcome reported by the gate. It is accepted for an expired
+// command as long as the command was not closed yet, since the gate may have
+// executed it just before the deadline.
+func (c *GateCommandUseCase) Ack(ctx context.Context, auth *model.AuthGate, request *model.AckGateCommandRequest) (*model.GateCommandResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	command := new(entity.GateCommand)
+	if err := c.findOpenCommand(tx, command, request.ID, auth.GateID); err != nil {
+		return nil, err
+	}
+
+	command.Complete(request.Status, request.Result, time.Now())
+	if err := c.GateCommandRepository.Update(tx, command); err != nil {
+		c.Log.Warnf("Failed update gate command : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed commit transaction : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.GateCommandToResponse(command), nil
+}
+
+// deliver expires the stale commands of the gate and marks the open ones as
+// delivered.
+func (c *GateCommandUseCase) deliver(ctx context.Context, gateID int64) ([]*model.GateCommandResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	now := time.Now()
+	if err := c.GateCommandRepository.ExpireByGateId(tx, gateID, now); err != nil {
+		c.Log.Warnf("Failed expire gate commands : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	commands, err := c.GateCommandRepository.FindOpenByGateIdForUpdate(tx, gateID, now, gateCommandPullLimit)
+	if err != nil {
+		c.Log.Warnf("Failed find gate commands : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	responses := make([]*model.GateCommandResponse, 0, len(commands))
+	for _, command := range commands {
+		if command.Status == entity.GateCommandStatusPending {
+			command.Status = entity.GateCommandStatusDelivered
+			command.DeliveredAt = &now
+			if err := c.GateCommandRepository.Update(tx, command); err != nil {
+				c.Log.Warnf("Failed update gate command : %+v", err)
+				return nil, fiber.ErrInternalServerError
+			}
+		}
+		responses = append(responses, converter.GateCommandToResponse(command))
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed commit transaction : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return responses, nil
+}
+
+func (c *GateCommandUseCase) findOpenCommand(db *gorm.DB, command *entity.GateCommand, id int64, gateID int64) error {
+	if err := c.GateCommandRepository.FindByIdAndGateIdForUpdate(db, command, id, gateID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, constants.GateCommandNotFoundMessage)
+		}
+		c.Log.Warnf("Failed find gate command : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+	if !command.IsOpen() {
+		return fiber.NewError(fiber.StatusConflict, constants.GateCommandClosedMessage)
+	}
+	return nil
+}
@@ -0,0 +1,358 @@
+package usecase
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"test-kerja-mkp/internal/constants"
+	"test-kerja-mkp/internal/entity"
+	"test-kerja-mkp/internal/helper"
+	"test-kerja-mkp/internal/model"
+	"test-kerja-mkp/internal/model/converter"
+	"test-kerja-mkp/internal/repository"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// lastUsedInterval limits how often last_used_at is written for a credential
+// that authenticates many requests.
+const lastUsedInterval = time.Minute
+
+type GateCredentialUseCase struct {
+	DB                       *gorm.DB
+	Log                      *logrus.Logger
+	Validate                 *validator.Validate
+	GateRepository           *repository.GateRepository
+	GateCredentialRepository *repository.GateCredentialRepository
+	// SignatureTolerance is how far the timestamp of a signed request may be
+	// from the server clock.
+	SignatureTolerance time.Duration
+	// RotationGrace is how long the previous credentials of a gate keep
+	// working after a rotation.
+	RotationGrace time.Duration
+	// NonceCache remembers the nonces of signed requests for twice the
+	// signature tolerance so a captured request cannot be replayed. It is
+	// per process, so replicas behind a load balancer need sticky routing
+	// per gate.
+	NonceCache *helper.TTLCache[string, struct{}]
+}
+
+func NewGateCredentialUseCase(db *gorm.DB, log *logrus.Logger, validate *validator.Validate, gateRepository *repository.GateRepository,
+	gateCredentialRepository *repository.GateCredentialRepository, signatureTolerance time.Duration, rotationGrace time.Duration) *GateCredentialUseCase {
+	return &GateCredentialUseCase{
+		DB:                       db,
+		Log:                      log,
+		Validate:                 validate,
+		GateRepository:           gateRepository,
+		GateCredentialRepository: gateCredentialRepository,
+		SignatureTolerance:       signatureTolerance,
+		RotationGrace:            rotationGrace,
+		NonceCache:               helper.NewTTLCache[string, struct{}](time.Minute),
+	}
+}
+
+// Issue creates an additional credential for the gate. The secret is only
+// returned here.
+func (c *GateCredentialUseCase) Issue(ctx context.Context, auth *model.AuthAdmin, request *model.GateCredentialRequest) (*model.GateCredentialSecretResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	helper.Audit(ctx).Action = constants.AuditActionGateCredentialIssue
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	if err := c.ensureGateExists(tx, request.GateID); err != nil {
+		return nil, err
+	}
+
+	response, err := c.create(ctx, tx, auth, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed commit transaction : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return response, nil
+}
+
+// Rotate issues a new credential and lets every other active credential of
+// the gate expire after RotationGrace.
+func (c *GateCredentialUseCase) Rotate(ctx context.Context, auth *model.AuthAdmin, request *model.GateCredentialRequest) (*model.GateCredentialSecretResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	helper.Audit(ctx).Action = constants.AuditActionGateCredentialRotate
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	if err := c.ensureGateExists(tx, request.GateID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	credentials, err := c.GateCredentialRepository.FindActiveByGateIdForUpdate(tx, request.GateID, now)
+	if err != nil {
+		c.Log.Warnf("Failed find gate credentials : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	expiresAt := now.Add(c.RotationGrace)
+	for _, credential := range credentials {
+		if credential.ExpiresAt != nil && credential.ExpiresAt.Before(expiresAt) {
+			continue
+		}
+		credential.ExpiresAt = &expiresAt
+		if err := c.GateCredentialRepository.Update(tx, credential); err != nil {
+			c.Log.Warnf("Failed expire gate credential : %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+	}
+
+	response, err := c.create(ctx, tx, auth, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed commit transaction : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return response, nil
+}
+
+// Revoke disables a credential immediately.
+func (c *GateCredentialUseCase) Revoke(ctx context.Context, request *model.RevokeGateCredentialRequest) error {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	audit := helper.Audit(ctx)
+	audit.Action = constants.AuditActionGateCredentialRevoke
+	audit.SetEntity(constants.AuditEntityGateCredential, request.CredentialID)
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	credential := new(entity.GateCredential)
+	if err := c.GateCredentialRepository.FindByIdAndGateId(tx, credential, request.CredentialID, request.GateID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.ErrNotFound
+		}
+		c.Log.Warnf("Failed find gate credential : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	before := converter.GateCredentialToResponse(credential)
+	if credential.RevokedAt == nil {
+		now := time.Now()
+		credential.RevokedAt = &now
+		if err := c.GateCredentialRepository.Update(tx, credential); err != nil {
+			c.Log.Warnf("Failed revoke gate credential : %+v", err)
+			return fiber.ErrInternalServerError
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed commit transaction : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	audit.SetChange(before, converter.GateCredentialToResponse(credential))
+
+	return nil
+}
+
+func (c *GateCredentialUseCase) FindAllByGateId(ctx context.Context, gateID int64) ([]*model.GateCredentialResponse, error) {
+	db := c.DB.WithContext(ctx)
+
+	if err := c.ensureGateExists(db, gateID); err != nil {
+		return nil, err
+	}
+
+	credentials, err := c.GateCredentialRepository.FindAllByGateId(db, gateID)
+	if err != nil {
+		c.Log.Warnf("Failed find gate credentials : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	responses := make([]*model.GateCredentialResponse, 0, len(credentials))
+	for _, credential := range credentials {
+		responses = append(responses, converter.GateCredentialToResponse(credential))
+	}
+	return responses, nil
+}
+
+// Verify authenticates a gate request either by API key or by an HMAC-SHA256
+// signature over the method, path, timestamp, nonce and body hash.
+func (c *GateCredentialUseCase) Verify(ctx context.Context, request *model.VerifyGateRequest) (*model.AuthGate, error) {
+	keyID, apiSecret := request.KeyID, ""
+	if request.APIKey != "" {
+		var ok bool
+		keyID, apiSecret, ok = strings.Cut(request.APIKey, ".")
+		if !ok {
+			return nil, fiber.ErrUnauthorized
+		}
+	}
+	if _, err := uuid.Parse(keyID); err != nil {
+		return nil, fiber.ErrUnauthorized
+	}
+
+	db := c.DB.WithContext(ctx)
+	credential := new(entity.GateCredential)
+	if err := c.GateCredentialRepository.FindByIdWithGate(db, credential, keyID); err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			c.Log.Warnf("Failed find gate credential : %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+		c.Log.Warnf("Unknown gate credential %s", keyID)
+		return nil, fiber.ErrUnauthorized
+	}
+
+	now := time.Now()
+	if !credential.IsActive(now) || credential.Gate == nil || credential.Gate.Terminal == nil {
+		c.Log.Warnf("Gate credential %s is not active", credential.ID)
+		return nil, fiber.ErrUnauthorized
+	}
+
+	if request.APIKey != "" {
+		if credential.AuthType != entity.GateAuthTypeAPIKey ||
+			subtle.ConstantTimeCompare([]byte(hashToken(apiSecret)), []byte(credential.KeyHash)) != 1 {
+			c.Log.Warnf("Invalid api key for gate credential %s", credential.ID)
+			return nil, fiber.ErrUnauthorized
+		}
+	} else if err := c.verifySignature(credential, request, now); err != nil {
+		return nil, err
+	}
+
+	if credential.LastUsedAt == nil || now.Sub(*credential.LastUsedAt) > lastUsedInterval {
+		if err := c.GateCredentialRepository.UpdateLastUsed(db, credential.ID, now); err != nil {
+			c.Log.Warnf("Failed update gate credential last used : %+v", err)
+		}
+	}
+
+	return &model.AuthGate{
+		GateID:       credential.GateID,
+		TerminalID:   credential.Gate.TerminalID,
+		GateNumber:   credential.Gate.GateNumber,
+		CredentialID: credential.ID,
+	}, nil
+}
+
+func (c *GateCredentialUseCase) verifySignature(credential *entity.GateCredential, request *model.VerifyGateRequest, now time.Time) error {
+	if credential.AuthType != entity.GateAuthTypeHMAC {
+		c.Log.Warnf("Gate credential %s is not an hmac credential", credential.ID)
+		return fiber.ErrUnauthorized
+	}
+	if request.Nonce == "" || len(request.Nonce) > 64 {
+		return fiber.ErrUnauthorized
+	}
+
+	timestamp, err := strconv.ParseInt(request.Timestamp, 10, 64)
+	if err != nil {
+		return fiber.ErrUnauthorized
+	}
+	if skew := now.Sub(time.Unix(timestamp, 0)); skew > c.SignatureTolerance || skew < -c.SignatureTolerance {
+		c.Log.Warnf("Gate request timestamp outside tolerance for credential %s", credential.ID)
+		return fiber.ErrUnauthorized
+	}
+
+	signature, err := hex.DecodeString(request.Signature)
+	if err != nil || !hmac.Equal(signature, GateSignature(credential.Secret, request.Method, request.Path, request.Timestamp, request.Nonce, request.Body)) {
+		c.Log.Warnf("Invalid signature for gate credential %s", credential.ID)
+		return fiber.ErrUnauthorized
+	}
+
+	// The nonce is only recorded after the signature matched, so unsigned
+	// requests cannot burn nonces of a real gate.
+	nonceKey := credential.ID + ":" + request.Nonce
+	if !c.NonceCache.SetIfAbsent(nonceKey, struct{}{}, 2*c.SignatureTolerance) {
+		c.Log.Warnf("Replayed nonce for gate credential %s", credential.ID)
+		return fiber.ErrUnauthorized
+	}
+	return nil
+}
+
+func (c *GateCredentialUseCase) create(ctx context.Context, tx *gorm.DB, auth *model.AuthAdmin, request *model.GateCredentialRequest) (*model.GateCredentialSecretResponse, error) {
+	secret, err := randomToken(32)
+	if err != nil {
+		c.Log.Warnf("Failed generate gate secret : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	credential := &entity.GateCredential{
+		ID:        uuid.New().String(),
+		GateID:    request.GateID,
+		AuthType:  request.AuthType,
+		CreatedBy: auth.ID,
+	}
+	if request.AuthType == entity.GateAuthTypeAPIKey {
+		credential.KeyHash = hashToken(secret)
+		secret = credential.ID + "." + secret
+	} else {
+		credential.Secret = secret
+	}
+
+	if err := c.GateCredentialRepository.Create(tx, credential); err != nil {
+		c.Log.Warnf("Failed create gate credential : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	response := converter.GateCredentialToResponse(credential)
+	audit := helper.Audit(ctx)
+	audit.SetEntity(constants.AuditEntityGateCredential, credential.ID)
+	audit.SetChange(nil, response)
+
+	return &model.GateCredentialSecretResponse{
+		GateCredentialResponse: *response,
+		Secret:                 secret,
+	}, nil
+}
+
+func (c *GateCredentialUseCase) ensureGateExists(db *gorm.DB, gateID int64) error {
+	total, err := c.GateRepository.CountActiveById(db, gateID)
+	if err != nil {
+		c.Log.Warnf("Failed count gate : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+	if total == 0 {
+		return fiber.NewError(fiber.StatusNotFound, constants.GateNotFoundMessage)
+	}
+	return nil
+}
+
+// GateSignature computes the HMAC-SHA256 a gate sends in X-Gate-Signature
+// (hex encoded). The signed string is the method, path with query,
+// timestamp, nonce and hex SHA-256 of the body, separated by newlines.
+func GateSignature(secret string, method string, path string, timestamp string, nonce string, body []byte) []byte {
+	bodyHash := sha256.Sum256(body)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strings.Join([]string{
+		strings.ToUpper(method),
+		path,
+		timestamp,
+		nonce,
+		hex.EncodeToString(bodyHash[:]),
+	}, "\n")))
+	return mac.Sum(nil)
+}
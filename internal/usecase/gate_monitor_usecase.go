@@ -0,0 +1,286 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"test-kerja-mkp/internal/constants"
+	"test-kerja-mkp/internal/entity"
+	"test-kerja-mkp/internal/helper"
+	"test-kerja-mkp/internal/model"
+	"test-kerja-mkp/internal/model/converter"
+	"test-kerja-mkp/internal/repository"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	silentGateBatchSize = 500
+	gateHistoryLimit    = 100
+)
+
+// GateMonitorUseCase tracks whether gates are up. Gates report through
+// heartbeats and a background monitor flips the gates that stayed silent for
+// longer than HeartbeatTimeout to offline. Every status change is written to
+// the status history.
+type GateMonitorUseCase struct {
+	DB                          *gorm.DB
+	Log                         *logrus.Logger
+	Validate                    *validator.Validate
+	GateRepository              *repository.GateRepository
+	GateStatusHistoryRepository *repository.GateStatusHistoryRepository
+	TerminalRepository          *repository.TerminalRepository
+	EventBroker                 *helper.EventBroker
+	HeartbeatTimeout            time.Duration
+	MonitorInterval             time.Duration
+}
+
+func NewGateMonitorUseCase(db *gorm.DB, log *logrus.Logger, validate *validator.Validate, gateRepository *repository.GateRepository,
+	gateStatusHistoryRepository *repository.GateStatusHistoryRepository, terminalRepository *repository.TerminalRepository,
+	eventBroker *helper.EventBroker, heartbeatTimeout time.Duration, monitorInterval time.Duration) *GateMonitorUseCase {
+	return &GateMonitorUseCase{
+		DB:                          db,
+		Log:                         log,
+		Validate:                    validate,
+		GateRepository:              gateRepository,
+		GateStatusHistoryRepository: gateStatusHistoryRepository,
+		TerminalRepository:          terminalRepository,
+		EventBroker:                 eventBroker,
+		HeartbeatTimeout:            heartbeatTimeout,
+		MonitorInterval:             monitorInterval,
+	}
+}
+
+// Heartbeat records a heartbeat of the authenticated gate. The clock offset is
+// the device time minus the server time, positive when the device is ahead.
+func (c *GateMonitorUseCase) Heartbeat(ctx context.Context, auth *model.AuthGate, request *model.GateHeartbeatRequest) (*model.GateHeartbeatResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	gate := new(entity.Gate)
+	if err := c.GateRepository.FindByIdForUpdate(tx, gate, auth.GateID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fiber.NewError(fiber.StatusNotFound, constants.GateNotFoundMessage)
+		}
+		c.Log.Warnf("Failed find gate : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if gate.IsDecommissioned() {
+		return nil, fiber.NewError(fiber.StatusConflict, constants.GateDecommissionedMessage)
+	}
+
+	status := request.Status
+	if status == "" {
+		status = entity.GateStatusOnline
+	}
+
+	now := time.Now()
+	offset := request.LocalTime.Sub(now).Milliseconds()
+	syncErrorReason := heartbeatSyncError(gate, status, request.PendingOfflineCount)
+	previousCount := gate.PendingOfflineCount
+	history := gate.ChangeStatus(status, entity.GateStatusReasonHeartbeat, now)
+	gate.LastHeartbeatAt = &now
+	gate.FirmwareVersion = &request.FirmwareVersion
+	gate.ClockOffsetMs = &offset
+	gate.PendingOfflineCount = request.PendingOfflineCount
+	if err := c.GateRepository.Update(tx.Omit(clause.Associations), gate); err != nil {
+		c.Log.Warnf("Failed update gate : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if history != nil {
+		if err := c.GateStatusHistoryRepository.Create(tx, history); err != nil {
+			c.Log.Warnf("Failed create gate status history : %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed commit transaction : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if history != nil {
+		c.EventBroker.Publish(constants.EventGateStatus, gate.TerminalID, converter.GateStatusToEvent(gate, history))
+	}
+	if syncErrorReason != "" {
+		c.EventBroker.Publish(constants.EventSyncError, gate.TerminalID, converter.GateSyncErrorToEvent(gate, syncErrorReason, previousCount, now))
+	}
+
+	return &model.GateHeartbeatResponse{
+		ServerTime:    now,
+		Status:        gate.Status,
+		ClockOffsetMs: offset,
+	}, nil
+}
+
+// MarkSilentGatesOffline flips the gates without a heartbeat since now minus
+// HeartbeatTimeout to offline and returns how many were changed. Gates locked
+// by a heartbeat or by another instance are skipped and picked up next run.
+func (c *GateMonitorUseCase) MarkSilentGatesOffline(ctx context.Context, now time.Time) (int, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	gates, err := c.GateRepository.FindSilentForUpdate(tx, now.Add(-c.HeartbeatTimeout), silentGateBatchSize)
+	if err != nil {
+		c.Log.Warnf("Failed find silent gates : %+v", err)
+		return 0, err
+	}
+
+	histories := make([]*entity.GateStatusHistory, 0, len(gates))
+	for _, gate := range gates {
+		history := gate.ChangeStatus(entity.GateStatusOffline, entity.GateStatusReasonSilence, now)
+		histories = append(histories, history)
+		if err := c.GateRepository.Update(tx.Omit(clause.Associations), gate); err != nil {
+			c.Log.Warnf("Failed update gate : %+v", err)
+			return 0, err
+		}
+		if err := c.GateStatusHistoryRepository.Create(tx, history); err != nil {
+			c.Log.Warnf("Failed create gate status history : %+v", err)
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed commit transaction : %+v", err)
+		return 0, err
+	}
+
+	for i, gate := range gates {
+		c.EventBroker.Publish(constants.EventGateStatus, gate.TerminalID, converter.GateStatusToEvent(gate, histories[i]))
+	}
+	return len(gates), nil
+}
+
+// Start runs MarkSilentGatesOffline every MonitorInterval in the background.
+// A zero interval disables the monitor.
+func (c *GateMonitorUseCase) Start() {
+	if c.MonitorInterval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(c.MonitorInterval)
+		defer ticker.Stop()
+		for now := range ticker.C {
+			total, err := c.MarkSilentGatesOffline(context.Background(), now)
+			if err != nil {
+				c.Log.Warnf("Failed mark silent gates offline : %+v", err)
+				continue
+			}
+			if total > 0 {
+				c.Log.Infof("Marked %d silent gates offline", total)
+			}
+		}
+	}()
+}
+
+// Dashboard returns the status of the gates in service of the terminal. Gates
+// that are down come first, the longest down at the top.
+func (c *GateMonitorUseCase) Dashboard(ctx context.Context, terminalID int64) (*model.TerminalGateStatusResponse, error) {
+	db := c.DB.WithContext(ctx)
+	if err := c.ensureTerminalExists(db, terminalID); err != nil {
+		return nil, err
+	}
+
+	gates, err := c.GateRepository.FindAllByTerminalId(db, terminalID, false)
+	if err != nil {
+		c.Log.Warnf("Failed find gates : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	response := &model.TerminalGateStatusResponse{
+		TerminalID: terminalID,
+		Total:      len(gates),
+		Gates:      make([]*model.GateStatusResponse, 0, len(gates)),
+	}
+	for _, gate := range gates {
+		if gate.IsDown() {
+			response.Down++
+		} else {
+			response.Online++
+		}
+		response.Gates = append(response.Gates, converter.GateToStatusResponse(gate))
+	}
+
+	slices.SortStableFunc(response.Gates, func(a, b *model.GateStatusResponse) int {
+		if a.Down != b.Down {
+			if a.Down {
+				return -1
+			}
+			return 1
+		}
+		switch {
+		case !a.Down || a.Since == b.Since:
+			return 0
+		case a.Since == nil:
+			return -1
+		case b.Since == nil:
+			return 1
+		}
+		return a.Since.Compare(*b.Since)
+	})
+
+	return response, nil
+}
+
+// History returns the latest status changes of a gate of the terminal.
+func (c *GateMonitorUseCase) History(ctx context.Context, terminalID int64, gateID int64) ([]*model.GateStatusHistoryResponse, error) {
+	db := c.DB.WithContext(ctx)
+
+	gate := new(entity.Gate)
+	if err := c.GateRepository.FindByIdAndTerminalId(db, gate, gateID, terminalID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fiber.NewError(fiber.StatusNotFound, constants.GateNotFoundMessage)
+		}
+		c.Log.Warnf("Failed find gate : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	histories, err := c.GateStatusHistoryRepository.FindAllByGateId(db, gate.ID, gateHistoryLimit)
+	if err != nil {
+		c.Log.Warnf("Failed find gate status history : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	responses := make([]*model.GateStatusHistoryResponse, 0, len(histories))
+	for _, history := range histories {
+		responses = append(responses, converter.GateStatusHistoryToResponse(history))
+	}
+	return responses, nil
+}
+
+func (c *GateMonitorUseCase) ensureTerminalExists(db *gorm.DB, terminalID int64) error {
+	total, err := c.TerminalRepository.CountById(db, "id_terminal", terminalID)
+	if err != nil {
+		c.Log.Warnf("Failed count terminal : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+	if total == 0 {
+		return fiber.NewError(fiber.StatusNotFound, constants.TerminalNotFoundMessage)
+	}
+	return nil
+}
+
+// heartbeatSyncError returns why a heartbeat should raise a sync.error event,
+// or an empty string. A gate entering the error status is reported once, and
+// a gate whose offline backlog grew since its last heartbeat on every
+// heartbeat until the backlog stops growing.
+func heartbeatSyncError(gate *entity.Gate, status string, pendingOfflineCount int) string {
+	switch {
+	case status == entity.GateStatusError && gate.Status != entity.GateStatusError:
+		return constants.SyncErrorReasonGateError
+	case gate.LastHeartbeatAt != nil && pendingOfflineCount > gate.PendingOfflineCount:
+		return constants.SyncErrorReasonPendingGrowing
+	}
+	return ""
+}
@@ -0,0 +1,310 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"test-kerja-mkp/internal/constants"
+	"test-kerja-mkp/internal/entity"
+	"test-kerja-mkp/internal/helper"
+	"test-kerja-mkp/internal/model"
+	"test-kerja-mkp/internal/model/converter"
+	"test-kerja-mkp/internal/repository"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GateUseCase manages the gates of terminals. Gate numbers are unique among
+// the gates in service of a terminal; every change locks the terminal row so
+// concurrent requests cannot both claim a number. The partial unique index
+// uq_gates_terminal_number backs this up and surfaces as a 409 as well.
+type GateUseCase struct {
+	DB                          *gorm.DB
+	Log                         *logrus.Logger
+	Validate                    *validator.Validate
+	GateRepository              *repository.GateRepository
+	GateCredentialRepository    *repository.GateCredentialRepository
+	GateStatusHistoryRepository *repository.GateStatusHistoryRepository
+	TerminalRepository          *repository.TerminalRepository
+	EventBroker                 *helper.EventBroker
+}
+
+func NewGateUseCase(db *gorm.DB, log *logrus.Logger, validate *validator.Validate, gateRepository *repository.GateRepository,
+	gateCredentialRepository *repository.GateCredentialRepository, gateStatusHistoryRepository *repository.GateStatusHistoryRepository,
+	terminalRepository *repository.TerminalRepository, eventBroker *helper.EventBroker) *GateUseCase {
+	return &GateUseCase{
+		DB:                          db,
+		Log:                         log,
+		Validate:                    validate,
+		GateRepository:              gateRepository,
+		GateCredentialRepository:    gateCredentialRepository,
+		GateStatusHistoryRepository: gateStatusHistoryRepository,
+		TerminalRepository:          terminalRepository,
+		EventBroker:                 eventBroker,
+	}
+}
+
+func (c *GateUseCase) FindAllByTerminalId(ctx context.Context, terminalID int64, includeDecommissioned bool) ([]*model.GateResponse, error) {
+	db := c.DB.WithContext(ctx)
+
+	total, err := c.TerminalRepository.CountById(db, "id_terminal", terminalID)
+	if err != nil {
+		c.Log.Warnf("Failed count terminal : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if total == 0 {
+		return nil, fiber.NewError(fiber.StatusNotFound, constants.TerminalNotFoundMessage)
+	}
+
+	gates, err := c.GateRepository.FindAllByTerminalId(db, terminalID, includeDecommissioned)
+	if err != nil {
+		c.Log.Warnf("Failed find gates : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	responses := make([]*model.GateResponse, 0, len(gates))
+	for _, gate := range gates {
+		responses = append(responses, converter.GateToResponse(gate))
+	}
+	return responses, nil
+}
+
+func (c *GateUseCase) Create(ctx context.Context, request *model.CreateGateRequest) (*model.GateResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	audit := helper.Audit(ctx)
+	audit.Action = constants.AuditActionGateCreate
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	if err := c.lockTerminal(tx, request.TerminalID); err != nil {
+		return nil, err
+	}
+	if err := c.ensureUniqueGateNumber(tx, request.TerminalID, request.GateNumber, 0); err != nil {
+		return nil, err
+	}
+
+	gate := &entity.Gate{
+		TerminalID: request.TerminalID,
+		GateNumber: request.GateNumber,
+		Status:     entity.GateStatusOffline,
+	}
+	if err := c.GateRepository.Create(tx, gate); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, fiber.NewError(fiber.StatusConflict, constants.GateNumberAlreadyExistsMessage)
+		}
+		c.Log.Warnf("Failed create gate : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed commit transaction : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	response := converter.GateToResponse(gate)
+	audit.SetEntity(constants.AuditEntityGate, gate.ID)
+	audit.SetChange(nil, response)
+
+	return response, nil
+}
+
+func (c *GateUseCase) Rename(ctx context.Context, request *model.RenameGateRequest) (*model.GateResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	audit := helper.Audit(ctx)
+	audit.Action = constants.AuditActionGateRename
+	audit.SetEntity(constants.AuditEntityGate, request.ID)
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	if err := c.lockTerminal(tx, request.TerminalID); err != nil {
+		return nil, err
+	}
+	gate := new(entity.Gate)
+	if err := c.findGateInService(tx, gate, request.ID, request.TerminalID); err != nil {
+		return nil, err
+	}
+	before := converter.GateToResponse(gate)
+
+	if err := c.ensureUniqueGateNumber(tx, request.TerminalID, request.GateNumber, gate.ID); err != nil {
+		return nil, err
+	}
+
+	gate.GateNumber = request.GateNumber
+	if err := c.GateRepository.Update(tx.Omit(clause.Associations), gate); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, fiber.NewError(fiber.StatusConflict, constants.GateNumberAlreadyExistsMessage)
+		}
+		c.Log.Warnf("Failed update gate : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed commit transaction : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	response := converter.GateToResponse(gate)
+	audit.SetChange(before, response)
+
+	return response, nil
+}
+
+// Move reassigns a gate to another terminal. Both terminals are locked, in id
+// order to avoid deadlocks with a move in the opposite direction.
+func (c *GateUseCase) Move(ctx context.Context, request *model.MoveGateRequest) (*model.GateResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	audit := helper.Audit(ctx)
+	audit.Action = constants.AuditActionGateMove
+	audit.SetEntity(constants.AuditEntityGate, request.ID)
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	first, second := request.TerminalID, request.TargetTerminalID
+	if second < first {
+		first, second = second, first
+	}
+	if err := c.lockTerminal(tx, first); err != nil {
+		return nil, err
+	}
+	if second != first {
+		if err := c.lockTerminal(tx, second); err != nil {
+			return nil, err
+		}
+	}
+
+	gate := new(entity.Gate)
+	if err := c.findGateInService(tx, gate, request.ID, request.TerminalID); err != nil {
+		return nil, err
+	}
+	before := converter.GateToResponse(gate)
+
+	if request.TargetTerminalID != gate.TerminalID {
+		if err := c.ensureUniqueGateNumber(tx, request.TargetTerminalID, gate.GateNumber, gate.ID); err != nil {
+			return nil, err
+		}
+
+		gate.TerminalID = request.TargetTerminalID
+		if err := c.GateRepository.Update(tx.Omit(clause.Associations), gate); err != nil {
+			if errors.Is(err, gorm.ErrDuplicatedKey) {
+				return nil, fiber.NewError(fiber.StatusConflict, constants.GateNumberAlreadyExistsMessage)
+			}
+			c.Log.Warnf("Failed move gate : %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed commit transaction : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	response := converter.GateToResponse(gate)
+	audit.SetChange(before, response)
+
+	return response, nil
+}
+
+// Decommission takes a gate out of service for good. The row stays for the
+// journeys and transactions recorded through it, its credentials are revoked
+// and its gate number becomes free again.
+func (c *GateUseCase) Decommission(ctx context.Context, terminalID int64, id int64) error {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	audit := helper.Audit(ctx)
+	audit.Action = constants.AuditActionGateDecommission
+	audit.SetEntity(constants.AuditEntityGate, id)
+
+	if err := c.lockTerminal(tx, terminalID); err != nil {
+		return err
+	}
+	gate := new(entity.Gate)
+	if err := c.findGateInService(tx, gate, id, terminalID); err != nil {
+		return err
+	}
+	before := converter.GateToResponse(gate)
+
+	now := time.Now()
+	history := gate.ChangeStatus(entity.GateStatusDecommissioned, entity.GateStatusReasonDecommission, now)
+	gate.DecommissionedAt = &now
+	if err := c.GateRepository.Update(tx.Omit(clause.Associations), gate); err != nil {
+		c.Log.Warnf("Failed decommission gate : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+	if err := c.GateStatusHistoryRepository.Create(tx, history); err != nil {
+		c.Log.Warnf("Failed create gate status history : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	if err := c.GateCredentialRepository.RevokeAllByGateId(tx, gate.ID, now); err != nil {
+		c.Log.Warnf("Failed revoke gate credentials : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed commit transaction : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	c.EventBroker.Publish(constants.EventGateStatus, gate.TerminalID, converter.GateStatusToEvent(gate, history))
+	audit.SetChange(before, converter.GateToResponse(gate))
+
+	return nil
+}
+
+func (c *GateUseCase) lockTerminal(db *gorm.DB, terminalID int64) error {
+	terminal := new(entity.Terminal)
+	if err := c.TerminalRepository.FindById(db.Clauses(clause.Locking{Strength: "UPDATE"}), terminal, "id_terminal", terminalID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, constants.TerminalNotFoundMessage)
+		}
+		c.Log.Warnf("Failed find terminal : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+	return nil
+}
+
+func (c *GateUseCase) findGateInService(db *gorm.DB, gate *entity.Gate, id int64, terminalID int64) error {
+	if err := c.GateRepository.FindByIdAndTerminalIdForUpdate(db, gate, id, terminalID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, constants.GateNotFoundMessage)
+		}
+		c.Log.Warnf("Failed find gate : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+	if gate.IsDecommissioned() {
+		return fiber.NewError(fiber.StatusConflict, constants.GateDecommissionedMessage)
+	}
+	return nil
+}
+
+func (c *GateUseCase) ensureUniqueGateNumber(db *gorm.DB, terminalID int64, gateNumber string, excludeID int64) error {
+	total, err := c.GateRepository.CountByGateNumber(db, terminalID, gateNumber, excludeID)
+	if err != nil {
+		c.Log.Warnf("Failed count gate number : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+	if total > 0 {
+		return fiber.NewError(fiber.StatusConflict, constants.GateNumberAlreadyExistsMessage)
+	}
+	return nil
+}
@@ -0,0 +1,305 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"test-kerja-mkp/internal/constants"
+	"test-kerja-mkp/internal/entity"
+	"test-kerja-mkp/internal/helper"
+	"test-kerja-mkp/internal/model"
+	"test-kerja-mkp/internal/model/converter"
+	"test-kerja-mkp/internal/repository"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// JourneyUseCase runs the online journey flow of gates. A check-in opens a
+// journey at the terminal of the gate and holds the highest fare from that
+// terminal; nothing is charged until check-out. When the card has a product
+// valid on the day of the check-in, its discount applies to both the held and
+// the charged fare.
+type JourneyUseCase struct {
+	DB                      *gorm.DB
+	Log                     *logrus.Logger
+	Validate                *validator.Validate
+	CardRepository          *repository.CardRepository
+	JourneyRepository       *repository.JourneyRepository
+	TransactionRepository   *repository.TransactionRepository
+	FareMatrixRepository    *repository.FareMatrixRepository
+	CardProductRepository   *repository.CardProductRepository
+	TerminalRepository      *repository.TerminalRepository
+	TerminalScheduleUseCase *TerminalScheduleUseCase
+	EventBroker             *helper.EventBroker
+}
+
+func NewJourneyUseCase(db *gorm.DB, log *logrus.Logger, validate *validator.Validate, cardRepository *repository.CardRepository,
+	journeyRepository *repository.JourneyRepository, transactionRepository *repository.TransactionRepository,
+	fareMatrixRepository *repository.FareMatrixRepository, cardProductRepository *repository.CardProductRepository,
+	terminalRepository *repository.TerminalRepository,
+	terminalScheduleUseCase *TerminalScheduleUseCase, eventBroker *helper.EventBroker) *JourneyUseCase {
+	return &JourneyUseCase{
+		DB:                      db,
+		Log:                     log,
+		Validate:                validate,
+		CardRepository:          cardRepository,
+		JourneyRepository:       journeyRepository,
+		TransactionRepository:   transactionRepository,
+		FareMatrixRepository:    fareMatrixRepository,
+		CardProductRepository:   cardProductRepository,
+		TerminalRepository:      terminalRepository,
+		TerminalScheduleUseCase: terminalScheduleUseCase,
+		EventBroker:             eventBroker,
+	}
+}
+
+// CheckIn opens a journey for the card at the gate. Hotlisted cards are
+// refused with 403 so the gate can retain them, as are inactive cards, cards
+// already travelling and cards whose balance does not cover the maximum fare.
+func (c *JourneyUseCase) CheckIn(ctx context.Context, gate *model.AuthGate, request *model.CheckInRequest) (*model.JourneyGateResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	// The share lock keeps the terminal from being deleted until the journey
+	// is committed.
+	terminal := new(entity.Terminal)
+	if err := c.TerminalRepository.FindById(tx.Clauses(clause.Locking{Strength: "SHARE"}), terminal, "id_terminal", gate.TerminalID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fiber.NewError(fiber.StatusNotFound, constants.TerminalNotFoundMessage)
+		}
+		c.Log.Warnf("Failed find terminal : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	now := time.Now()
+	if err := c.TerminalScheduleUseCase.EnsureOpen(tx, gate.TerminalID, now); err != nil {
+		return nil, err
+	}
+
+	card := new(entity.Card)
+	if err := c.CardRepository.FindByIdForUpdate(tx, card, request.CardNumber); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fiber.NewError(fiber.StatusNotFound, constants.CardNotFoundMessage)
+		}
+		c.Log.Warnf("Failed find card : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if card.IsHotlisted() {
+		c.Log.Warnf("Hotlisted card %d presented at gate %d", card.CardNumber, gate.GateID)
+		return nil, fiber.NewError(fiber.StatusForbidden, constants.CardHotlistedMessage)
+	}
+	if card.Status != entity.CardStatusActive {
+		return nil, fiber.NewError(fiber.StatusConflict, constants.CardNotActiveMessage)
+	}
+
+	active, err := c.JourneyRepository.CountActiveByCardNumber(tx, card.CardNumber)
+	if err != nil {
+		c.Log.Warnf("Failed count active journeys : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if active > 0 {
+		return nil, fiber.NewError(fiber.StatusConflict, constants.JourneyAlreadyActiveMessage)
+	}
+
+	date := now.In(c.TerminalScheduleUseCase.Location).Format(time.DateOnly)
+	maxFare, err := c.FareMatrixRepository.FindMaxFareFrom(tx, gate.TerminalID, date)
+	if err != nil {
+		c.Log.Warnf("Failed find max fare : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if maxFare <= 0 {
+		return nil, fiber.NewError(fiber.StatusUnprocessableEntity, constants.NoFareFromTerminalMessage)
+	}
+
+	var productID *int64
+	if card.ProductID != nil {
+		product := new(entity.CardProduct)
+		if err := c.CardProductRepository.FindById(tx, product, "id_product", *card.ProductID); err != nil {
+			c.Log.Warnf("Failed find card product : %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+		if product.IsValidOn(date) {
+			productID = &product.ID
+			maxFare = product.Apply(maxFare)
+		}
+	}
+	if card.Balance < maxFare {
+		return nil, fiber.NewError(fiber.StatusUnprocessableEntity, constants.InsufficientBalanceMessage)
+	}
+
+	journey := &entity.Journey{
+		// journeys.id_journey is VARCHAR(32), too short for a hyphenated UUID.
+		ID:             strings.ReplaceAll(uuid.New().String(), "-", ""),
+		CardNumber:     card.CardNumber,
+		OriginTerminal: gate.TerminalID,
+		CheckInGate:    gate.GateID,
+		CheckInTime:    now,
+		MaxFareHeld:    maxFare,
+		ProductID:      productID,
+		Status:         entity.JourneyStatusActive,
+	}
+	if err := c.JourneyRepository.Create(tx, journey); err != nil {
+		c.Log.Warnf("Failed create journey : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	transaction := &entity.Transaction{
+		CardNumber:    card.CardNumber,
+		JourneyID:     &journey.ID,
+		Type:          entity.TransactionTypeCheckIn,
+		BalanceBefore: card.Balance,
+		BalanceAfter:  card.Balance,
+		GateID:        &gate.GateID,
+		TerminalID:    &gate.TerminalID,
+		Timestamp:     now,
+		SyncStatus:    entity.SyncStatusSynced,
+	}
+	if err := c.TransactionRepository.Create(tx, transaction); err != nil {
+		c.Log.Warnf("Failed create check-in transaction : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed commit transaction : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	c.EventBroker.Publish(constants.EventJourneyCheckIn, gate.TerminalID, converter.JourneyToCheckInEvent(journey, gate))
+
+	return &model.JourneyGateResponse{
+		Journey: converter.JourneyToResponse(journey),
+		Balance: card.Balance.String(),
+	}, nil
+}
+
+// CheckOut closes the active journey of the card at the gate and charges the
+// fare of the route in effect on the day of the check-in, discounted by the
+// product of the journey. Leaving through a route without a fare, such as the
+// terminal of the check-in, charges the held fare and ends the journey as a
+// penalty. Check-out is allowed whether the terminal is open or not, and for
+// cards blocked during the journey, so riders are never stuck behind a gate.
+func (c *JourneyUseCase) CheckOut(ctx context.Context, gate *model.AuthGate, request *model.CheckOutRequest) (*model.JourneyGateResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	card := new(entity.Card)
+	if err := c.CardRepository.FindByIdForUpdate(tx, card, request.CardNumber); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fiber.NewError(fiber.StatusNotFound, constants.CardNotFoundMessage)
+		}
+		c.Log.Warnf("Failed find card : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	journey := new(entity.Journey)
+	if err := c.JourneyRepository.FindActiveByCardNumberForUpdate(tx, journey, card.CardNumber); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fiber.NewError(fiber.StatusConflict, constants.NoActiveJourneyMessage)
+		}
+		c.Log.Warnf("Failed find active journey : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	fare, regularFare, err := c.journeyFare(tx, journey, gate.TerminalID)
+	if err != nil {
+		return nil, err
+	}
+	if card.Balance < fare {
+		return nil, fiber.NewError(fiber.StatusUnprocessableEntity, constants.InsufficientBalanceMessage)
+	}
+
+	now := time.Now()
+	transaction := &entity.Transaction{
+		CardNumber:    card.CardNumber,
+		JourneyID:     &journey.ID,
+		Type:          entity.TransactionTypeCheckOut,
+		Amount:        -fare,
+		BalanceBefore: card.Balance,
+		BalanceAfter:  card.Balance - fare,
+		GateID:        &gate.GateID,
+		TerminalID:    &gate.TerminalID,
+		Timestamp:     now,
+		SyncStatus:    entity.SyncStatusSynced,
+	}
+	if err := c.TransactionRepository.Create(tx, transaction); err != nil {
+		c.Log.Warnf("Failed create check-out transaction : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	duration := int(now.Sub(journey.CheckInTime).Minutes())
+	journey.DestinationTerminal = &gate.TerminalID
+	journey.CheckOutGate = &gate.GateID
+	journey.CheckOutTime = &now
+	journey.RegularFare = regularFare
+	journey.FareCharged = &fare
+	journey.TravelDuration = &duration
+	journey.Status = entity.JourneyStatusCompleted
+	if regularFare == nil {
+		journey.Status = entity.JourneyStatusPenalty
+	}
+	if err := c.JourneyRepository.Update(tx, journey); err != nil {
+		c.Log.Warnf("Failed update journey : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	card.Balance = transaction.BalanceAfter
+	if err := c.CardRepository.Update(tx, card); err != nil {
+		c.Log.Warnf("Failed update card balance : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed commit transaction : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	c.EventBroker.Publish(constants.EventJourneyCheckOut, gate.TerminalID, converter.JourneyToCheckOutEvent(journey, gate))
+
+	return &model.JourneyGateResponse{
+		Journey: converter.JourneyToResponse(journey),
+		Balance: card.Balance.String(),
+	}, nil
+}
+
+// journeyFare returns the fare to charge for the journey ending at the
+// terminal and the regular fare it was computed from, nil when the route has
+// no fare and the held fare is charged instead. The fare never exceeds the
+// held fare, even if the product changed during the journey.
+func (c *JourneyUseCase) journeyFare(db *gorm.DB, journey *entity.Journey, terminalID int64) (entity.Money, *entity.Money, error) {
+	date := journey.CheckInTime.In(c.TerminalScheduleUseCase.Location).Format(time.DateOnly)
+	route := new(entity.FareMatrix)
+	if err := c.FareMatrixRepository.FindFare(db, route, journey.OriginTerminal, terminalID, date); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return journey.MaxFareHeld, nil, nil
+		}
+		c.Log.Warnf("Failed find fare : %+v", err)
+		return 0, nil, fiber.ErrInternalServerError
+	}
+
+	fare := route.RegularFare
+	if journey.ProductID != nil {
+		product := new(entity.CardProduct)
+		if err := c.CardProductRepository.FindById(db, product, "id_product", *journey.ProductID); err != nil {
+			c.Log.Warnf("Failed find card product : %+v", err)
+			return 0, nil, fiber.ErrInternalServerError
+		}
+		fare = product.Apply(fare)
+	}
+	return min(fare, journey.MaxFareHeld), &route.RegularFare, nil
+}
@@ -0,0 +1,148 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"test-kerja-mkp/internal/constants"
+	"test-kerja-mkp/internal/entity"
+	"test-kerja-mkp/internal/repository"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// LoginThrottlePolicy configures brute-force protection of the admin login.
+type LoginThrottlePolicy struct {
+	// UsernameThreshold is the number of failures after which a username is locked.
+	UsernameThreshold int
+	// IPThreshold is the number of failures after which a client IP is locked.
+	IPThreshold int
+	// LockDuration is how long a locked username or IP stays locked.
+	LockDuration time.Duration
+	// Window is how long a failure is remembered before the counter restarts.
+	Window time.Duration
+	// BaseDelay is the delay required after the first failure, doubling with
+	// every following failure up to MaxDelay.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+}
+
+type LoginThrottleUseCase struct {
+	DB                      *gorm.DB
+	Log                     *logrus.Logger
+	LoginThrottleRepository *repository.LoginThrottleRepository
+	AuthRepository          *repository.AuthRepository
+	Policy                  LoginThrottlePolicy
+}
+
+func NewLoginThrottleUseCase(db *gorm.DB, log *logrus.Logger, loginThrottleRepository *repository.LoginThrottleRepository,
+	authRepository *repository.AuthRepository, policy LoginThrottlePolicy) *LoginThrottleUseCase {
+	return &LoginThrottleUseCase{
+		DB:                      db,
+		Log:                     log,
+		LoginThrottleRepository: loginThrottleRepository,
+		AuthRepository:          authRepository,
+		Policy:                  policy,
+	}
+}
+
+// Check refuses the login attempt when the username or the client IP is
+// locked (423 and 429) or when it comes before the progressive delay of the
+// previous failure has passed (429). The same message is used whether or not
+// the username exists.
+func (c *LoginThrottleUseCase) Check(ctx context.Context, username string, ip string) error {
+	throttles, err := c.LoginThrottleRepository.FindByKeys(c.DB.WithContext(ctx), []string{usernameThrottleKey(username), ipThrottleKey(ip)})
+	if err != nil {
+		c.Log.Warnf("Failed find login throttle : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	now := time.Now()
+	for _, throttle := range throttles {
+		if throttle.IsLocked(now) {
+			c.Log.Warnf("Login attempt for locked key %s", throttle.Key)
+			if strings.HasPrefix(throttle.Key, "ip:") {
+				return fiber.NewError(fiber.StatusTooManyRequests, constants.TooManyLoginAttemptsMessage)
+			}
+			return fiber.NewError(fiber.StatusLocked, constants.TooManyLoginAttemptsMessage)
+		}
+
+		if throttle.LastFailedAt.Before(now.Add(-c.Policy.Window)) {
+			continue
+		}
+		if now.Before(throttle.LastFailedAt.Add(c.delay(throttle.FailedCount))) {
+			c.Log.Warnf("Login attempt for key %s before progressive delay", throttle.Key)
+			return fiber.NewError(fiber.StatusTooManyRequests, constants.TooManyLoginAttemptsMessage)
+		}
+	}
+
+	return nil
+}
+
+// RecordFailure counts a failed login for both the username and the client IP.
+// It runs outside of the login transaction so the failure is kept even though
+// the login itself is rolled back.
+func (c *LoginThrottleUseCase) RecordFailure(ctx context.Context, username string, ip string) {
+	db := c.DB.WithContext(ctx)
+	now := time.Now()
+	windowStart := now.Add(-c.Policy.Window)
+	lockUntil := now.Add(c.Policy.LockDuration)
+
+	if err := c.LoginThrottleRepository.RecordFailure(db, usernameThrottleKey(username), now, windowStart, c.Policy.UsernameThreshold, lockUntil); err != nil {
+		c.Log.Warnf("Failed record login failure for username : %+v", err)
+	}
+	if err := c.LoginThrottleRepository.RecordFailure(db, ipThrottleKey(ip), now, windowStart, c.Policy.IPThreshold, lockUntil); err != nil {
+		c.Log.Warnf("Failed record login failure for ip : %+v", err)
+	}
+}
+
+// RecordSuccess clears the failure counter of the username.
+func (c *LoginThrottleUseCase) RecordSuccess(ctx context.Context, username string) {
+	if err := c.LoginThrottleRepository.DeleteByKey(c.DB.WithContext(ctx), usernameThrottleKey(username)); err != nil {
+		c.Log.Warnf("Failed reset login throttle : %+v", err)
+	}
+}
+
+// Unlock lifts the lockout of an admin account.
+func (c *LoginThrottleUseCase) Unlock(ctx context.Context, adminID int64) error {
+	db := c.DB.WithContext(ctx)
+
+	admin := new(entity.Admin)
+	if err := c.AuthRepository.FindById(db, admin, "id_admin", adminID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.ErrNotFound
+		}
+		c.Log.Warnf("Failed to find admin by ID: %+v ", err)
+		return fiber.ErrInternalServerError
+	}
+
+	if err := c.LoginThrottleRepository.DeleteByKey(db, usernameThrottleKey(admin.Username)); err != nil {
+		c.Log.Warnf("Failed unlock admin : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+	return nil
+}
+
+// delay returns the wait required after the given number of failures.
+func (c *LoginThrottleUseCase) delay(failedCount int) time.Duration {
+	if failedCount < 1 || c.Policy.BaseDelay <= 0 {
+		return 0
+	}
+
+	delay := c.Policy.BaseDelay
+	for i := 1; i < failedCount && delay < c.Policy.MaxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, c.Policy.MaxDelay)
+}
+
+func usernameThrottleKey(username string) string {
+	return "username:" + strings.ToLower(strings.TrimSpace(username))
+}
+
+func ipThrottleKey(ip string) string {
+	return "ip:" + ip
+}
@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// maxPasswordBytes is the longest password bcrypt accepts. The request
+// validators count runes, so multi-byte passwords are caught here.
+const maxPasswordBytes = 72
+
+// PasswordPolicy describes the rules a new admin password must satisfy.
+type PasswordPolicy struct {
+	MinLength        int
+	RequireUppercase bool
+	RequireLowercase bool
+	RequireDigit     bool
+	RequireSymbol    bool
+	// HistorySize is the number of previous passwords that cannot be reused.
+	HistorySize int
+}
+
+// Check returns a human readable list of every rule the password violates.
+func (p PasswordPolicy) Check(password string) []string {
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			hasSymbol = true
+		}
+	}
+
+	var violations []string
+	if len([]rune(password)) < p.MinLength {
+		violations = append(violations, fmt.Sprintf("at least %d characters", p.MinLength))
+	}
+	if len(password) > maxPasswordBytes {
+		violations = append(violations, fmt.Sprintf("no more than %d bytes", maxPasswordBytes))
+	}
+	if p.RequireUppercase && !hasUpper {
+		violations = append(violations, "an uppercase letter")
+	}
+	if p.RequireLowercase && !hasLower {
+		violations = append(violations, "a lowercase letter")
+	}
+	if p.RequireDigit && !hasDigit {
+		violations = append(violations, "a digit")
+	}
+	if p.RequireSymbol && !hasSymbol {
+		violations = append(violations, "a symbol")
+	}
+	return violations
+}
+
+// Describe formats the violations returned by Check as a single sentence.
+func (p PasswordPolicy) Describe(violations []string) string {
+	return "Password must contain " + strings.Join(violations, ", ")
+}
@@ -0,0 +1,272 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"test-kerja-mkp/internal/constants"
+	"test-kerja-mkp/internal/entity"
+	"test-kerja-mkp/internal/helper"
+	"test-kerja-mkp/internal/model"
+	"test-kerja-mkp/internal/repository"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+const (
+	sessionRevokedReasonPasswordChange = "password_change"
+)
+
+type PasswordUseCase struct {
+	DB                             *gorm.DB
+	Log                            *logrus.Logger
+	Validate                       *validator.Validate
+	AuthRepository                 *repository.AuthRepository
+	AdminPasswordHistoryRepository *repository.AdminPasswordHistoryRepository
+	AdminPasswordResetRepository   *repository.AdminPasswordResetRepository
+	AuthUseCase                    *AuthUseCase
+	Policy                         PasswordPolicy
+	ResetTokenTTL                  time.Duration
+}
+
+func NewPasswordUseCase(db *gorm.DB, log *logrus.Logger, validate *validator.Validate, authRepository *repository.AuthRepository,
+	adminPasswordHistoryRepository *repository.AdminPasswordHistoryRepository, adminPasswordResetRepository *repository.AdminPasswordResetRepository,
+	authUseCase *AuthUseCase, policy PasswordPolicy, resetTokenTTL time.Duration) *PasswordUseCase {
+	return &PasswordUseCase{
+		DB:                             db,
+		Log:                            log,
+		Validate:                       validate,
+		AuthRepository:                 authRepository,
+		AdminPasswordHistoryRepository: adminPasswordHistoryRepository,
+		AdminPasswordResetRepository:   adminPasswordResetRepository,
+		AuthUseCase:                    authUseCase,
+		Policy:                         policy,
+		ResetTokenTTL:                  resetTokenTTL,
+	}
+}
+
+// ChangePassword lets an admin change their own password. All sessions of the
+// admin, including the current one, are revoked afterwards.
+func (c *PasswordUseCase) ChangePassword(ctx context.Context, auth *model.AuthAdmin, request *model.ChangePasswordRequest) error {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	audit := helper.Audit(ctx)
+	audit.Action = constants.AuditActionPasswordChange
+	audit.SetEntity(constants.AuditEntityAdmin, auth.ID)
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	admin := new(entity.Admin)
+	if err := c.AuthRepository.FindById(tx, admin, "id_admin", auth.ID); err != nil {
+		c.Log.Warnf("Failed to find admin by ID: %+v ", err)
+		return fiber.ErrUnauthorized
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(admin.Password), []byte(request.CurrentPassword)); err != nil {
+		c.Log.Warnf("Current password does not match: %v", err)
+		return fiber.NewError(fiber.StatusUnprocessableEntity, constants.InvalidCurrentPasswordMessage)
+	}
+
+	sessionIDs, err := c.setPassword(tx, admin, request.NewPassword)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed commit transaction : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+	c.AuthUseCase.markSessionsRevoked(sessionIDs)
+
+	return nil
+}
+
+// IssueReset creates a one-time password reset token for another admin. Any
+// previously issued token that has not been used yet stops working.
+func (c *PasswordUseCase) IssueReset(ctx context.Context, auth *model.AuthAdmin, adminID int64) (*model.PasswordResetResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	audit := helper.Audit(ctx)
+	audit.Action = constants.AuditActionPasswordIssue
+	audit.SetEntity(constants.AuditEntityAdmin, adminID)
+
+	admin := new(entity.Admin)
+	if err := c.AuthRepository.FindById(tx, admin, "id_admin", adminID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fiber.ErrNotFound
+		}
+		c.Log.Warnf("Failed to find admin by ID: %+v ", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := c.AdminPasswordResetRepository.InvalidatePending(tx, admin.ID); err != nil {
+		c.Log.Warnf("Failed invalidate pending password resets : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	token, err := randomToken(32)
+	if err != nil {
+		c.Log.Warnf("Failed generate password reset token : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	reset := &entity.AdminPasswordReset{
+		AdminID:   admin.ID,
+		TokenHash: hashToken(token),
+		CreatedBy: auth.ID,
+		ExpiresAt: time.Now().Add(c.ResetTokenTTL),
+	}
+	if err := c.AdminPasswordResetRepository.Create(tx, reset); err != nil {
+		c.Log.Warnf("Failed create password reset : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed commit transaction : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return &model.PasswordResetResponse{
+		AdminID:    admin.ID,
+		ResetToken: token,
+		ExpiresAt:  reset.ExpiresAt,
+	}, nil
+}
+
+// ResetPassword consumes a password reset token and sets a new password.
+func (c *PasswordUseCase) ResetPassword(ctx context.Context, request *model.ResetPasswordRequest) error {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	audit := helper.Audit(ctx)
+	audit.Action = constants.AuditActionPasswordReset
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	reset := new(entity.AdminPasswordReset)
+	if err := c.AdminPasswordResetRepository.FindByTokenHashForUpdate(tx, reset, hashToken(request.Token)); err != nil {
+		c.Log.Warnf("Failed find password reset : %+v", err)
+		return fiber.NewError(fiber.StatusUnprocessableEntity, constants.InvalidResetTokenMessage)
+	}
+
+	now := time.Now()
+	if reset.UsedAt != nil || reset.ExpiresAt.Before(now) {
+		c.Log.Warnf("Password reset %d already used or expired", reset.ID)
+		return fiber.NewError(fiber.StatusUnprocessableEntity, constants.InvalidResetTokenMessage)
+	}
+
+	admin := new(entity.Admin)
+	if err := c.AuthRepository.FindById(tx, admin, "id_admin", reset.AdminID); err != nil {
+		c.Log.Warnf("Failed to find admin by ID: %+v ", err)
+		return fiber.NewError(fiber.StatusUnprocessableEntity, constants.InvalidResetTokenMessage)
+	}
+	audit.SetEntity(constants.AuditEntityAdmin, admin.ID)
+	audit.SetActor(admin.ID, admin.Username)
+
+	sessionIDs, err := c.setPassword(tx, admin, request.NewPassword)
+	if err != nil {
+		return err
+	}
+
+	reset.UsedAt = &now
+	if err := c.AdminPasswordResetRepository.Update(tx, reset); err != nil {
+		c.Log.Warnf("Failed update password reset : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed commit transaction : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+	c.AuthUseCase.markSessionsRevoked(sessionIDs)
+
+	return nil
+}
+
+// hashNewPassword checks the password against the policy and returns its
+// bcrypt hash. It does not look at the password history.
+func (c *PasswordUseCase) hashNewPassword(password string) (string, error) {
+	if violations := c.Policy.Check(password); len(violations) > 0 {
+		return "", fiber.NewError(fiber.StatusUnprocessableEntity, c.Policy.Describe(violations))
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		c.Log.Warnf("Failed to generate bcrypt hash : %+v", err)
+		return "", fiber.ErrInternalServerError
+	}
+	return string(hash), nil
+}
+
+// recordHistory stores the password hash in the history of the admin.
+func (c *PasswordUseCase) recordHistory(tx *gorm.DB, adminID int64, passwordHash string) error {
+	if err := c.AdminPasswordHistoryRepository.Create(tx, &entity.AdminPasswordHistory{
+		AdminID:      adminID,
+		PasswordHash: passwordHash,
+	}); err != nil {
+		c.Log.Warnf("Failed create password history : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+	return nil
+}
+
+// setPassword validates the new password against the policy and the password
+// history, stores it and revokes every session of the admin. It returns the
+// revoked session IDs to be marked in the verify cache after commit.
+func (c *PasswordUseCase) setPassword(tx *gorm.DB, admin *entity.Admin, password string) ([]string, error) {
+	hash, err := c.hashNewPassword(password)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := []string{admin.Password}
+	if c.Policy.HistorySize > 0 {
+		histories, err := c.AdminPasswordHistoryRepository.FindLatestByAdminId(tx, admin.ID, c.Policy.HistorySize)
+		if err != nil {
+			c.Log.Warnf("Failed find password history : %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+		for _, history := range histories {
+			previous = append(previous, history.PasswordHash)
+		}
+	}
+	for _, previousHash := range previous {
+		if bcrypt.CompareHashAndPassword([]byte(previousHash), []byte(password)) == nil {
+			return nil, fiber.NewError(fiber.StatusUnprocessableEntity, constants.PasswordReusedMessage)
+		}
+	}
+
+	admin.Password = hash
+	if err := tx.Model(admin).Update("password", hash).Error; err != nil {
+		c.Log.Warnf("Failed update admin password : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := c.recordHistory(tx, admin.ID, hash); err != nil {
+		return nil, err
+	}
+
+	return c.AuthUseCase.revokeAllSessions(tx, admin.ID, sessionRevokedReasonPasswordChange)
+}
+
+func randomToken(size int) (string, error) {
+	buf := make([]byte, size)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,173 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"test-kerja-mkp/internal/constants"
+	"test-kerja-mkp/internal/entity"
+	"test-kerja-mkp/internal/helper"
+	"test-kerja-mkp/internal/model"
+	"test-kerja-mkp/internal/model/converter"
+	"test-kerja-mkp/internal/repository"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// RiderUseCase manages the riders cards are registered to.
+type RiderUseCase struct {
+	DB              *gorm.DB
+	Log             *logrus.Logger
+	Validate        *validator.Validate
+	RiderRepository *repository.RiderRepository
+	CardRepository  *repository.CardRepository
+}
+
+func NewRiderUseCase(db *gorm.DB, log *logrus.Logger, validate *validator.Validate, riderRepository *repository.RiderRepository,
+	cardRepository *repository.CardRepository) *RiderUseCase {
+	return &RiderUseCase{
+		DB:              db,
+		Log:             log,
+		Validate:        validate,
+		RiderRepository: riderRepository,
+		CardRepository:  cardRepository,
+	}
+}
+
+func (c *RiderUseCase) Create(ctx context.Context, request *model.CreateRiderRequest) (*model.RiderResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	audit := helper.Audit(ctx)
+	audit.Action = constants.AuditActionRiderCreate
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	total, err := c.RiderRepository.CountById(tx, "id_number", request.IDNumber)
+	if err != nil {
+		c.Log.Warnf("Failed count rider : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if total > 0 {
+		return nil, fiber.NewError(fiber.StatusConflict, constants.RiderAlreadyExistsMessage)
+	}
+
+	rider := &entity.Rider{
+		Name:     request.Name,
+		IDNumber: request.IDNumber,
+	}
+	if request.Phone != "" {
+		rider.Phone = &request.Phone
+	}
+	if request.Email != "" {
+		rider.Email = &request.Email
+	}
+	if err := c.RiderRepository.Create(tx, rider); err != nil {
+		c.Log.Warnf("Failed create rider : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed commit transaction : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	response := converter.RiderToResponse(rider)
+	audit.SetEntity(constants.AuditEntityRider, rider.ID)
+	audit.SetChange(nil, response)
+
+	return response, nil
+}
+
+// FindById returns the rider with its registered cards, replaced ones
+// included.
+func (c *RiderUseCase) FindById(ctx context.Context, id int64) (*model.RiderResponse, error) {
+	db := c.DB.WithContext(ctx)
+
+	rider := new(entity.Rider)
+	if err := c.RiderRepository.FindById(db, rider, "id_rider", id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fiber.NewError(fiber.StatusNotFound, constants.RiderNotFoundMessage)
+		}
+		c.Log.Warnf("Failed find rider : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	cards, err := c.CardRepository.FindAllByRiderId(db, rider.ID)
+	if err != nil {
+		c.Log.Warnf("Failed find rider cards : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	response := converter.RiderToResponse(rider)
+	response.Cards = make([]*model.CardResponse, 0, len(cards))
+	for _, card := range cards {
+		response.Cards = append(response.Cards, converter.CardToResponse(card))
+	}
+	return response, nil
+}
+
+// RegisterCard registers an active card to the rider. Registering a card
+// again to the same rider changes nothing.
+func (c *RiderUseCase) RegisterCard(ctx context.Context, request *model.RegisterCardRequest) (*model.CardResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	audit := helper.Audit(ctx)
+	audit.Action = constants.AuditActionCardRegister
+	audit.SetEntity(constants.AuditEntityCard, request.CardNumber)
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	rider := new(entity.Rider)
+	if err := c.RiderRepository.FindByIdForShare(tx, rider, request.RiderID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fiber.NewError(fiber.StatusNotFound, constants.RiderNotFoundMessage)
+		}
+		c.Log.Warnf("Failed find rider : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	card := new(entity.Card)
+	if err := c.CardRepository.FindByIdForUpdate(tx, card, request.CardNumber); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fiber.NewError(fiber.StatusNotFound, constants.CardNotFoundMessage)
+		}
+		c.Log.Warnf("Failed find card : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if card.RiderID != nil {
+		if *card.RiderID != rider.ID {
+			return nil, fiber.NewError(fiber.StatusConflict, constants.CardAlreadyRegisteredMessage)
+		}
+		return converter.CardToResponse(card), nil
+	}
+	if card.Status != entity.CardStatusActive {
+		return nil, fiber.NewError(fiber.StatusConflict, constants.CardNotActiveMessage)
+	}
+	before := converter.CardToResponse(card)
+
+	card.RiderID = &rider.ID
+	if err := c.CardRepository.Update(tx, card); err != nil {
+		c.Log.Warnf("Failed register card : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed commit transaction : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	response := converter.CardToResponse(card)
+	audit.SetChange(before, response)
+
+	return response, nil
+}
@@ -0,0 +1,329 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"test-kerja-mkp/internal/constants"
+	"test-kerja-mkp/internal/entity"
+	"test-kerja-mkp/internal/helper"
+	"test-kerja-mkp/internal/model"
+	"test-kerja-mkp/internal/model/converter"
+	"test-kerja-mkp/internal/repository"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// TerminalScheduleUseCase manages the weekly operating hours and the closures
+// of terminals. Both are evaluated in Location, the local time of the network.
+// A terminal without operating hours is open around the clock.
+type TerminalScheduleUseCase struct {
+	DB                              *gorm.DB
+	Log                             *logrus.Logger
+	Validate                        *validator.Validate
+	TerminalRepository              *repository.TerminalRepository
+	TerminalOperatingHourRepository *repository.TerminalOperatingHourRepository
+	TerminalClosureRepository       *repository.TerminalClosureRepository
+	Location                        *time.Location
+}
+
+func NewTerminalScheduleUseCase(db *gorm.DB, log *logrus.Logger, validate *validator.Validate, terminalRepository *repository.TerminalRepository,
+	terminalOperatingHourRepository *repository.TerminalOperatingHourRepository, terminalClosureRepository *repository.TerminalClosureRepository,
+	location *time.Location) *TerminalScheduleUseCase {
+	return &TerminalScheduleUseCase{
+		DB:                              db,
+		Log:                             log,
+		Validate:                        validate,
+		TerminalRepository:              terminalRepository,
+		TerminalOperatingHourRepository: terminalOperatingHourRepository,
+		TerminalClosureRepository:       terminalClosureRepository,
+		Location:                        location,
+	}
+}
+
+func (c *TerminalScheduleUseCase) FindHours(ctx context.Context, terminalID int64) ([]*model.OperatingHourResponse, error) {
+	db := c.DB.WithContext(ctx)
+	if err := c.ensureTerminalExists(db, terminalID); err != nil {
+		return nil, err
+	}
+
+	hours, err := c.TerminalOperatingHourRepository.FindAllByTerminalId(db, terminalID)
+	if err != nil {
+		c.Log.Warnf("Failed find operating hours : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return operatingHoursToResponses(hours), nil
+}
+
+func (c *TerminalScheduleUseCase) UpdateHours(ctx context.Context, request *model.UpdateOperatingHoursRequest) ([]*model.OperatingHourResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	audit := helper.Audit(ctx)
+	audit.Action = constants.AuditActionTerminalHoursUpdate
+	audit.SetEntity(constants.AuditEntityTerminal, request.TerminalID)
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	if err := c.ensureTerminalExists(tx, request.TerminalID); err != nil {
+		return nil, err
+	}
+
+	hours := make([]*entity.TerminalOperatingHour, 0, len(request.Hours))
+	for _, hour := range request.Hours {
+		openMinute, err := parseClock(hour.OpenTime)
+		if err != nil {
+			return nil, fiber.NewError(fiber.StatusBadRequest, constants.InvalidOperatingHoursMessage)
+		}
+		closeMinute, err := parseClock(hour.CloseTime)
+		if err != nil || closeMinute <= openMinute {
+			return nil, fiber.NewError(fiber.StatusBadRequest, constants.InvalidOperatingHoursMessage)
+		}
+		hours = append(hours, &entity.TerminalOperatingHour{
+			TerminalID:  request.TerminalID,
+			DayOfWeek:   hour.DayOfWeek,
+			OpenMinute:  openMinute,
+			CloseMinute: closeMinute,
+		})
+	}
+
+	slices.SortFunc(hours, func(a, b *entity.TerminalOperatingHour) int {
+		if a.DayOfWeek != b.DayOfWeek {
+			return a.DayOfWeek - b.DayOfWeek
+		}
+		return a.OpenMinute - b.OpenMinute
+	})
+	for i := 1; i < len(hours); i++ {
+		if hours[i].DayOfWeek == hours[i-1].DayOfWeek && hours[i].OpenMinute < hours[i-1].CloseMinute {
+			return nil, fiber.NewError(fiber.StatusBadRequest, constants.InvalidOperatingHoursMessage)
+		}
+	}
+
+	before, err := c.TerminalOperatingHourRepository.FindAllByTerminalId(tx, request.TerminalID)
+	if err != nil {
+		c.Log.Warnf("Failed find operating hours : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := c.TerminalOperatingHourRepository.ReplaceAll(tx, request.TerminalID, hours); err != nil {
+		c.Log.Warnf("Failed replace operating hours : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed commit transaction : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	response := operatingHoursToResponses(hours)
+	audit.SetChange(map[string]any{"hours": operatingHoursToResponses(before)}, map[string]any{"hours": response})
+
+	return response, nil
+}
+
+func (c *TerminalScheduleUseCase) FindClosures(ctx context.Context, terminalID int64) ([]*model.TerminalClosureResponse, error) {
+	db := c.DB.WithContext(ctx)
+	if err := c.ensureTerminalExists(db, terminalID); err != nil {
+		return nil, err
+	}
+
+	closures, err := c.TerminalClosureRepository.FindAllByTerminalId(db, terminalID)
+	if err != nil {
+		c.Log.Warnf("Failed find terminal closures : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	responses := make([]*model.TerminalClosureResponse, 0, len(closures))
+	for _, closure := range closures {
+		responses = append(responses, converter.TerminalClosureToResponse(closure))
+	}
+	return responses, nil
+}
+
+func (c *TerminalScheduleUseCase) CreateClosure(ctx context.Context, auth *model.AuthAdmin, request *model.CreateTerminalClosureRequest) (*model.TerminalClosureResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	audit := helper.Audit(ctx)
+	audit.Action = constants.AuditActionTerminalClosureCreate
+	audit.SetEntity(constants.AuditEntityTerminal, request.TerminalID)
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	startDate, _ := time.Parse(time.DateOnly, request.StartDate)
+	endDate, _ := time.Parse(time.DateOnly, request.EndDate)
+	if endDate.Before(startDate) {
+		return nil, fiber.NewError(fiber.StatusBadRequest, constants.InvalidClosureRangeMessage)
+	}
+
+	if err := c.ensureTerminalExists(tx, request.TerminalID); err != nil {
+		return nil, err
+	}
+
+	closure := &entity.TerminalClosure{
+		TerminalID: request.TerminalID,
+		StartDate:  startDate,
+		EndDate:    endDate,
+		Reason:     request.Reason,
+		CreatedBy:  auth.ID,
+	}
+	if err := c.TerminalClosureRepository.Create(tx, closure); err != nil {
+		c.Log.Warnf("Failed create terminal closure : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed commit transaction : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	response := converter.TerminalClosureToResponse(closure)
+	audit.SetChange(nil, response)
+
+	return response, nil
+}
+
+func (c *TerminalScheduleUseCase) DeleteClosure(ctx context.Context, terminalID int64, closureID int64) error {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	audit := helper.Audit(ctx)
+	audit.Action = constants.AuditActionTerminalClosureDelete
+	audit.SetEntity(constants.AuditEntityTerminal, terminalID)
+
+	closure := new(entity.TerminalClosure)
+	if err := c.TerminalClosureRepository.FindByIdAndTerminalId(tx, closure, closureID, terminalID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, constants.TerminalClosureNotFoundMessage)
+		}
+		c.Log.Warnf("Failed find terminal closure : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+	audit.SetChange(converter.TerminalClosureToResponse(closure), nil)
+
+	if err := c.TerminalClosureRepository.Delete(tx, closure); err != nil {
+		c.Log.Warnf("Failed delete terminal closure : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed commit transaction : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	return nil
+}
+
+func (c *TerminalScheduleUseCase) Status(ctx context.Context, terminalID int64, at time.Time) (*model.TerminalStatusResponse, error) {
+	db := c.DB.WithContext(ctx)
+	if err := c.ensureTerminalExists(db, terminalID); err != nil {
+		return nil, err
+	}
+	return c.status(db, terminalID, at)
+}
+
+// EnsureOpen refuses with TerminalClosedMessage when the terminal is closed at
+// the given moment. Gate check-in calls it with the terminal of the gate.
+func (c *TerminalScheduleUseCase) EnsureOpen(db *gorm.DB, terminalID int64, at time.Time) error {
+	status, err := c.status(db, terminalID, at)
+	if err != nil {
+		return err
+	}
+	if !status.Open {
+		c.Log.Warnf("Terminal %d is closed at %s : %s", terminalID, at.Format(time.RFC3339), status.Reason)
+		return fiber.NewError(fiber.StatusConflict, constants.TerminalClosedMessage)
+	}
+	return nil
+}
+
+func (c *TerminalScheduleUseCase) status(db *gorm.DB, terminalID int64, at time.Time) (*model.TerminalStatusResponse, error) {
+	local := at.In(c.Location)
+	response := &model.TerminalStatusResponse{
+		TerminalID: terminalID,
+		At:         local,
+		Open:       true,
+		Hours:      []*model.OperatingHourResponse{},
+	}
+
+	closure := new(entity.TerminalClosure)
+	err := c.TerminalClosureRepository.FindCovering(db, closure, terminalID, local.Format(time.DateOnly))
+	if err == nil {
+		response.Open = false
+		response.Reason = model.TerminalClosedByClosure
+		response.Closure = converter.TerminalClosureToResponse(closure)
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		c.Log.Warnf("Failed find terminal closure : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	hours, err := c.TerminalOperatingHourRepository.FindAllByTerminalId(db, terminalID)
+	if err != nil {
+		c.Log.Warnf("Failed find operating hours : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if len(hours) == 0 {
+		return response, nil
+	}
+
+	minute := local.Hour()*60 + local.Minute()
+	withinHours := false
+	for _, hour := range hours {
+		if hour.DayOfWeek != int(local.Weekday()) {
+			continue
+		}
+		response.Hours = append(response.Hours, converter.OperatingHourToResponse(hour))
+		if hour.OpenMinute <= minute && minute < hour.CloseMinute {
+			withinHours = true
+		}
+	}
+	if response.Open && !withinHours {
+		response.Open = false
+		response.Reason = model.TerminalClosedOutsideOfHours
+	}
+
+	return response, nil
+}
+
+func (c *TerminalScheduleUseCase) ensureTerminalExists(db *gorm.DB, terminalID int64) error {
+	total, err := c.TerminalRepository.CountById(db, "id_terminal", terminalID)
+	if err != nil {
+		c.Log.Warnf("Failed count terminal : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+	if total == 0 {
+		return fiber.NewError(fiber.StatusNotFound, constants.TerminalNotFoundMessage)
+	}
+	return nil
+}
+
+func operatingHoursToResponses(hours []*entity.TerminalOperatingHour) []*model.OperatingHourResponse {
+	responses := make([]*model.OperatingHourResponse, 0, len(hours))
+	for _, hour := range hours {
+		responses = append(responses, converter.OperatingHourToResponse(hour))
+	}
+	return responses
+}
+
+// parseClock reads "15:04" as minutes from midnight, allowing "24:00" for the
+// end of the day.
+func parseClock(value string) (int, error) {
+	if value == "24:00" {
+		return 24 * 60, nil
+	}
+	clock, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, err
+	}
+	return clock.Hour()*60 + clock.Minute(), nil
+}
@@ -2,30 +2,364 @@ package usecase
 
 import (
 	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"test-kerja-mkp/internal/constants"
 	"test-kerja-mkp/internal/entity"
+	"test-kerja-mkp/internal/helper"
 	"test-kerja-mkp/internal/model"
+	"test-kerja-mkp/internal/model/converter"
 	"test-kerja-mkp/internal/repository"
-	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+const exportBatchSize = 500
+
 type TerminalUseCase struct {
-	Log      *logrus.Logger
-	DB      *gorm.DB
-	Validate *validator.Validate
+	Log                *logrus.Logger
+	DB                 *gorm.DB
+	Validate           *validator.Validate
 	TerminalRepository *repository.TerminalRepository
 }
 
 func NewTerminalUseCase(log *logrus.Logger, TerminalRepository *repository.TerminalRepository, db *gorm.DB, validate *validator.Validate) *TerminalUseCase {
 	return &TerminalUseCase{
-		Log:              log,
-		DB:               db,
-		Validate:         validate,
+		Log:                log,
+		DB:                 db,
+		Validate:           validate,
 		TerminalRepository: TerminalRepository,
 	}
 }
 
+func (c *TerminalUseCase) Create(ctx context.Context, request *model.CreateTerminalRequest) (*model.TerminalResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	audit := helper.Audit(ctx)
+	audit.Action = constants.AuditActionTerminalCreate
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	terminal := &entity.Terminal{
+		Name:       request.Name,
+		Location:   request.Location,
+		Address:    request.Address,
+		RegionCode: request.RegionCode,
+		Latitude:   request.Latitude,
+		Longitude:  request.Longitude,
+	}
+	if err := c.TerminalRepository.Create(tx, terminal); err != nil {
+		c.Log.Warnf("Failed create terminal : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed commit transaction : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	response := converter.TerminalToResponse(terminal)
+	audit.SetEntity(constants.AuditEntityTerminal, terminal.IDTerminal)
+	audit.SetChange(nil, response)
+
+	return response, nil
+}
+
+func (c *TerminalUseCase) FindAll(ctx context.Context, request *model.SearchTerminalRequest) ([]*model.TerminalResponse, *model.PageMetadata, error) {
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %v", err)
+		return nil, nil, fiber.ErrBadRequest
+	}
+
+	terminals, total, err := c.TerminalRepository.FindAll(c.DB.WithContext(ctx), request)
+	if err != nil {
+		return nil, nil, fiber.ErrInternalServerError
+	}
+
+	responses := make([]*model.TerminalResponse, 0, len(terminals))
+	for _, terminal := range terminals {
+		responses = append(responses, converter.TerminalToResponse(terminal))
+	}
+
+	return responses, &model.PageMetadata{
+		Page:      request.Page,
+		Size:      request.Size,
+		TotalItem: total,
+		TotalPage: int64(math.Ceil(float64(total) / float64(request.Size))),
+	}, nil
+}
+
+func (c *TerminalUseCase) FindNearby(ctx context.Context, request *model.NearbyTerminalRequest) ([]*model.NearbyTerminalResponse, error) {
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	terminals, err := c.TerminalRepository.FindNearby(c.DB.WithContext(ctx), request.Latitude, request.Longitude, request.Radius, request.Limit)
+	if err != nil {
+		c.Log.Warnf("Failed find nearby terminals : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	responses := make([]*model.NearbyTerminalResponse, 0, len(terminals))
+	for _, terminal := range terminals {
+		responses = append(responses, converter.TerminalDistanceToResponse(terminal))
+	}
+	return responses, nil
+}
+
+func (c *TerminalUseCase) FindById(ctx context.Context, id int64) (*model.TerminalResponse, error) {
+	terminal := new(entity.Terminal)
+	if err := c.findTerminal(c.DB.WithContext(ctx), terminal, id); err != nil {
+		return nil, err
+	}
+
+	return converter.TerminalToResponse(terminal), nil
+}
+
+func (c *TerminalUseCase) Update(ctx context.Context, request *model.UpdateTerminalRequest) (*model.TerminalResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	audit := helper.Audit(ctx)
+	audit.Action = constants.AuditActionTerminalUpdate
+	audit.SetEntity(constants.AuditEntityTerminal, request.ID)
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	terminal := new(entity.Terminal)
+	if err := c.findTerminal(tx, terminal, request.ID); err != nil {
+		return nil, err
+	}
+	before := converter.TerminalToResponse(terminal)
+
+	terminal.Name = request.Name
+	terminal.Location = request.Location
+	terminal.Address = request.Address
+	terminal.RegionCode = request.RegionCode
+	terminal.Latitude = request.Latitude
+	terminal.Longitude = request.Longitude
+	if err := c.TerminalRepository.Update(tx, terminal); err != nil {
+		c.Log.Warnf("Failed update terminal : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed commit transaction : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	response := converter.TerminalToResponse(terminal)
+	audit.SetChange(before, response)
+
+	return response, nil
+}
+
+// Delete soft deletes a terminal that nothing active depends on. It is refused
+// with a 409 while the terminal has gates in service, fares that are effective
+// now or later, or active journeys: gates must be decommissioned and fares
+// ended first, so no gate credential is left pointing at a deleted terminal.
+// History stays in place and is ignored until the terminal is restored. The
+// row is locked while the references are counted so no check-in slips in
+// between.
+func (c *TerminalUseCase) Delete(ctx context.Context, id int64) error {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	audit := helper.Audit(ctx)
+	audit.Action = constants.AuditActionTerminalDelete
+	audit.SetEntity(constants.AuditEntityTerminal, id)
+
+	terminal := new(entity.Terminal)
+	if err := c.findTerminal(tx.Clauses(clause.Locking{Strength: "UPDATE"}), terminal, id); err != nil {
+		return err
+	}
+	audit.SetChange(converter.TerminalToResponse(terminal), nil)
+
+	references, err := c.TerminalRepository.CountActiveReferences(tx, id)
+	if err != nil {
+		c.Log.Warnf("Failed count terminal references : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+	if references > 0 {
+		return fiber.NewError(fiber.StatusConflict, constants.TerminalInUseMessage)
+	}
+
+	if err := c.TerminalRepository.Delete(tx, terminal); err != nil {
+		c.Log.Warnf("Failed delete terminal : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed commit transaction : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	return nil
+}
+
+func (c *TerminalUseCase) Restore(ctx context.Context, id int64) (*model.TerminalResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	audit := helper.Audit(ctx)
+	audit.Action = constants.AuditActionTerminalRestore
+	audit.SetEntity(constants.AuditEntityTerminal, id)
+
+	terminal := new(entity.Terminal)
+	if err := c.TerminalRepository.FindByIdWithDeleted(tx.Clauses(clause.Locking{Strength: "UPDATE"}), terminal, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.Log.Warnf("Terminal %d not found", id)
+			return nil, fiber.NewError(fiber.StatusNotFound, constants.TerminalNotFoundMessage)
+		}
+		c.Log.Warnf("Failed find terminal : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if !terminal.DeletedAt.Valid {
+		return nil, fiber.NewError(fiber.StatusConflict, constants.TerminalNotDeletedMessage)
+	}
+	before := converter.TerminalToResponse(terminal)
+
+	if err := c.TerminalRepository.Restore(tx, terminal); err != nil {
+		c.Log.Warnf("Failed restore terminal : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed commit transaction : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	response := converter.TerminalToResponse(terminal)
+	audit.SetChange(before, response)
+
+	return response, nil
+}
+
+// Import creates the terminals of a bulk upload in a single transaction. Rows
+// are validated by the caller so every invalid row can be reported at once.
+func (c *TerminalUseCase) Import(ctx context.Context, request *model.ImportTerminalRequest) (*model.ImportTerminalResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	audit := helper.Audit(ctx)
+	audit.Action = constants.AuditActionTerminalImport
+	audit.SetEntity(constants.AuditEntityTerminal, "")
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	terminals := make([]*entity.Terminal, 0, len(request.Rows))
+	for _, row := range request.Rows {
+		terminals = append(terminals, &entity.Terminal{
+			Name:       row.Name,
+			Location:   row.Location,
+			Address:    row.Address,
+			RegionCode: row.RegionCode,
+			Latitude:   row.Latitude,
+			Longitude:  row.Longitude,
+		})
+	}
+	if err := c.TerminalRepository.CreateAll(tx, terminals); err != nil {
+		c.Log.Warnf("Failed import terminals : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	response := &model.ImportTerminalResponse{
+		Total:   len(request.Rows),
+		Created: len(terminals),
+		DryRun:  request.DryRun,
+	}
+	if request.DryRun {
+		return response, nil
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed commit transaction : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	audit.SetChange(nil, response)
+
+	return response, nil
+}
+
+// Export writes the terminals in the import format, csv or json, reading them
+// in batches so the whole list is never held in memory.
+func (c *TerminalUseCase) Export(ctx context.Context, format string, w io.Writer) error {
+	db := c.DB.WithContext(ctx)
+
+	if format == "csv" {
+		writer := csv.NewWriter(w)
+		if err := writer.Write(converter.TerminalCSVHeader); err != nil {
+			return err
+		}
+		err := c.TerminalRepository.FindAllInBatches(db, exportBatchSize, func(terminals []*entity.Terminal) error {
+			for _, terminal := range terminals {
+				if err := writer.Write(converter.TerminalRowToCSV(converter.TerminalToImportRow(terminal))); err != nil {
+					return err
+				}
+			}
+			writer.Flush()
+			return writer.Error()
+		})
+		if err != nil {
+			return err
+		}
+		writer.Flush()
+		return writer.Error()
+	}
+
+	if _, err := io.WriteString(w, "["); err != nil {
+		return err
+	}
+	first := true
+	encoder := json.NewEncoder(w)
+	err := c.TerminalRepository.FindAllInBatches(db, exportBatchSize, func(terminals []*entity.Terminal) error {
+		for _, terminal := range terminals {
+			if !first {
+				if _, err := io.WriteString(w, ","); err != nil {
+					return err
+				}
+			}
+			first = false
+			if err := encoder.Encode(converter.TerminalToImportRow(terminal)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, "]")
+	return err
+}
+
+func (c *TerminalUseCase) findTerminal(db *gorm.DB, terminal *entity.Terminal, id int64) error {
+	if err := c.TerminalRepository.FindById(db, terminal, "id_terminal", id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.Log.Warnf("Terminal %d not found", id)
+			return fiber.NewError(fiber.StatusNotFound, constants.TerminalNotFoundMessage)
+		}
+		c.Log.Warnf("Failed find terminal : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+	return nil
+}
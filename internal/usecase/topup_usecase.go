@@ -0,0 +1,147 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"test-kerja-mkp/internal/constants"
+	"test-kerja-mkp/internal/entity"
+	"test-kerja-mkp/internal/helper"
+	"test-kerja-mkp/internal/model"
+	"test-kerja-mkp/internal/model/converter"
+	"test-kerja-mkp/internal/repository"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// TopUpPolicy limits top-ups.
+type TopUpPolicy struct {
+	MinAmount entity.Money
+	MaxAmount entity.Money
+	// MaxBalance is the highest balance a card may reach through a top-up.
+	MaxBalance entity.Money
+}
+
+// TopUpUseCase adds money to cards. Every top-up is a ledger transaction whose
+// reference number is the idempotency key of the caller, scoped to the
+// channel partner or to the admins.
+type TopUpUseCase struct {
+	DB                    *gorm.DB
+	Log                   *logrus.Logger
+	Validate              *validator.Validate
+	CardRepository        *repository.CardRepository
+	TransactionRepository *repository.TransactionRepository
+	Policy                TopUpPolicy
+}
+
+func NewTopUpUseCase(db *gorm.DB, log *logrus.Logger, validate *validator.Validate, cardRepository *repository.CardRepository,
+	transactionRepository *repository.TransactionRepository, policy TopUpPolicy) *TopUpUseCase {
+	return &TopUpUseCase{
+		DB:                    db,
+		Log:                   log,
+		Validate:              validate,
+		CardRepository:        cardRepository,
+		TransactionRepository: transactionRepository,
+		Policy:                policy,
+	}
+}
+
+// TopUp adds the amount to the card, on behalf of the partner or, when partner
+// is nil, of an admin. A retry with a reference number already used for the
+// same card and amount returns the original top-up; any other reuse of the
+// reference is rejected.
+func (c *TopUpUseCase) TopUp(ctx context.Context, partner *model.AuthPartner, request *model.TopUpRequest) (*model.TopUpResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	audit := helper.Audit(ctx)
+	audit.Action = constants.AuditActionCardTopUp
+	audit.SetEntity(constants.AuditEntityCard, request.CardNumber)
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	amount, err := entity.ParseMoney(request.Amount.String())
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, constants.InvalidAmountMessage)
+	}
+	if amount < c.Policy.MinAmount || amount > c.Policy.MaxAmount {
+		return nil, fiber.NewError(fiber.StatusUnprocessableEntity, constants.TopUpAmountOutOfRangeMessage)
+	}
+
+	var partnerID *string
+	scope := "admin"
+	if partner != nil {
+		partnerID = &partner.ID
+		scope = partner.ID
+	}
+	if err := c.TransactionRepository.LockReference(tx, scope, request.ReferenceNumber); err != nil {
+		c.Log.Warnf("Failed lock reference number : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	previous := new(entity.Transaction)
+	err = c.TransactionRepository.FindTopUpByReference(tx, previous, partnerID, request.ReferenceNumber)
+	if err == nil {
+		if previous.CardNumber != request.CardNumber || previous.Amount != amount {
+			return nil, fiber.NewError(fiber.StatusConflict, constants.ReferenceNumberConflictMessage)
+		}
+		return converter.TransactionToTopUpResponse(previous), nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		c.Log.Warnf("Failed find top-up : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	card := new(entity.Card)
+	if err := c.CardRepository.FindByIdForUpdate(tx, card, request.CardNumber); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fiber.NewError(fiber.StatusNotFound, constants.CardNotFoundMessage)
+		}
+		c.Log.Warnf("Failed find card : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+	if card.Status != entity.CardStatusActive {
+		return nil, fiber.NewError(fiber.StatusConflict, constants.CardNotActiveMessage)
+	}
+	if card.Balance+amount > c.Policy.MaxBalance {
+		return nil, fiber.NewError(fiber.StatusUnprocessableEntity, constants.MaxBalanceExceededMessage)
+	}
+
+	transaction := &entity.Transaction{
+		CardNumber:      card.CardNumber,
+		Type:            entity.TransactionTypeTopUp,
+		Amount:          amount,
+		BalanceBefore:   card.Balance,
+		BalanceAfter:    card.Balance + amount,
+		ReferenceNumber: &request.ReferenceNumber,
+		PartnerID:       partnerID,
+		Timestamp:       time.Now(),
+		SyncStatus:      entity.SyncStatusSynced,
+	}
+	if err := c.TransactionRepository.Create(tx, transaction); err != nil {
+		c.Log.Warnf("Failed create top-up transaction : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	before := converter.CardToResponse(card)
+	card.Balance = transaction.BalanceAfter
+	if err := c.CardRepository.Update(tx, card); err != nil {
+		c.Log.Warnf("Failed update card balance : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed commit transaction : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	audit.SetChange(before, converter.CardToResponse(card))
+
+	return converter.TransactionToTopUpResponse(transaction), nil
+}
@@ -0,0 +1,586 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"strings"
+	"test-kerja-mkp/internal/constants"
+	"test-kerja-mkp/internal/entity"
+	"test-kerja-mkp/internal/helper"
+	"test-kerja-mkp/internal/model"
+	"test-kerja-mkp/internal/model/converter"
+	"test-kerja-mkp/internal/repository"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	recoveryCodeCount = 10
+	// totpSkew accepts codes from one period before and after the current one
+	// to tolerate clock drift on the authenticator.
+	totpSkew = 1
+)
+
+type TwoFactorUseCase struct {
+	DB                                 *gorm.DB
+	Log                                *logrus.Logger
+	Validate                           *validator.Validate
+	AuthRepository                     *repository.AuthRepository
+	AdminRecoveryCodeRepository        *repository.AdminRecoveryCodeRepository
+	AdminTwoFactorEnrollmentRepository *repository.AdminTwoFactorEnrollmentRepository
+	AuthUseCase                        *AuthUseCase
+	Issuer                             string
+	EnrollmentTokenTTL                 time.Duration
+}
+
+func NewTwoFactorUseCase(db *gorm.DB, log *logrus.Logger, validate *validator.Validate, authRepository *repository.AuthRepository,
+	adminRecoveryCodeRepository *repository.AdminRecoveryCodeRepository, adminTwoFactorEnrollmentRepository *repository.AdminTwoFactorEnrollmentRepository,
+	authUseCase *AuthUseCase, issuer string, enrollmentTokenTTL time.Duration) *TwoFactorUseCase {
+	return &TwoFactorUseCase{
+		DB:                                 db,
+		Log:                                log,
+		Validate:                           validate,
+		AuthRepository:                     authRepository,
+		AdminRecoveryCodeRepository:        adminRecoveryCodeRepository,
+		AdminTwoFactorEnrollmentRepository: adminTwoFactorEnrollmentRepository,
+		AuthUseCase:                        authUseCase,
+		Issuer:                             issuer,
+		EnrollmentTokenTTL:                 enrollmentTokenTTL,
+	}
+}
+
+// VerifyLogin completes a two-step login with a TOTP code or a recovery code.
+// Failed codes count towards the login lockout of the admin.
+func (c *TwoFactorUseCase) VerifyLogin(ctx context.Context, request *model.VerifyTwoFactorLoginRequest) (*model.LoginAdminResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	audit := helper.Audit(ctx)
+	audit.Action = constants.AuditActionLoginTwoFactor
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	adminID, err := c.AuthUseCase.parseChallenge(request.ChallengeToken, challengeTypeVerify)
+	if err != nil {
+		return nil, err
+	}
+
+	admin := new(entity.Admin)
+	if err := c.findAdminForUpdate(tx, admin, adminID); err != nil {
+		return nil, fiber.ErrUnauthorized
+	}
+	audit.SetEntity(constants.AuditEntityAdmin, admin.ID)
+	audit.ActorUsername = admin.Username
+	if !admin.IsActive || !admin.TOTPEnabled {
+		c.Log.Warnf("Admin %d cannot complete two-factor login", admin.ID)
+		return nil, fiber.ErrUnauthorized
+	}
+
+	throttle := c.AuthUseCase.LoginThrottleUseCase
+	if err := throttle.Check(ctx, admin.Username, request.IPAddress); err != nil {
+		return nil, err
+	}
+
+	if request.Code != "" {
+		err = c.verifyCode(tx, admin, request.Code)
+	} else {
+		err = c.useRecoveryCode(tx, admin, request.RecoveryCode)
+	}
+	if err != nil {
+		throttle.RecordFailure(ctx, admin.Username, request.IPAddress)
+		return nil, err
+	}
+
+	token, err := c.AuthUseCase.startSession(tx, admin, request.IPAddress, request.UserAgent)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed commit transaction : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	throttle.RecordSuccess(ctx, admin.Username)
+	audit.SetActor(admin.ID, admin.Username)
+
+	return &model.LoginAdminResponse{
+		Token: token,
+		Admin: converter.AdminToResponse(admin),
+	}, nil
+}
+
+// IssueEnrollment creates a one-time token that lets another admin enroll 2FA
+// on first login. Any previously issued token that has not been used yet
+// stops working.
+func (c *TwoFactorUseCase) IssueEnrollment(ctx context.Context, auth *model.AuthAdmin, adminID int64) (*model.TwoFactorEnrollmentResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	audit := helper.Audit(ctx)
+	audit.Action = constants.AuditActionTwoFactorEnrollIssue
+	audit.SetEntity(constants.AuditEntityAdmin, adminID)
+
+	admin := new(entity.Admin)
+	if err := c.findAdminForUpdate(tx, admin, adminID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fiber.ErrNotFound
+		}
+		return nil, fiber.ErrInternalServerError
+	}
+	if admin.TOTPEnabled {
+		return nil, fiber.NewError(fiber.StatusConflict, constants.TwoFactorAlreadyEnabledMessage)
+	}
+
+	if err := c.AdminTwoFactorEnrollmentRepository.InvalidatePending(tx, admin.ID); err != nil {
+		c.Log.Warnf("Failed invalidate pending 2fa enrollments : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	token, err := randomToken(32)
+	if err != nil {
+		c.Log.Warnf("Failed generate 2fa enrollment token : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	enrollment := &entity.AdminTwoFactorEnrollment{
+		AdminID:   admin.ID,
+		TokenHash: hashToken(token),
+		CreatedBy: auth.ID,
+		ExpiresAt: time.Now().Add(c.EnrollmentTokenTTL),
+	}
+	if err := c.AdminTwoFactorEnrollmentRepository.Create(tx, enrollment); err != nil {
+		c.Log.Warnf("Failed create 2fa enrollment : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed commit transaction : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return &model.TwoFactorEnrollmentResponse{
+		AdminID:         admin.ID,
+		EnrollmentToken: token,
+		ExpiresAt:       enrollment.ExpiresAt,
+	}, nil
+}
+
+// EnrollWithChallenge starts enrollment for an admin whose role requires 2FA
+// but who has not set it up yet, using the challenge returned by LoginAdmin
+// and an enrollment token issued by another admin.
+func (c *TwoFactorUseCase) EnrollWithChallenge(ctx context.Context, request *model.EnrollTwoFactorChallengeRequest) (*model.TwoFactorSetupResponse, error) {
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	adminID, err := c.AuthUseCase.parseChallenge(request.ChallengeToken, challengeTypeEnroll)
+	if err != nil {
+		return nil, err
+	}
+
+	admin := new(entity.Admin)
+	if err := c.AuthRepository.FindById(c.DB.WithContext(ctx), admin, "id_admin", adminID); err != nil {
+		c.Log.Warnf("Failed to find admin by ID: %+v ", err)
+		return nil, fiber.ErrUnauthorized
+	}
+	if !admin.IsActive {
+		c.Log.Warnf("Admin %d is disabled", admin.ID)
+		return nil, fiber.ErrUnauthorized
+	}
+
+	throttle := c.AuthUseCase.LoginThrottleUseCase
+	if err := throttle.Check(ctx, admin.Username, request.IPAddress); err != nil {
+		return nil, err
+	}
+
+	enrollment := new(entity.AdminTwoFactorEnrollment)
+	if err := c.findEnrollment(c.DB.WithContext(ctx), enrollment, admin.ID, request.EnrollmentToken); err != nil {
+		throttle.RecordFailure(ctx, admin.Username, request.IPAddress)
+		return nil, err
+	}
+
+	return c.Setup(ctx, admin.ID)
+}
+
+// ConfirmWithChallenge finishes enrollment started with EnrollWithChallenge,
+// consumes the enrollment token and completes the login. Wrong enrollment
+// tokens and codes count towards the login lockout of the admin.
+func (c *TwoFactorUseCase) ConfirmWithChallenge(ctx context.Context, request *model.ConfirmTwoFactorChallengeRequest) (*model.LoginAdminResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	audit := helper.Audit(ctx)
+	audit.Action = constants.AuditActionTwoFactorEnable
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	adminID, err := c.AuthUseCase.parseChallenge(request.ChallengeToken, challengeTypeEnroll)
+	if err != nil {
+		return nil, err
+	}
+
+	admin := new(entity.Admin)
+	if err := c.findAdminForUpdate(tx, admin, adminID); err != nil {
+		return nil, fiber.ErrUnauthorized
+	}
+	audit.SetEntity(constants.AuditEntityAdmin, admin.ID)
+	audit.SetActor(admin.ID, admin.Username)
+	if !admin.IsActive {
+		c.Log.Warnf("Admin %d is disabled", admin.ID)
+		return nil, fiber.ErrUnauthorized
+	}
+
+	throttle := c.AuthUseCase.LoginThrottleUseCase
+	if err := throttle.Check(ctx, admin.Username, request.IPAddress); err != nil {
+		return nil, err
+	}
+
+	enrollment := new(entity.AdminTwoFactorEnrollment)
+	if err := c.findEnrollment(tx.Clauses(clause.Locking{Strength: "UPDATE"}), enrollment, admin.ID, request.EnrollmentToken); err != nil {
+		throttle.RecordFailure(ctx, admin.Username, request.IPAddress)
+		return nil, err
+	}
+
+	recoveryCodes, err := c.enable(tx, admin, request.Code)
+	if err != nil {
+		var fiberErr *fiber.Error
+		if errors.As(err, &fiberErr) && fiberErr.Code == fiber.StatusUnauthorized {
+			throttle.RecordFailure(ctx, admin.Username, request.IPAddress)
+		}
+		return nil, err
+	}
+
+	now := time.Now()
+	enrollment.UsedAt = &now
+	if err := c.AdminTwoFactorEnrollmentRepository.Update(tx, enrollment); err != nil {
+		c.Log.Warnf("Failed update 2fa enrollment : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	token, err := c.AuthUseCase.startSession(tx, admin, request.IPAddress, request.UserAgent)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed commit transaction : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	throttle.RecordSuccess(ctx, admin.Username)
+
+	return &model.LoginAdminResponse{
+		Token:         token,
+		Admin:         converter.AdminToResponse(admin),
+		RecoveryCodes: recoveryCodes,
+	}, nil
+}
+
+// Setup generates a new TOTP secret for the admin. The secret is only used
+// for login after it has been confirmed with a valid code.
+func (c *TwoFactorUseCase) Setup(ctx context.Context, adminID int64) (*model.TwoFactorSetupResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	audit := helper.Audit(ctx)
+	audit.Action = constants.AuditActionTwoFactorSetup
+	audit.SetEntity(constants.AuditEntityAdmin, adminID)
+
+	admin := new(entity.Admin)
+	if err := c.findAdminForUpdate(tx, admin, adminID); err != nil {
+		return nil, fiber.ErrUnauthorized
+	}
+	if admin.TOTPEnabled {
+		return nil, fiber.NewError(fiber.StatusConflict, constants.TwoFactorAlreadyEnabledMessage)
+	}
+
+	secret, err := helper.GenerateTOTPSecret()
+	if err != nil {
+		c.Log.Warnf("Failed generate totp secret : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Model(admin).Update("totp_secret", secret).Error; err != nil {
+		c.Log.Warnf("Failed update totp secret : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed commit transaction : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return &model.TwoFactorSetupResponse{
+		Secret:          secret,
+		ProvisioningURI: helper.TOTPProvisioningURI(c.Issuer, admin.Username, secret),
+	}, nil
+}
+
+// Confirm enables 2FA for an authenticated admin and returns fresh recovery
+// codes. The codes are only shown once.
+func (c *TwoFactorUseCase) Confirm(ctx context.Context, auth *model.AuthAdmin, request *model.ConfirmTwoFactorRequest) (*model.RecoveryCodesResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	audit := helper.Audit(ctx)
+	audit.Action = constants.AuditActionTwoFactorEnable
+	audit.SetEntity(constants.AuditEntityAdmin, auth.ID)
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	admin := new(entity.Admin)
+	if err := c.findAdminForUpdate(tx, admin, auth.ID); err != nil {
+		return nil, fiber.ErrUnauthorized
+	}
+
+	recoveryCodes, err := c.enable(tx, admin, request.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed commit transaction : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return &model.RecoveryCodesResponse{RecoveryCodes: recoveryCodes}, nil
+}
+
+// Disable turns 2FA off after checking the password and a current code. It is
+// refused when the role of the admin requires 2FA.
+func (c *TwoFactorUseCase) Disable(ctx context.Context, auth *model.AuthAdmin, request *model.DisableTwoFactorRequest) error {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	audit := helper.Audit(ctx)
+	audit.Action = constants.AuditActionTwoFactorDisable
+	audit.SetEntity(constants.AuditEntityAdmin, auth.ID)
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %v", err)
+		return fiber.ErrBadRequest
+	}
+
+	admin := new(entity.Admin)
+	if err := c.findAdminForUpdate(tx, admin, auth.ID); err != nil {
+		return fiber.ErrUnauthorized
+	}
+	if !admin.TOTPEnabled {
+		return fiber.NewError(fiber.StatusConflict, constants.TwoFactorNotEnabledMessage)
+	}
+	if admin.Role != nil && admin.Role.Require2FA {
+		return fiber.NewError(fiber.StatusUnprocessableEntity, constants.TwoFactorRequiredByRoleMessage)
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(admin.Password), []byte(request.Password)); err != nil {
+		c.Log.Warnf("Current password does not match: %v", err)
+		return fiber.NewError(fiber.StatusUnprocessableEntity, constants.InvalidCurrentPasswordMessage)
+	}
+	if err := c.verifyCode(tx, admin, request.Code); err != nil {
+		return err
+	}
+
+	if err := tx.Model(admin).Updates(map[string]any{
+		"totp_secret":    "",
+		"totp_enabled":   false,
+		"totp_last_step": 0,
+	}).Error; err != nil {
+		c.Log.Warnf("Failed disable totp : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	if err := c.AdminRecoveryCodeRepository.DeleteByAdminId(tx, admin.ID); err != nil {
+		c.Log.Warnf("Failed delete recovery codes : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed commit transaction : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	return nil
+}
+
+// RegenerateRecoveryCodes replaces every recovery code of the admin.
+func (c *TwoFactorUseCase) RegenerateRecoveryCodes(ctx context.Context, auth *model.AuthAdmin, request *model.ConfirmTwoFactorRequest) (*model.RecoveryCodesResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	audit := helper.Audit(ctx)
+	audit.Action = constants.AuditActionTwoFactorRecoveryCodes
+	audit.SetEntity(constants.AuditEntityAdmin, auth.ID)
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body: %v", err)
+		return nil, fiber.ErrBadRequest
+	}
+
+	admin := new(entity.Admin)
+	if err := c.findAdminForUpdate(tx, admin, auth.ID); err != nil {
+		return nil, fiber.ErrUnauthorized
+	}
+	if !admin.TOTPEnabled {
+		return nil, fiber.NewError(fiber.StatusConflict, constants.TwoFactorNotEnabledMessage)
+	}
+	if err := c.verifyCode(tx, admin, request.Code); err != nil {
+		return nil, err
+	}
+
+	recoveryCodes, err := c.replaceRecoveryCodes(tx, admin.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.Warnf("Failed commit transaction : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return &model.RecoveryCodesResponse{RecoveryCodes: recoveryCodes}, nil
+}
+
+// enable checks the first code generated from the pending secret, turns 2FA
+// on and issues recovery codes.
+func (c *TwoFactorUseCase) enable(tx *gorm.DB, admin *entity.Admin, code string) ([]string, error) {
+	if admin.TOTPEnabled {
+		return nil, fiber.NewError(fiber.StatusConflict, constants.TwoFactorAlreadyEnabledMessage)
+	}
+	if admin.TOTPSecret == "" {
+		return nil, fiber.NewError(fiber.StatusConflict, constants.TwoFactorNotSetUpMessage)
+	}
+
+	if err := c.verifyCode(tx, admin, code); err != nil {
+		return nil, err
+	}
+
+	admin.TOTPEnabled = true
+	if err := tx.Model(admin).Update("totp_enabled", true).Error; err != nil {
+		c.Log.Warnf("Failed enable totp : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return c.replaceRecoveryCodes(tx, admin.ID)
+}
+
+// verifyCode checks a TOTP code and remembers its time step so the same code
+// cannot be used twice.
+func (c *TwoFactorUseCase) verifyCode(tx *gorm.DB, admin *entity.Admin, code string) error {
+	step, ok := helper.ValidateTOTP(admin.TOTPSecret, code, time.Now(), totpSkew, admin.TOTPLastStep)
+	if !ok {
+		c.Log.Warnf("Invalid totp code for admin %d", admin.ID)
+		return fiber.NewError(fiber.StatusUnauthorized, constants.InvalidTwoFactorCodeMessage)
+	}
+
+	admin.TOTPLastStep = step
+	if err := tx.Model(admin).Update("totp_last_step", step).Error; err != nil {
+		c.Log.Warnf("Failed update totp step : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+	return nil
+}
+
+func (c *TwoFactorUseCase) useRecoveryCode(tx *gorm.DB, admin *entity.Admin, code string) error {
+	recoveryCode := new(entity.AdminRecoveryCode)
+	if err := c.AdminRecoveryCodeRepository.FindUnusedForUpdate(tx, recoveryCode, admin.ID, hashToken(normalizeRecoveryCode(code))); err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			c.Log.Warnf("Failed find recovery code : %+v", err)
+			return fiber.ErrInternalServerError
+		}
+		c.Log.Warnf("Invalid recovery code for admin %d", admin.ID)
+		return fiber.NewError(fiber.StatusUnauthorized, constants.InvalidTwoFactorCodeMessage)
+	}
+
+	now := time.Now()
+	recoveryCode.UsedAt = &now
+	if err := c.AdminRecoveryCodeRepository.Update(tx, recoveryCode); err != nil {
+		c.Log.Warnf("Failed update recovery code : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+	return nil
+}
+
+func (c *TwoFactorUseCase) replaceRecoveryCodes(tx *gorm.DB, adminID int64) ([]string, error) {
+	if err := c.AdminRecoveryCodeRepository.DeleteByAdminId(tx, adminID); err != nil {
+		c.Log.Warnf("Failed delete recovery codes : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	codes := make([]string, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		code, err := generateRecoveryCode()
+		if err != nil {
+			c.Log.Warnf("Failed generate recovery code : %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+
+		if err := c.AdminRecoveryCodeRepository.Create(tx, &entity.AdminRecoveryCode{
+			AdminID:  adminID,
+			CodeHash: hashToken(normalizeRecoveryCode(code)),
+		}); err != nil {
+			c.Log.Warnf("Failed create recovery code : %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+		codes = append(codes, code)
+	}
+	return codes, nil
+}
+
+// findEnrollment looks up an enrollment token and checks that it belongs to
+// the admin and can still be used.
+func (c *TwoFactorUseCase) findEnrollment(db *gorm.DB, enrollment *entity.AdminTwoFactorEnrollment, adminID int64, token string) error {
+	if err := c.AdminTwoFactorEnrollmentRepository.FindByTokenHash(db, enrollment, hashToken(token)); err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			c.Log.Warnf("Failed find 2fa enrollment : %+v", err)
+			return fiber.ErrInternalServerError
+		}
+		c.Log.Warnf("2fa enrollment token not found for admin %d", adminID)
+		return fiber.NewError(fiber.StatusUnauthorized, constants.InvalidEnrollmentTokenMessage)
+	}
+	if !enrollment.IsUsable(adminID, time.Now()) {
+		c.Log.Warnf("2fa enrollment %d is not usable by admin %d", enrollment.ID, adminID)
+		return fiber.NewError(fiber.StatusUnauthorized, constants.InvalidEnrollmentTokenMessage)
+	}
+	return nil
+}
+
+func (c *TwoFactorUseCase) findAdminForUpdate(tx *gorm.DB, admin *entity.Admin, adminID int64) error {
+	if err := c.AuthRepository.FindByIdWithRole(tx.Clauses(clause.Locking{Strength: "UPDATE", Table: clause.Table{Name: clause.CurrentTable}}), admin, adminID); err != nil {
+		c.Log.Warnf("Failed to find admin by ID: %+v ", err)
+		return err
+	}
+	return nil
+}
+
+// generateRecoveryCode returns a code formatted as XXXXX-XXXXX.
+func generateRecoveryCode() (string, error) {
+	buf := make([]byte, 7)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	code := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(buf)[:10]
+	return code[:5] + "-" + code[5:], nil
+}
+
+func normalizeRecoveryCode(code string) string {
+	return strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+}